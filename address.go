@@ -0,0 +1,112 @@
+package reqbind
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Address is a postal address split into its components
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code
+	Country string `json:"country,omitempty"`
+}
+
+// String is the address on one line, its components joined with commas
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.Line1, a.Line2, a.City, strings.TrimSpace(a.Region + " " + a.PostalCode), a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AddressNormalizer turns the address a client typed into its components,
+// plug in libpostal or an address api with WithAddressNormalizer. Return an
+// error for addresses that don't exist, a ThrottledError when the api is
+// rate limiting.
+type AddressNormalizer interface {
+	Normalize(ctx context.Context, address string) (Address, error)
+}
+
+// AddressNormalizerFunc adapts a function to AddressNormalizer
+type AddressNormalizerFunc func(ctx context.Context, address string) (Address, error)
+
+// Normalize calls f
+func (f AddressNormalizerFunc) Normalize(ctx context.Context, address string) (Address, error) {
+	return f(ctx, address)
+}
+
+// WithAddressNormalizer sets the normalizer for validate:"address" fields,
+// it's called with the request context. Without one addresses only have
+// their whitespace cleaned up and end up whole in Line1.
+func WithAddressNormalizer(normalizer AddressNormalizer) Option {
+	return func(b *Binder) {
+		b.address = normalizer
+	}
+}
+
+// normalizeAddress returns the address check for a request
+func (b *Binder) normalizeAddress(ctx context.Context) func(address string) (Address, error) {
+	if b.address == nil {
+		return nil
+	}
+	return func(address string) (Address, error) {
+		return b.address.Normalize(ctx, address)
+	}
+}
+
+// checkAddress normalizes a validate:"address" field, the value becomes the
+// normalized address on one line and an into=Field option copies the
+// components into that sibling Address field
+func checkAddress(parent reflect.Value, f reflect.StructField, value reflect.Value, options map[string]string, opts checkOptions) error {
+	// leave empty values to the required check
+	if value.String() == "" {
+		return nil
+	}
+	address := strings.Join(strings.Fields(value.String()), " ")
+	normalized := Address{Line1: address}
+	if opts.normalizeAddress != nil && address != "" {
+		var err error
+		if normalized, err = opts.normalizeAddress(address); err != nil {
+			if errors.Is(err, ErrThrottled) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			return fieldErrorf(CodeInvalidAddress, f.Name, "is invalid: %s", err)
+		}
+	}
+	if normalized.Line1 == "" {
+		return fieldErrorf(CodeInvalidAddress, f.Name, "is invalid: address has no street")
+	}
+	if normalized.Country != "" {
+		if err := validateCountry(normalized.Country); err != nil {
+			return fieldErrorf(CodeInvalidAddress, f.Name, "is invalid: %s", err)
+		}
+	}
+
+	if into := options["into"]; into != "" {
+		var sibling reflect.Value
+		ok := parent.IsValid()
+		if ok {
+			sibling, ok = fieldByNameFold(parent, into)
+		}
+		if !ok || derefType(sibling.Type()) != reflect.TypeOf(Address{}) {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid address field %s", into)
+		}
+		if sibling.Kind() == reflect.Ptr {
+			sibling.Set(reflect.New(sibling.Type().Elem()))
+			sibling = sibling.Elem()
+		}
+		sibling.Set(reflect.ValueOf(normalized))
+	}
+	value.SetString(normalized.String())
+	return nil
+}
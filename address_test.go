@@ -0,0 +1,77 @@
+package reqbind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testNormalizer knows one address, and tells tenants apart by context
+var testNormalizer = AddressNormalizerFunc(func(ctx context.Context, address string) (Address, error) {
+	switch {
+	case ctx.Value(tenantKey{}) == "throttled":
+		return Address{}, Throttled(time.Second)
+	case strings.EqualFold(address, "1 main st springfield il"):
+		return Address{Line1: "1 Main St", City: "Springfield", Region: "IL", PostalCode: "62701", Country: "US"}, nil
+	case address == "bad country":
+		return Address{Line1: "1 Main St", Country: "ZZ"}, nil
+	}
+	return Address{}, errors.New("address not found")
+})
+
+type shipping struct {
+	Address  string   `json:"address" required:"true" validate:"address,into=Location"`
+	Location *Address `json:"-"`
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer AddressNormalizer
+		tenant     string
+		address    string
+		expected   string
+		location   *Address
+		err        string
+	}{
+		{name: "normalized", normalizer: testNormalizer, address: "1  Main St\n Springfield IL", expected: "1 Main St, Springfield, IL 62701, US", location: &Address{Line1: "1 Main St", City: "Springfield", Region: "IL", PostalCode: "62701", Country: "US"}},
+		{name: "not found", normalizer: testNormalizer, address: "nowhere", err: "field Address is invalid: address not found"},
+		{name: "bad country", normalizer: testNormalizer, address: "bad country", err: "field Address is invalid: invalid country code"},
+		{name: "throttled", normalizer: testNormalizer, tenant: "throttled", address: "1 Main St Springfield IL", err: "request was throttled, retry after 1s"},
+		{name: "no normalizer", address: " 1  Main St ", expected: "1 Main St", location: &Address{Line1: "1 Main St"}},
+		{name: "blank", address: "  ", err: "field Address is invalid: address has no street"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := `{"address":` + strconv.Quote(test.address) + `}`
+			request := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+			request = request.WithContext(context.WithValue(request.Context(), tenantKey{}, test.tenant))
+			s := &shipping{}
+			err := New(WithAddressNormalizer(test.normalizer)).UnmarshalBody(request, s)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, s.Address)
+			require.Equal(t, test.location, s.Location)
+		})
+	}
+}
+
+func TestAddressInvalidInto(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"address":"1 Main St"}`))
+	s := &struct {
+		Address  string `json:"address" validate:"address,into=Location"`
+		Location string
+	}{}
+	require.EqualError(t, UnmarshalBody(request, s), "field Address has invalid address field Location")
+}
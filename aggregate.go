@@ -0,0 +1,90 @@
+package reqbind
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Errors is every problem found with a request when the binder aggregates
+// errors, in the order the fields are declared with nested fields after
+// their parent
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As look at each error
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// WithAggregateErrors keeps checking after a field fails and returns every
+// failed field as Errors. Each field still stops at its first failed check
+// unless it's tagged stop-on-first:"false".
+func WithAggregateErrors() Option {
+	return func(b *Binder) {
+		b.aggregate = true
+	}
+}
+
+// stopOnFirst is false for fields that report all their failed checks
+func stopOnFirst(f reflect.StructField, opts checkOptions) bool {
+	return !opts.aggregate || f.Tag.Get("stop-on-first") != "false"
+}
+
+// collector gathers the errors of a set of checks, unless all is set it
+// stops at the first one
+type collector struct {
+	all  bool
+	errs Errors
+	// field is the index of the field being checked, errors are kept in
+	// field order even when fields are checked out of order
+	field  int
+	fields []int
+}
+
+// add records err, flattening Errors, and reports whether to stop checking
+func (c *collector) add(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errs, ok := err.(Errors); ok {
+		c.errs = append(c.errs, errs...)
+	} else {
+		c.errs = append(c.errs, err)
+	}
+	for len(c.fields) < len(c.errs) {
+		c.fields = append(c.fields, c.field)
+	}
+	return !c.all
+}
+
+// err is nil, the only error, or Errors when there's more than one
+func (c *collector) err() error {
+	switch len(c.errs) {
+	case 0:
+		return nil
+	case 1:
+		return c.errs[0]
+	}
+	sort.Stable(byField{c})
+	return c.errs
+}
+
+// byField sorts a collector's errors by field
+type byField struct {
+	c *collector
+}
+
+func (b byField) Len() int           { return len(b.c.errs) }
+func (b byField) Less(i, j int) bool { return b.c.fields[i] < b.c.fields[j] }
+func (b byField) Swap(i, j int) {
+	b.c.errs[i], b.c.errs[j] = b.c.errs[j], b.c.errs[i]
+	b.c.fields[i], b.c.fields[j] = b.c.fields[j], b.c.fields[i]
+}
@@ -0,0 +1,103 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type signupRequest struct {
+	Name     string   `json:"name" required:"true"`
+	Email    string   `json:"email" max-length:"12" validate:"email" stop-on-first:"false"`
+	Backup   string   `json:"backup" max-length:"12" validate:"email"`
+	Tags     []string `json:"tags" max-items:"2" unique:"true" stop-on-first:"false"`
+	Settings struct {
+		Theme string `json:"theme" max-length:"4"`
+	} `json:"settings"`
+}
+
+func TestAggregateErrors(t *testing.T) {
+	body := `{"email":"not-an-email-address","backup":"not-an-email-address","tags":["a","b","a"],"settings":{"theme":"solarized"}}`
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	err = New(WithAggregateErrors()).UnmarshalBody(request, &signupRequest{})
+	require.Error(t, err)
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, []string{
+		"field Name is required",
+		// stop-on-first:"false" reports every failed check
+		"field Email is too long",
+		"field Email is invalid: invalid email address",
+		// other fields stop at the first
+		"field Backup is too long",
+		"field Tags has more than 2 items",
+		"field Tags has duplicate items at [0 2]",
+		"field Theme is too long",
+	}, errorMessages(errs))
+
+	// without aggregate mode the first error is returned on its own
+	request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &signupRequest{}), "field Name is required")
+}
+
+func TestAggregateErrorsSingle(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","backup":"c@d.com"}`))
+	require.NoError(t, err)
+	err = New(WithAggregateErrors()).UnmarshalBody(request, &signupRequest{})
+	require.EqualError(t, err, "field Name is required")
+	require.False(t, errors.As(err, &Errors{}))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"a","email":"a@b.com","backup":"c@d.com"}`))
+	require.NoError(t, err)
+	require.NoError(t, New(WithAggregateErrors()).UnmarshalBody(request, &signupRequest{}))
+}
+
+func TestAggregateErrorsIs(t *testing.T) {
+	type project struct {
+		Name  string `json:"name" required:"true"`
+		Owner string `json:"owner" allow-roles:"admin"`
+	}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"owner":"me"}`))
+	require.NoError(t, err)
+	err = New(WithAggregateErrors()).UnmarshalBody(request, &project{})
+	require.EqualError(t, err, "field Name is required; forbidden: field Owner can not be set")
+	require.ErrorIs(t, err, ErrForbidden)
+}
+
+func errorMessages(errs Errors) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
+func TestAggregateErrorOrder(t *testing.T) {
+	type line struct {
+		SKU string `json:"sku" required:"true"`
+		Qty string `json:"qty" required:"true"`
+	}
+	type order struct {
+		Name     string `json:"name" max-length:"5"`
+		NameSlug string `json:"nameSlug" derive:"slug(Name)" max-length:"3"`
+		Lines    []line `json:"lines"`
+		Email    string `json:"email" validate:"email"`
+	}
+
+	binder := New(WithAggregateErrors(), WithParallelItems(2, 4))
+	for i := 0; i < 20; i++ {
+		request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"abcdefg","lines":[{"sku":"a"},{},{"qty":"1"}],"email":"nope"}`))
+		require.NoError(t, err)
+		err = binder.UnmarshalBody(request, &order{})
+		require.EqualError(t, err, "field Name is too long; field NameSlug is too long; "+
+			"field Lines item 0: field Qty is required; field Lines item 1: field SKU is required; field Lines item 1: field Qty is required; "+
+			"field Lines item 2: field SKU is required; field Email is invalid: invalid email address")
+	}
+}
@@ -0,0 +1,153 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// aliasField is a top level field tagged aliases:"user_id,uid"
+type aliasField struct {
+	name    string
+	aliases []string
+}
+
+// aliasFields returns the fields of the struct v points to that have aliases
+func aliasFields(v interface{}) []aliasField {
+	t := reflect.TypeOf(v).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []aliasField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("aliases") == "" || isIgnored(f) {
+			continue
+		}
+		field := aliasField{name: jsonName(f)}
+		for _, alias := range strings.Split(f.Tag.Get("aliases"), ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				field.aliases = append(field.aliases, alias)
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// pick decides which of the sent keys a field is bound from. The field's
+// own name beats its aliases, and earlier aliases beat later ones. It
+// returns the alias to use, or "" when the name was sent or nothing was.
+func (field aliasField) pick(sent func(name string) bool) string {
+	if sent(field.name) {
+		return ""
+	}
+	for _, alias := range field.aliases {
+		if sent(alias) {
+			return alias
+		}
+	}
+	return ""
+}
+
+// aliasQuery renames query keys sent under an alias to the field's json
+// name, e.g. ?uid=1 to ?userId=1, and drops the aliases that lost. r isn't
+// changed, a copy with the new query is returned. The aliases used are
+// recorded in aliases, keyed by json name.
+func aliasQuery(r *http.Request, v interface{}, aliases map[string]string) *http.Request {
+	fields := aliasFields(v)
+	if len(fields) == 0 || r.URL.RawQuery == "" {
+		return r
+	}
+	query := r.URL.Query()
+	// the first segment of the key is what's renamed, so uid[0] and uid.x
+	// go along with uid
+	firstSegment := func(key string) string {
+		return parseQueryKey(key)[0]
+	}
+	changed := false
+	for _, field := range fields {
+		alias := field.pick(func(name string) bool {
+			for key := range query {
+				if strings.EqualFold(firstSegment(key), name) {
+					return true
+				}
+			}
+			return false
+		})
+		for key, values := range query {
+			first := firstSegment(key)
+			if !containsFold(field.aliases, first) {
+				continue
+			}
+			delete(query, key)
+			changed = true
+			if strings.EqualFold(first, alias) {
+				query[field.name+key[len(first):]] = values
+			}
+		}
+		if alias != "" {
+			aliases[strings.ToLower(field.name)] = alias
+		}
+	}
+	if !changed {
+		return r
+	}
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	r = r.WithContext(r.Context())
+	r.URL = &u
+	return r
+}
+
+// aliasBody renames the top level keys of a json object body the same way
+// as aliasQuery. Bodies without aliased keys are returned as they are.
+func aliasBody(bodyBytes []byte, v interface{}, aliases map[string]string) ([]byte, error) {
+	fields := aliasFields(v)
+	if len(fields) == 0 || len(bodyBytes) == 0 {
+		return bodyBytes, nil
+	}
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bodyBytes, &object); err != nil {
+		// not an object, decoding reports the problem
+		return bodyBytes, nil
+	}
+	changed := false
+	for _, field := range fields {
+		alias := field.pick(func(name string) bool {
+			for key := range object {
+				if strings.EqualFold(key, name) {
+					return true
+				}
+			}
+			return false
+		})
+		for key, value := range object {
+			if !containsFold(field.aliases, key) {
+				continue
+			}
+			delete(object, key)
+			changed = true
+			if strings.EqualFold(key, alias) {
+				object[field.name] = value
+			}
+		}
+		if alias != "" {
+			aliases[strings.ToLower(field.name)] = alias
+		}
+	}
+	if !changed {
+		return bodyBytes, nil
+	}
+	return json.Marshal(object)
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
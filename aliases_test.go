@@ -0,0 +1,95 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type aliasRequest struct {
+	UserID string `json:"userId" aliases:"user_id,uid" required:"true" warn:"alias"`
+	Name   string `json:"name"`
+}
+
+func TestAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		expected aliasRequest
+		warnings Warnings
+	}{
+		{
+			name:     "own name",
+			query:    "userId=u1",
+			expected: aliasRequest{UserID: "u1"},
+		},
+		{
+			name:     "query alias",
+			query:    "UID=u2&name=a",
+			expected: aliasRequest{UserID: "u2", Name: "a"},
+			warnings: Warnings{{Path: "userId", Message: "field UserID was sent as uid", Deprecated: true}},
+		},
+		{
+			name:     "body alias",
+			body:     `{"user_id":"u3"}`,
+			expected: aliasRequest{UserID: "u3"},
+			warnings: Warnings{{Path: "userId", Message: "field UserID was sent as user_id", Deprecated: true}},
+		},
+		{
+			name:     "own name beats aliases",
+			body:     `{"uid":"old","userId":"new","user_id":"older"}`,
+			expected: aliasRequest{UserID: "new"},
+		},
+		{
+			name:     "earlier alias beats later",
+			query:    "uid=u5&user_id=u4",
+			expected: aliasRequest{UserID: "u4"},
+			warnings: Warnings{{Path: "userId", Message: "field UserID was sent as user_id", Deprecated: true}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?"+test.query, strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &aliasRequest{}
+			warnings, err := BindWithWarnings(request, k)
+			require.NoError(t, err)
+			require.Equal(t, &test.expected, k)
+			if test.warnings == nil {
+				test.warnings = Warnings{}
+			}
+			require.Equal(t, test.warnings, warnings)
+			require.Equal(t, "/?"+test.query, request.URL.String(), "the request isn't changed")
+		})
+	}
+}
+
+func TestAliasesEntryPoints(t *testing.T) {
+	request, err := http.NewRequest("GET", "/?uid=u1", nil)
+	require.NoError(t, err)
+	k := &aliasRequest{}
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, "u1", k.UserID)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"uid":"u2"}`))
+	require.NoError(t, err)
+	k = &aliasRequest{}
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "u2", k.UserID)
+
+	// strict query mode accepts aliases
+	request, err = http.NewRequest("GET", "/?user_id=u3", nil)
+	require.NoError(t, err)
+	presence, err := New(WithStrictQuery()).BindWithPresence(request, &aliasRequest{})
+	require.NoError(t, err)
+	require.True(t, presence.Has("userId"))
+	require.False(t, presence.Has("user_id"))
+
+	request, err = http.NewRequest("POST", "/?name=a", nil)
+	require.NoError(t, err)
+	require.EqualError(t, BindScenario(request, &aliasRequest{}, "create"), "field UserID is required")
+}
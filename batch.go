@@ -0,0 +1,151 @@
+package reqbind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MaxBatchRequests is the most requests a batch envelope can hold
+const MaxBatchRequests = 100
+
+// BatchRequest is one request of a batch envelope
+type BatchRequest struct {
+	Method string `json:"method" required:"true"`
+	// Path is the request's path with its query, e.g. /orders/1?expand=lines
+	Path string          `json:"path" required:"true"`
+	Body json.RawMessage `json:"body"`
+}
+
+// BatchItem is one request of a batch, matched and bound
+type BatchItem struct {
+	// Request is the request as it was sent
+	Request BatchRequest
+	// Endpoint is the route it matched
+	Endpoint Endpoint
+	// Value is a new value of the endpoint's Request type, bound from the
+	// request, always a pointer
+	Value interface{}
+	// Err is a StatusError with a 404 when no route matched, or a 400 when
+	// binding failed
+	Err error
+}
+
+// BindBatch binds a batch envelope, {"requests":[{"method":"POST",
+// "path":"/orders","body":{...}}, ...]}, matching each request against
+// routes and binding it with Bind into a new value of the route's Request
+// type. Path parameters come from the route's {placeholders} and the
+// headers are the batch request's. The error is only for the envelope, each
+// item has its own.
+func BindBatch(r *http.Request, routes Endpoints) ([]BatchItem, error) {
+	return defaultBinder.BindBatch(r, routes)
+}
+
+// BindBatch binds a batch envelope like the package level BindBatch
+func (b *Binder) BindBatch(r *http.Request, routes Endpoints) (_ []BatchItem, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	envelope := &struct {
+		Requests []BatchRequest `json:"requests" required:"true" min-items:"1"`
+	}{}
+	if err := b.UnmarshalBody(r, envelope); err != nil {
+		return nil, err
+	}
+	if len(envelope.Requests) > MaxBatchRequests {
+		return nil, fieldErrorf(CodeTooManyItems, "Requests", "has more than %d items", MaxBatchRequests)
+	}
+
+	items := make([]BatchItem, len(envelope.Requests))
+	for i, request := range envelope.Requests {
+		items[i] = b.bindBatchItem(r, request, routes)
+	}
+	return items, nil
+}
+
+func (b *Binder) bindBatchItem(r *http.Request, request BatchRequest, routes Endpoints) BatchItem {
+	item := BatchItem{Request: request}
+	target, err := url.Parse(request.Path)
+	if err != nil {
+		item.Err = &StatusError{Status: http.StatusBadRequest, Err: fmt.Errorf("invalid path %s", request.Path)}
+		return item
+	}
+	method := strings.ToUpper(request.Method)
+	for _, route := range routes {
+		params, ok := matchRoute(route.Path, target.Path)
+		if !ok || route.Method != method {
+			continue
+		}
+		t := reflect.TypeOf(route.Request)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			item.Err = fmt.Errorf("request for %s %s is not a struct", route.Method, route.Path)
+			return item
+		}
+
+		inner, err := http.NewRequestWithContext(r.Context(), method, target.String(), bytes.NewReader(request.Body))
+		if err != nil {
+			item.Err = &StatusError{Status: http.StatusBadRequest, Err: err}
+			return item
+		}
+		inner.Host = r.Host
+		inner.RemoteAddr = r.RemoteAddr
+		inner.Header = r.Header.Clone()
+		inner.Header.Del("Content-Length")
+		inner.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.RoutePatterns = []string{route.Path}
+		for _, param := range params {
+			rctx.URLParams.Add(param[0], param[1])
+		}
+		inner = inner.WithContext(context.WithValue(inner.Context(), chi.RouteCtxKey, rctx))
+
+		item.Endpoint = route
+		item.Value = reflect.New(t).Interface()
+		if err := b.Bind(inner, item.Value); err != nil {
+			item.Err = &StatusError{Status: http.StatusBadRequest, Err: err}
+		}
+		return item
+	}
+	item.Err = &StatusError{Status: http.StatusNotFound, Err: fmt.Errorf("no route for %s %s", method, target.Path)}
+	return item
+}
+
+// matchRoute matches path against a route with {name} placeholders, which
+// match one segment. The parameters are returned in order as name, value
+// pairs.
+func matchRoute(route string, path string) ([][2]string, bool) {
+	routeParts := strings.Split(strings.Trim(route, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(routeParts) != len(pathParts) {
+		return nil, false
+	}
+	var params [][2]string
+	for i, part := range routeParts {
+		if match := urlParamRegex.FindStringSubmatch(part); match != nil && match[0] == part {
+			value, err := url.PathUnescape(pathParts[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			// chi allows a regexp after the name, {id:[0-9]+}, it isn't
+			// checked here
+			name, _, _ := strings.Cut(match[1], ":")
+			params = append(params, [2]string{name, value})
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,88 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type batchCreateOrder struct {
+	SKU string `json:"sku" required:"true"`
+	Qty int    `json:"qty"`
+}
+
+type batchGetOrder struct {
+	ID     string `json:"id" required:"true"`
+	Expand string `json:"expand"`
+	Tenant string `json:"tenant" header:"X-Tenant"`
+}
+
+var batchRoutes = Endpoints{
+	{Method: "POST", Path: "/orders", Request: batchCreateOrder{}},
+	{Method: "GET", Path: "/orders/{id}", Request: &batchGetOrder{}},
+}
+
+func TestBindBatch(t *testing.T) {
+	body := `{"requests":[
+		{"method":"POST","path":"/orders","body":{"sku":"a","qty":2}},
+		{"method":"get","path":"/orders/7?expand=lines"},
+		{"method":"POST","path":"/orders","body":{"qty":2}},
+		{"method":"DELETE","path":"/orders/7"}
+	]}`
+	request := httptest.NewRequest("POST", "/batch", strings.NewReader(body))
+	request.Header.Set("X-Tenant", "acme")
+
+	items, err := BindBatch(request, batchRoutes)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+
+	require.NoError(t, items[0].Err)
+	require.Equal(t, &batchCreateOrder{SKU: "a", Qty: 2}, items[0].Value)
+
+	require.NoError(t, items[1].Err)
+	require.Equal(t, "/orders/{id}", items[1].Endpoint.Path)
+	require.Equal(t, &batchGetOrder{ID: "7", Expand: "lines", Tenant: "acme"}, items[1].Value)
+
+	require.EqualError(t, items[2].Err, "field SKU is required")
+	var statusErr *StatusError
+	require.True(t, errors.As(items[2].Err, &statusErr))
+	require.Equal(t, http.StatusBadRequest, statusErr.Status)
+
+	require.EqualError(t, items[3].Err, "no route for DELETE /orders/7")
+	require.True(t, errors.As(items[3].Err, &statusErr))
+	require.Equal(t, http.StatusNotFound, statusErr.Status)
+}
+
+func TestBindBatchEnvelope(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{body: `{}`, expected: "field Requests is required"},
+		{body: `{"requests":[]}`, expected: "field Requests has fewer than 1 items"},
+		{body: `{"requests":[{"path":"/orders"}]}`, expected: "field Requests item 0: field Method is required"},
+		{body: `{"requests":[` + strings.Repeat(`{"method":"GET","path":"/"},`, 100) + `{"method":"GET","path":"/"}]}`, expected: "field Requests has more than 100 items"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			_, err := BindBatch(httptest.NewRequest("POST", "/batch", strings.NewReader(test.body)), batchRoutes)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	params, ok := matchRoute("/teams/{team}/users/{id:[0-9]+}", "/teams/a%20b/users/9")
+	require.True(t, ok)
+	require.Equal(t, [][2]string{{"team", "a b"}, {"id", "9"}}, params)
+
+	_, ok = matchRoute("/teams/{team}", "/teams/a/users")
+	require.False(t, ok)
+	_, ok = matchRoute("/teams/{team}", "/teams/")
+	require.False(t, ok)
+}
@@ -0,0 +1,151 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// ReadOnlyMode decides what happens when a client sends a field tagged
+// readonly:"true"
+type ReadOnlyMode int
+
+const (
+	// ReadOnlyReject fails the bind with an error
+	ReadOnlyReject ReadOnlyMode = iota
+	// ReadOnlyZero silently resets the field to its zero value
+	ReadOnlyZero
+)
+
+// Binder holds the options the binding functions run with. The package level
+// functions use a Binder with the default options.
+type Binder struct {
+	readOnly       ReadOnlyMode
+	roles          func(ctx context.Context) []string
+	customRoles    bool
+	strictQuery    bool
+	conflicts      ConflictPolicy
+	single         bool
+	required       bool
+	useNumber      bool
+	limits         JSONLimits
+	invalidUTF8    InvalidUTF8Mode
+	trustedProxies []netip.Prefix
+	forwarded      ForwardedHeaders
+	userAgent      UserAgentParser
+	derivers       map[string]Deriver
+	pipeline       []Step
+	aggregate      bool
+	versions       map[reflect.Type]map[string]Rules
+	policy         PolicyProvider
+	flags          FlagEvaluator
+	flagMode       FlagMode
+	queryCache     *queryCache
+	zeroCopy       bool
+	parallel       parallelItems
+	maxDepth       int
+	fillMissing    bool
+	hints          bool
+	verbosity      Verbosity
+	errorHook      ErrorHook
+	heartbeat      heartbeatBounds
+	bindTimeout    time.Duration
+	maxBodyBytes   int64
+	address        AddressNormalizer
+	uploadDir      string
+	scanner        FileScanner
+}
+
+// Option configures a Binder
+type Option func(*Binder)
+
+// New creates a Binder with the given options
+func New(opts ...Option) *Binder {
+	b := &Binder{roles: RolesFromContext, userAgent: UserAgentParserFunc(ParseUserAgent)}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+var defaultBinder = New()
+
+// SetDefaults replaces the options the package level functions use. It's
+// meant to be called once at startup, it isn't safe to call while requests
+// are being bound.
+func SetDefaults(opts ...Option) {
+	defaultBinder = New(opts...)
+}
+
+// WithReadOnly sets how sent readonly fields are handled, the default is
+// ReadOnlyReject
+func WithReadOnly(mode ReadOnlyMode) Option {
+	return func(b *Binder) {
+		b.readOnly = mode
+	}
+}
+
+// WithRoles sets how the caller's roles are found for allow-roles fields,
+// the default is RolesFromContext
+func WithRoles(roles func(ctx context.Context) []string) Option {
+	return func(b *Binder) {
+		b.roles = roles
+		b.customRoles = true
+	}
+}
+
+// WithStrictQuery rejects query parameters that don't map to a field, the
+// query string equivalent of json's DisallowUnknownFields. It catches typos
+// like ?limt=10 that would otherwise be silently ignored.
+func WithStrictQuery() Option {
+	return func(b *Binder) {
+		b.strictQuery = true
+	}
+}
+
+// WithUseNumber decodes numbers in the body into json.Number rather than
+// float64 wherever the target is an interface, so int64 ids above 2^53
+// keep every digit
+func WithUseNumber() Option {
+	return func(b *Binder) {
+		b.useNumber = true
+	}
+}
+
+// WithRequiredByDefault makes every exported field required unless it's
+// tagged optional:"true", for APIs that prefer explicit optionality
+func WithRequiredByDefault() Option {
+	return func(b *Binder) {
+		b.required = true
+	}
+}
+
+// checkOptions returns the options for checking a request with this binder's
+// settings
+func (b *Binder) checkOptions(r *http.Request, presence *Presence) checkOptions {
+	var policy *Policy
+	if b.policy != nil {
+		p := b.policy.Policy(r.Context())
+		policy = &p
+	}
+	return checkOptions{
+		presence:          presence,
+		readOnly:          b.readOnly,
+		roles:             b.roles(r.Context()),
+		requiredByDefault: b.required,
+		derivers:          b.derivers,
+		pipeline:          b.pipeline,
+		aggregate:         b.aggregate,
+		policy:            policy,
+		flagEnabled:       b.flagEnabled(r.Context()),
+		flagMode:          b.flagMode,
+		parallel:          b.parallel,
+		maxDepth:          b.maxDepth,
+		hints:             b.hints,
+		values:            b.verbosity == VerbosityDebug,
+		heartbeat:         b.heartbeat,
+		normalizeAddress:  b.normalizeAddress(r.Context()),
+	}
+}
@@ -0,0 +1,135 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type readOnlyProject struct {
+	ID        string `json:"id" readonly:"true"`
+	Name      string `json:"name" required:"true"`
+	CreatedAt string `json:"createdAt" readonly:"true"`
+}
+
+func TestReadOnlyReject(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"id":"1","name":"a"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, &readOnlyProject{}))
+
+	request, err = http.NewRequest("POST", "/?createdAt=today&name=a", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, &readOnlyProject{}))
+
+	// fields the server set itself are fine as long as the client didn't send them
+	k := &readOnlyProject{ID: "1"}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "1", k.ID)
+}
+
+func TestReadOnlyZero(t *testing.T) {
+	binder := New(WithReadOnly(ReadOnlyZero))
+
+	k := &readOnlyProject{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"id":"1","name":"a","createdAt":"today"}`))
+	require.NoError(t, err)
+	require.NoError(t, binder.UnmarshalBody(request, k))
+	require.Equal(t, &readOnlyProject{Name: "a"}, k)
+
+	k = &readOnlyProject{}
+	request, err = http.NewRequest("POST", "/?id=abc", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	_, err = binder.BindWithPresence(request, k)
+	require.NoError(t, err)
+	require.Equal(t, &readOnlyProject{Name: "a"}, k)
+}
+
+func TestStrictQuery(t *testing.T) {
+	type query struct {
+		Pagination
+		Status string `json:"status"`
+	}
+	binder := New(WithStrictQuery())
+
+	request, err := http.NewRequest("GET", "/?status=open&perPage=10&Page=2", nil)
+	require.NoError(t, err)
+	require.NoError(t, binder.UnmarshalQuery(request, &query{}))
+
+	request, err = http.NewRequest("GET", "/?status=open&limt=10", nil)
+	require.NoError(t, err)
+	require.Error(t, binder.UnmarshalQuery(request, &query{}))
+	require.NoError(t, UnmarshalQuery(request, &query{}), "only the strict binder rejects unknown parameters")
+
+	_, err = binder.BindWithPresence(request, &query{})
+	require.Error(t, err)
+	require.Error(t, binder.BindScenario(request, &query{}, "create"))
+}
+
+func TestRequiredByDefault(t *testing.T) {
+	type query struct {
+		Pagination `optional:"true"`
+		Status     string `json:"status"`
+		Owner      string `json:"owner" optional:"true"`
+		Internal   string `json:"-"`
+	}
+	binder := New(WithRequiredByDefault())
+
+	request, err := http.NewRequest("GET", "/?status=open", nil)
+	require.NoError(t, err)
+	require.NoError(t, binder.UnmarshalQuery(request, &query{}))
+
+	request, err = http.NewRequest("GET", "/?owner=me", nil)
+	require.NoError(t, err)
+	require.Error(t, binder.UnmarshalQuery(request, &query{}))
+	require.NoError(t, UnmarshalQuery(request, &query{}))
+}
+
+func TestSetDefaults(t *testing.T) {
+	defer SetDefaults()
+	SetDefaults(WithRequiredByDefault())
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, &struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}{}))
+}
+
+func TestUseNumber(t *testing.T) {
+	type event struct {
+		ID      interface{}            `json:"id" max-length:"16"`
+		Payload map[string]interface{} `json:"payload"`
+		Count   int64                  `json:"count"`
+	}
+	body := `{"id":9007199254740993,"payload":{"id":9007199254740993},"count":9007199254740993}`
+
+	k := &event{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, New(WithUseNumber()).UnmarshalBody(request, k))
+	require.Equal(t, json.Number("9007199254740993"), k.ID)
+	require.Equal(t, json.Number("9007199254740993"), k.Payload["id"])
+	require.Equal(t, int64(9007199254740993), k.Count)
+
+	// without it the same ids come back as rounded float64s
+	k = &event{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, float64(9007199254740992), k.ID)
+
+	// the string form is checked like any other interface value
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"id":12345678901234567}`))
+	require.NoError(t, err)
+	require.Error(t, New(WithUseNumber()).UnmarshalBody(request, &event{}))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"id":1} {}`))
+	require.NoError(t, err)
+	require.Error(t, New(WithUseNumber()).UnmarshalBody(request, &event{}))
+}
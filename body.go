@@ -0,0 +1,57 @@
+package reqbind
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrBodyTooLarge is returned (wrapped) for a body over WithMaxBodyBytes
+	ErrBodyTooLarge = errors.New("request body is too large")
+	// ErrTruncatedBody is returned (wrapped) when the body ends before its
+	// Content-Length or final chunk, e.g. because the client went away, so
+	// it isn't mistaken for invalid json
+	ErrTruncatedBody = errors.New("request body was cut short")
+	// ErrContentLength is returned (wrapped) for a body longer than its
+	// Content-Length
+	ErrContentLength = errors.New("request body does not match its Content-Length")
+)
+
+// WithMaxBodyBytes caps the size of bodies. A Content-Length over the cap is
+// rejected before anything is read and chunked bodies are read up to it.
+// Zero means no limit.
+func WithMaxBodyBytes(n int64) Option {
+	return func(b *Binder) {
+		b.maxBodyBytes = n
+	}
+}
+
+// copyBody reads r's body into buf, up to maxBytes when it's over 0, and
+// checks it against the Content-Length
+func copyBody(buf *bytes.Buffer, r *http.Request, maxBytes int64) error {
+	if maxBytes > 0 && r.ContentLength > maxBytes {
+		return fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+	}
+	body := io.Reader(r.Body)
+	if maxBytes > 0 {
+		// one more byte than allowed tells a body at the limit from one over
+		body = io.LimitReader(r.Body, maxBytes+1)
+	}
+	_, err := buf.ReadFrom(body)
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("%w after %d bytes", ErrTruncatedBody, buf.Len())
+	case err != nil:
+		return err
+	case maxBytes > 0 && int64(buf.Len()) > maxBytes:
+		return fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+	case r.ContentLength > 0 && int64(buf.Len()) < r.ContentLength:
+		return fmt.Errorf("%w after %d of %d bytes", ErrTruncatedBody, buf.Len(), r.ContentLength)
+	case r.ContentLength > 0 && int64(buf.Len()) > r.ContentLength:
+		return fmt.Errorf("%w: more than %d bytes", ErrContentLength, r.ContentLength)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package reqbind
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cutReader ends its body the way the server does when a client goes away
+// mid-body
+type cutReader struct {
+	io.Reader
+}
+
+func (r cutReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func TestBodyLength(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          io.Reader
+		contentLength int64
+		maxBytes      int64
+		expected      string
+		target        error
+	}{
+		{name: "fits", body: strings.NewReader(`{"sku":"a"}`), contentLength: 11, maxBytes: 11},
+		{name: "chunked fits", body: strings.NewReader(`{"sku":"a"}`), contentLength: -1, maxBytes: 11},
+		{name: "content length too large", body: strings.NewReader(`{"sku":"a"}`), contentLength: 11, maxBytes: 10, expected: "request body is too large: more than 10 bytes", target: ErrBodyTooLarge},
+		{name: "chunked too large", body: strings.NewReader(`{"sku":"a"}`), contentLength: -1, maxBytes: 10, expected: "request body is too large: more than 10 bytes", target: ErrBodyTooLarge},
+		{name: "cut short", body: cutReader{strings.NewReader(`{"sku":`)}, contentLength: -1, expected: "request body was cut short after 7 bytes", target: ErrTruncatedBody},
+		{name: "shorter than content length", body: strings.NewReader(`{"sku":"a"}`), contentLength: 100, expected: "request body was cut short after 11 of 100 bytes", target: ErrTruncatedBody},
+		{name: "longer than content length", body: strings.NewReader(`{"sku":"a"}`), contentLength: 5, expected: "request body does not match its Content-Length: more than 5 bytes", target: ErrContentLength},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/", test.body)
+			request.ContentLength = test.contentLength
+			order := &handlerOrder{}
+			err := New(WithMaxBodyBytes(test.maxBytes)).Bind(request, order)
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.Equal(t, "a", order.SKU)
+				return
+			}
+			require.EqualError(t, err, test.expected)
+			require.True(t, errors.Is(err, test.target))
+		})
+	}
+}
+
+func TestBodyLengthWriteError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{err: ErrBodyTooLarge, status: http.StatusRequestEntityTooLarge},
+		{err: &http.MaxBytesError{Limit: 10}, status: http.StatusRequestEntityTooLarge},
+		{err: ErrTruncatedBody, status: http.StatusBadRequest},
+		{err: ErrContentLength, status: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, httptest.NewRequest("POST", "/", nil), test.err)
+			require.Equal(t, test.status, recorder.Code)
+		})
+	}
+}
+
+func TestTruncatedChunkedBody(t *testing.T) {
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errs <- Bind(r, &handlerOrder{})
+	}))
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nContent-Type: application/json\r\nTransfer-Encoding: chunked\r\n\r\n7\r\n{\"sku\":\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+	defer conn.Close()
+
+	select {
+	case err := <-errs:
+		require.True(t, errors.Is(err, ErrTruncatedBody), err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler never ran")
+	}
+}
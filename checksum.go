@@ -0,0 +1,56 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// uploadChecksum is the checksum-field:"Name" of a file field, the sibling
+// string field with the sha256 of the file, hex or base64 encoded
+type uploadChecksum struct {
+	file    reflect.StructField
+	sibling reflect.StructField
+	index   []int
+}
+
+// checksumSibling resolves the checksum-field tag of the file field f, a
+// field of t at parent
+func checksumSibling(t reflect.Type, parent []int, f reflect.StructField) (*uploadChecksum, error) {
+	name := f.Tag.Get("checksum-field")
+	if name == "" {
+		return nil, nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sibling := t.Field(i)
+		if !strings.EqualFold(sibling.Name, name) && !strings.EqualFold(jsonName(sibling), name) {
+			continue
+		}
+		if sibling.Type.Kind() != reflect.String || isIgnored(sibling) {
+			break
+		}
+		index := append(append([]int{}, parent...), i)
+		return &uploadChecksum{file: f, sibling: sibling, index: index}, nil
+	}
+	return nil, fieldErrorf(CodeInvalidTag, f.Name, "has invalid checksum-field %s", name)
+}
+
+// sent is the checksum when it arrived before the file, in a header or an
+// earlier part
+func (c *uploadChecksum) sent(header http.Header, values url.Values) string {
+	if name := c.sibling.Tag.Get("header"); name != "" {
+		return header.Get(name)
+	}
+	return values.Get(jsonName(c.sibling))
+}
+
+// check compares the file's digest with the checksum, a missing checksum is
+// left to the sibling's required tag
+func (c *uploadChecksum) check(digest []byte, expected string) error {
+	if expected == "" || matchesDigest(digest, expected) {
+		return nil
+	}
+	return &FieldError{Field: c.file.Name, Code: CodeInvalid, subject: "field", err: fmt.Errorf("%w: field %s is not the sha256 in %s", ErrChecksum, c.file.Name, c.sibling.Name)}
+}
@@ -0,0 +1,91 @@
+package reqbind
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// helloSHA256 is the hex sha256 of "hello"
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestUnmarshalMultipartChecksum(t *testing.T) {
+	type upload struct {
+		Sha256 string `json:"sha256" required:"true"`
+		Data   *File  `file:"data" checksum-field:"Sha256"`
+	}
+	tests := []struct {
+		name  string
+		parts []testPart
+		err   string
+	}{
+		{"before the file", []testPart{{name: "sha256", content: helloSHA256}, {name: "data", filename: "a.txt", content: "hello"}}, ""},
+		{"after the file", []testPart{{name: "data", filename: "a.txt", content: "hello"}, {name: "sha256", content: helloSHA256}}, ""},
+		{"base64", []testPart{{name: "sha256", content: "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}, {name: "data", filename: "a.txt", content: "hello"}}, ""},
+		{"mismatch before", []testPart{{name: "sha256", content: helloSHA256}, {name: "data", filename: "a.txt", content: "bye"}}, "checksum does not match: field Data is not the sha256 in Sha256"},
+		{"mismatch after", []testPart{{name: "data", filename: "a.txt", content: "bye"}, {name: "sha256", content: helloSHA256}}, "checksum does not match: field Data is not the sha256 in Sha256"},
+		{"missing", []testPart{{name: "data", filename: "a.txt", content: "hello"}}, "field Sha256 is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &upload{}
+			err := UnmarshalMultipart(multipartRequest(t, tt.parts...), v)
+			if tt.err == "" {
+				require.NoError(t, err)
+				require.Equal(t, 5, int(v.Data.Size))
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestUnmarshalMultipartChecksumFailsFast(t *testing.T) {
+	type upload struct {
+		Sha256 string `header:"X-Checksum"`
+		Data   *File  `file:"data" checksum-field:"sha256"`
+		Name   string `json:"name" required:"true"`
+	}
+	request := multipartRequest(t, testPart{name: "data", filename: "a.txt", content: "bye"}, testPart{name: "name", content: "x"})
+	request.Header.Set("X-Checksum", helloSHA256)
+	err := UnmarshalMultipart(request, &upload{})
+	require.True(t, errors.Is(err, ErrChecksum))
+
+	v := &upload{}
+	request = multipartRequest(t, testPart{name: "data", filename: "a.txt", content: "hello"}, testPart{name: "name", content: "x"})
+	request.Header.Set("X-Checksum", helloSHA256)
+	require.NoError(t, UnmarshalMultipart(request, v))
+	require.Equal(t, helloSHA256, v.Sha256)
+}
+
+func TestUnmarshalMultipartChecksumStream(t *testing.T) {
+	type upload struct {
+		Sha256 string        `json:"sha256"`
+		Data   io.ReadCloser `file:"data" checksum-field:"Sha256"`
+	}
+	v := &upload{}
+	request := multipartRequest(t, testPart{name: "sha256", content: helloSHA256}, testPart{name: "data", filename: "a.txt", content: "hello"})
+	require.NoError(t, UnmarshalMultipart(request, v))
+	data, err := io.ReadAll(v.Data)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+	require.NoError(t, Cleanup(v))
+
+	v = &upload{}
+	request = multipartRequest(t, testPart{name: "sha256", content: helloSHA256}, testPart{name: "data", filename: "a.txt", content: "hellO"})
+	require.NoError(t, UnmarshalMultipart(request, v))
+	_, err = io.ReadAll(v.Data)
+	require.EqualError(t, err, "checksum does not match: field Data is not the sha256 in Sha256")
+	require.NoError(t, Cleanup(v))
+}
+
+func TestUnmarshalMultipartChecksumInvalidTag(t *testing.T) {
+	type upload struct {
+		Sha256 int   `json:"sha256"`
+		Data   *File `file:"data" checksum-field:"Sha256"`
+	}
+	request := multipartRequest(t, testPart{name: "data", filename: "a.txt", content: "hello"})
+	require.EqualError(t, UnmarshalMultipart(request, &upload{}), "field Data has invalid checksum-field Sha256")
+}
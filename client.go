@@ -0,0 +1,181 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// urlParamRegex finds the {name} placeholders of a url
+var urlParamRegex = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// NewRequest builds a request from params, the inverse of Bind, so service
+// clients can share the server's structs. Fields named by a {placeholder}
+// in the url go in the path, header tagged fields in the headers, and the
+// rest in the query for GET, HEAD, DELETE and OPTIONS and in a json body
+// otherwise. A source tag sends the field to the first place in its chain.
+//
+// params is checked against its tags first so a request the server would
+// reject isn't sent. Fields the server fills, such as readonly ones, are
+// left out.
+func NewRequest(method string, url string, params interface{}) (*http.Request, error) {
+	return defaultBinder.NewRequest(method, url, params)
+}
+
+// NewRequest builds a request like the package level NewRequest, checking
+// params with the binder's options
+func (b *Binder) NewRequest(method string, rawURL string, params interface{}) (_ *http.Request, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	rv := reflect.ValueOf(params)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("params must be a struct")
+	}
+
+	// the checks normalize values so they run on a copy, and there's no
+	// caller context for roles or flags on the client
+	v := reflect.New(rv.Type())
+	v.Elem().Set(rv)
+	if err := checkStruct(v.Interface(), b.checkOptions(new(http.Request), nil), ""); err != nil {
+		return nil, err
+	}
+
+	pathParams := map[string]string{}
+	for _, match := range urlParamRegex.FindAllStringSubmatch(rawURL, -1) {
+		pathParams[strings.ToLower(match[1])] = match[0]
+	}
+	inQuery := sendsQuery(method)
+	header := http.Header{}
+	var query []string
+	var body orderedObject
+	var add func(rv reflect.Value) error
+	add = func(rv reflect.Value) error {
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			// encoding/json promotes the fields of embedded structs, even
+			// unexported ones
+			if f.Anonymous && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" && indirectType(f.Type).Kind() == reflect.Struct {
+				if embedded := reflect.Indirect(rv.Field(i)); embedded.IsValid() {
+					if err := add(embedded); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if isIgnored(f) {
+				continue
+			}
+			name := jsonName(f)
+			place := paramPlace(f, pathParams[strings.ToLower(name)] != "", inQuery)
+			if place == "" {
+				continue
+			}
+
+			value := rv.Field(i)
+			if place == "body" {
+				if _, opts, _ := strings.Cut(f.Tag.Get("json"), ","); !strings.Contains(opts, "omitempty") || !value.IsZero() {
+					body = append(body, orderedField{name: name, value: value.Interface()})
+				}
+				continue
+			}
+			if value.IsZero() {
+				continue
+			}
+			raw, err := json.Marshal(value.Interface())
+			if err != nil {
+				return err
+			}
+			switch place {
+			case "path":
+				placeholder, ok := pathParams[strings.ToLower(name)]
+				if !ok {
+					return fieldErrorf(CodeInvalidTag, f.Name, "has source path but the url has no {%s}", name)
+				}
+				rawURL = strings.ReplaceAll(rawURL, placeholder, url.PathEscape(rawText(raw)))
+			case "header":
+				key := f.Tag.Get("header")
+				if key == "" {
+					key = name
+				}
+				header.Set(key, rawText(raw))
+			case "query":
+				var decoded interface{}
+				decoder := json.NewDecoder(bytes.NewReader(raw))
+				decoder.UseNumber()
+				if err := decoder.Decode(&decoded); err != nil {
+					return err
+				}
+				flattenQuery(decoded, name, &query)
+			default:
+				return fieldErrorf(CodeInvalidTag, f.Name, "has invalid source: unknown source %s", place)
+			}
+		}
+		return nil
+	}
+	if err := add(v.Elem()); err != nil {
+		return nil, err
+	}
+	if match := urlParamRegex.FindStringSubmatch(rawURL); match != nil {
+		return nil, fieldErrorf(CodeRequired, match[1], "is required")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	r, err := http.NewRequest(method, rawURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		if r.URL.RawQuery != "" {
+			r.URL.RawQuery += "&"
+		}
+		r.URL.RawQuery += strings.Join(query, "&")
+	}
+	r.Header = header
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	return r, nil
+}
+
+// paramPlace is where f is sent: "path", "header", "query" or "body", or
+// "" when the server fills it. inPath is set when the url has a
+// placeholder for f.
+func paramPlace(f reflect.StructField, inPath bool, inQuery bool) string {
+	if source := strings.TrimSpace(strings.Split(f.Tag.Get("source"), ",")[0]); source != "" {
+		return source
+	}
+	switch {
+	case inPath:
+		return "path"
+	case f.Tag.Get("header") != "":
+		return "header"
+	case !generated(f):
+		return ""
+	case inQuery:
+		return "query"
+	}
+	return "body"
+}
+
+// sendsQuery reports whether requests with method send their parameters in
+// the query rather than a body
+func sendsQuery(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodDelete || method == http.MethodOptions
+}
@@ -0,0 +1,84 @@
+package reqbind
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type clientPaging struct {
+	Limit int `json:"limit"`
+}
+
+type clientUpdate struct {
+	clientPaging
+	ProjectID string   `json:"projectId" required:"true"`
+	Tenant    string   `json:"tenant" header:"X-Tenant"`
+	Name      string   `json:"name" required:"true" trimlower:"true"`
+	Tags      []string `json:"tags,omitempty"`
+	DryRun    bool     `json:"dryRun" source:"query"`
+	Owner     string   `json:"owner" readonly:"true"`
+}
+
+func TestNewRequest(t *testing.T) {
+	params := &clientUpdate{clientPaging: clientPaging{Limit: 5}, ProjectID: "p 1", Tenant: "acme", Name: "  Board ", DryRun: true, Owner: "me"}
+	r, err := NewRequest("PUT", "http://example.com/projects/{projectId}", params)
+	require.NoError(t, err)
+	require.Equal(t, "/projects/p%201", r.URL.EscapedPath())
+	require.Equal(t, "dryRun=true", r.URL.RawQuery)
+	require.Equal(t, "acme", r.Header.Get("X-Tenant"))
+	require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"limit":5,"name":"board"}`, string(body))
+	// the caller's struct isn't normalized
+	require.Equal(t, "  Board ", params.Name)
+
+	// it binds back into the same struct on the server
+	router := chi.NewRouter()
+	router.Put("/projects/{projectId}", func(w http.ResponseWriter, r *http.Request) {
+		k := &clientUpdate{}
+		require.NoError(t, Bind(r, k))
+		require.Equal(t, &clientUpdate{clientPaging: clientPaging{Limit: 5}, ProjectID: "p 1", Tenant: "acme", Name: "board", DryRun: true}, k)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r, err = NewRequest("PUT", "/projects/{projectId}", params)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestNewRequestQuery(t *testing.T) {
+	r, err := NewRequest("GET", "/projects/{projectId}?v=2", &clientUpdate{ProjectID: "p1", Name: "a", Tags: []string{"x", "y"}})
+	require.NoError(t, err)
+	require.Equal(t, "/projects/p1", r.URL.Path)
+	require.Equal(t, "v=2&name=a&tags=x&tags=y", r.URL.RawQuery)
+	require.Nil(t, r.Body)
+}
+
+func TestNewRequestErrors(t *testing.T) {
+	tests := []struct {
+		url      string
+		params   interface{}
+		expected string
+	}{
+		{url: "/projects/{projectId}", params: &clientUpdate{ProjectID: "p1"}, expected: "field Name is required"},
+		{url: "/projects/{projectId}/{version}", params: &clientUpdate{ProjectID: "p1", Name: "a"}, expected: "field version is required"},
+		{url: "/projects", params: &struct {
+			ID string `json:"id" source:"path"`
+		}{ID: "1"}, expected: "field ID has source path but the url has no {id}"},
+		{url: "/projects", params: "nope", expected: "params must be a struct"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			_, err := NewRequest("POST", test.url, test.params)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package reqbind
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// ForwardedHeaders are the headers trusted proxies write the client's
+// address to
+type ForwardedHeaders int
+
+const (
+	// HeaderXForwardedFor is X-Forwarded-For
+	HeaderXForwardedFor ForwardedHeaders = 1 << iota
+	// HeaderForwarded is the RFC 7239 Forwarded header
+	HeaderForwarded
+)
+
+// WithTrustedProxies sets the proxies whose forwarding headers are believed
+// when resolving clientip:"true" fields, and which headers they write. Only
+// those headers are read, a client can't pick its address by sending one
+// the proxies pass through untouched. Without any proxies the client ip is
+// always the connection's RemoteAddr.
+func WithTrustedProxies(headers ForwardedHeaders, proxies ...netip.Prefix) Option {
+	return func(b *Binder) {
+		b.forwarded |= headers
+		b.trustedProxies = append(b.trustedProxies, proxies...)
+	}
+}
+
+// ClientIP resolves the caller's ip with the default binder's trusted proxies
+func ClientIP(r *http.Request) (netip.Addr, error) {
+	return defaultBinder.ClientIP(r)
+}
+
+// ClientIP resolves the caller's ip. Starting from RemoteAddr, each hop
+// that's a trusted proxy hands over to the address it forwarded for, read
+// right to left from the trusted header. When both headers are trusted a
+// request with a Forwarded header uses that one.
+func (b *Binder) ClientIP(r *http.Request) (netip.Addr, error) {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid remote address %s", r.RemoteAddr)
+	}
+	addr = addr.Unmap()
+
+	hops := forwardedHops(r.Header, b.forwarded)
+	for i := len(hops) - 1; i >= 0 && b.isTrustedProxy(addr); i-- {
+		hop, err := parseHop(hops[i])
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		addr = hop
+	}
+	return addr, nil
+}
+
+func (b *Binder) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range b.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedHops lists the forwarded addresses from the trusted headers, the
+// client first
+func forwardedHops(header http.Header, trusted ForwardedHeaders) []string {
+	var hops []string
+	if forwarded := header.Values("Forwarded"); len(forwarded) > 0 && trusted&HeaderForwarded != 0 {
+		for _, element := range strings.Split(strings.Join(forwarded, ","), ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+				if strings.EqualFold(key, "for") {
+					hops = append(hops, strings.Trim(value, `"`))
+				}
+			}
+		}
+		return hops
+	}
+	if trusted&HeaderXForwardedFor == 0 {
+		return nil
+	}
+	for _, element := range strings.Split(strings.Join(header.Values("X-Forwarded-For"), ","), ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			hops = append(hops, element)
+		}
+	}
+	return hops
+}
+
+// parseHop parses 1.2.3.4, 1.2.3.4:80, [2001:db8::1] and [2001:db8::1]:80
+func parseHop(hop string) (netip.Addr, error) {
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(strings.Trim(hop, "[]"))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid forwarded address %s", hop)
+	}
+	return addr.Unmap(), nil
+}
+
+// bindClientIP sets the fields tagged clientip:"true". They can be a string,
+// a netip.Addr or a net.IP.
+func (b *Binder) bindClientIP(r *http.Request, v interface{}, presence *Presence) error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("clientip") != "true" || isIgnored(f) {
+			continue
+		}
+		addr, err := b.ClientIP(r)
+		if err != nil {
+			return err
+		}
+		if err := setFromString(rv.Field(i), addr.String()); err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has clientip but %s", err)
+		}
+		if presence != nil {
+			presence.add(jsonName(f))
+		}
+	}
+	return nil
+}
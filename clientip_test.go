@@ -0,0 +1,105 @@
+package reqbind
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	binder := New(WithTrustedProxies(HeaderXForwardedFor|HeaderForwarded, netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{name: "no proxy", remoteAddr: "203.0.113.7:1234", expected: "203.0.113.7"},
+		{name: "untrusted remote can't forward", remoteAddr: "203.0.113.7:1234", headers: map[string]string{"X-Forwarded-For": "1.1.1.1"}, expected: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "1.1.1.1"}, expected: "1.1.1.1"},
+		{name: "spoofed entry before the real client", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "9.9.9.9, 1.1.1.1, 10.0.0.2"}, expected: "1.1.1.1"},
+		{name: "all trusted", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, expected: "10.0.0.3"},
+		{name: "forwarded wins", remoteAddr: "10.0.0.1:1234", headers: map[string]string{"Forwarded": `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`, "X-Forwarded-For": "1.1.1.1"}, expected: "192.0.2.60"},
+		{name: "ipv6 remote", remoteAddr: "[2001:db8::5]:443", headers: map[string]string{"X-Forwarded-For": "198.51.100.1:80"}, expected: "198.51.100.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("GET", "/", nil)
+			require.NoError(t, err)
+			request.RemoteAddr = test.remoteAddr
+			for k, v := range test.headers {
+				request.Header.Set(k, v)
+			}
+			addr, err := binder.ClientIP(request)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, addr.String())
+		})
+	}
+}
+
+func TestClientIPUntrustedHeader(t *testing.T) {
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "1.1.1.1")
+	// the proxy only appends X-Forwarded-For, this one came from the client
+	request.Header.Set("Forwarded", "for=6.6.6.6")
+
+	addr, err := New(WithTrustedProxies(HeaderXForwardedFor, netip.MustParsePrefix("10.0.0.0/8"))).ClientIP(request)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.1.1", addr.String())
+
+	request.Header.Del("Forwarded")
+	addr, err = New(WithTrustedProxies(HeaderForwarded, netip.MustParsePrefix("10.0.0.0/8"))).ClientIP(request)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", addr.String(), "no fall through to X-Forwarded-For")
+}
+
+func TestClientIPErrors(t *testing.T) {
+	binder := New(WithTrustedProxies(HeaderXForwardedFor, netip.MustParsePrefix("10.0.0.0/8")))
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "nonsense")
+	_, err = binder.ClientIP(request)
+	require.Error(t, err)
+
+	request.RemoteAddr = "pipe"
+	_, err = ClientIP(request)
+	require.Error(t, err)
+}
+
+func TestBindClientIP(t *testing.T) {
+	type audit struct {
+		Name string     `json:"name"`
+		IP   string     `json:"ip" clientip:"true" required:"true"`
+		Addr netip.Addr `json:"addr" clientip:"true"`
+		Raw  net.IP     `json:"raw" clientip:"true"`
+	}
+
+	binder := New(WithTrustedProxies(HeaderXForwardedFor, netip.MustParsePrefix("10.0.0.0/8")))
+	request, err := http.NewRequest("POST", "/?ip=6.6.6.6", strings.NewReader(`{"name":"a","ip":"6.6.6.6","addr":"6.6.6.6"}`))
+	require.NoError(t, err)
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "1.1.1.1")
+
+	k := &audit{}
+	require.NoError(t, binder.Bind(request, k))
+	require.Equal(t, "a", k.Name)
+	require.Equal(t, "1.1.1.1", k.IP)
+	require.Equal(t, netip.MustParseAddr("1.1.1.1"), k.Addr)
+	require.Equal(t, "1.1.1.1", k.Raw.String())
+
+	// the body can't set it either
+	k = &audit{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"ip":"6.6.6.6"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "", k.IP)
+}
@@ -0,0 +1,87 @@
+package reqbind
+
+import (
+	"flag"
+	"net/url"
+	"os"
+	"reflect"
+)
+
+// BindFlags registers a flag on fs for every field tagged cli:"port", with
+// the field's default and desc tags as its default and usage, and returns
+// the check to run once fs is parsed. The check binds the flags the way a
+// query string is bound and checks v's tags. A flag that isn't set falls
+// back to the field's env variable, then its default:
+//
+//	type Options struct {
+//		Port    int    `cli:"port" env:"PORT" default:"8080" desc:"port to listen on"`
+//		DryRun  bool   `cli:"dry-run" desc:"print what would change"`
+//		Tenant  string `cli:"tenant" required:"true"`
+//	}
+//
+//	check := reqbind.BindFlags(fs, opts)
+//	fs.Parse(os.Args[1:])
+//	if err := check(); err != nil {
+func BindFlags(fs *flag.FlagSet, v interface{}) func() error {
+	return defaultBinder.BindFlags(fs, v)
+}
+
+// BindFlags registers flags like the package level BindFlags
+func (b *Binder) BindFlags(fs *flag.FlagSet, v interface{}) func() error {
+	var flags []*cliFlag
+	walkTagged(reflect.TypeOf(v).Elem(), "cli", "", func(f reflect.StructField, key string) {
+		value := &cliFlag{field: f, key: key, values: url.Values{}}
+		fs.Var(value, f.Tag.Get("cli"), f.Tag.Get("desc"))
+		flags = append(flags, value)
+	})
+	return func() error {
+		values := url.Values{}
+		for _, value := range flags {
+			if value.set {
+				for key, list := range value.values {
+					values[key] = append(values[key], list...)
+				}
+				continue
+			}
+			fallback, ok := "", false
+			if name := value.field.Tag.Get("env"); name != "" {
+				fallback, ok = os.LookupEnv(name)
+			}
+			if !ok {
+				if fallback, ok = value.field.Tag.Lookup("default"); !ok {
+					continue
+				}
+			}
+			addFieldValue(values, value.key, value.field.Type, fallback)
+		}
+		return b.BindValues(values, v)
+	}
+}
+
+// cliFlag collects the values given for a cli tagged field
+type cliFlag struct {
+	field  reflect.StructField
+	key    string
+	values url.Values
+	set    bool
+}
+
+// String is the default shown in the usage
+func (c *cliFlag) String() string {
+	if c == nil || c.field.Tag == "" {
+		return ""
+	}
+	return c.field.Tag.Get("default")
+}
+
+// Set adds a value, a slice flag can be given more than once
+func (c *cliFlag) Set(value string) error {
+	c.set = true
+	addFieldValue(c.values, c.key, c.field.Type, value)
+	return nil
+}
+
+// IsBoolFlag lets a bool flag be given without a value
+func (c *cliFlag) IsBoolFlag() bool {
+	return derefType(c.field.Type).Kind() == reflect.Bool
+}
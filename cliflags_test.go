@@ -0,0 +1,72 @@
+package reqbind
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cliOptions struct {
+	Port   int      `cli:"port" env:"TEST_CLI_PORT" default:"8080" desc:"port to listen on"`
+	DryRun bool     `cli:"dry-run" desc:"print what would change"`
+	Tenant string   `cli:"tenant" required:"true" trimlower:"true"`
+	Tags   []string `cli:"tag"`
+	Admin  struct {
+		Email string `cli:"admin-email" default:"root@example.com" validate:"email"`
+	} `json:"admin"`
+}
+
+func TestBindFlags(t *testing.T) {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	v := &cliOptions{}
+	check := BindFlags(fs, v)
+	require.NoError(t, fs.Parse([]string{"-dry-run", "-tenant", " Acme ", "-tag", "a,b", "-tag", "c", "-admin-email", "ops@example.com"}))
+	require.NoError(t, check())
+	require.Equal(t, 8080, v.Port)
+	require.True(t, v.DryRun)
+	require.Equal(t, "acme", v.Tenant)
+	require.Equal(t, []string{"a", "b", "c"}, v.Tags)
+	require.Equal(t, "ops@example.com", v.Admin.Email)
+}
+
+func TestBindFlagsFallback(t *testing.T) {
+	t.Setenv("TEST_CLI_PORT", "9000")
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	v := &cliOptions{}
+	check := BindFlags(fs, v)
+	require.NoError(t, fs.Parse([]string{"-tenant", "acme"}))
+	require.NoError(t, check())
+	require.Equal(t, 9000, v.Port, "the env variable beats the default")
+	require.Equal(t, "root@example.com", v.Admin.Email)
+
+	fs = flag.NewFlagSet("tool", flag.ContinueOnError)
+	v = &cliOptions{}
+	check = BindFlags(fs, v)
+	require.NoError(t, fs.Parse([]string{"-tenant", "acme", "-port", "7000"}))
+	require.NoError(t, check())
+	require.Equal(t, 7000, v.Port, "the flag beats the env variable")
+}
+
+func TestBindFlagsErrors(t *testing.T) {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	check := BindFlags(fs, &cliOptions{})
+	require.NoError(t, fs.Parse(nil))
+	require.EqualError(t, check(), "field Tenant is required")
+
+	fs = flag.NewFlagSet("tool", flag.ContinueOnError)
+	check = BindFlags(fs, &cliOptions{})
+	require.NoError(t, fs.Parse([]string{"-tenant", "acme", "-admin-email", "nope"}))
+	require.EqualError(t, check(), "field Email is invalid: invalid email address")
+}
+
+func TestBindFlagsUsage(t *testing.T) {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	out := &bytes.Buffer{}
+	fs.SetOutput(out)
+	BindFlags(fs, &cliOptions{})
+	fs.PrintDefaults()
+	require.Contains(t, out.String(), "-port value\n    \tport to listen on (default 8080)")
+	require.Contains(t, out.String(), "-dry-run\n    \tprint what would change")
+}
@@ -0,0 +1,152 @@
+package reqbind
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CloudEventsContentType marks a structured mode CloudEvent
+const CloudEventsContentType = "application/cloudevents+json"
+
+// CloudEvent holds the context attributes of a CloudEvent. Extensions has
+// every attribute that isn't one of the standard ones.
+type CloudEvent struct {
+	ID              string            `json:"id" required:"true"`
+	Source          string            `json:"source" required:"true"`
+	SpecVersion     string            `json:"specversion" required:"true"`
+	Type            string            `json:"type" required:"true"`
+	DataContentType string            `json:"datacontenttype"`
+	DataSchema      string            `json:"dataschema"`
+	Subject         string            `json:"subject"`
+	Time            time.Time         `json:"time"`
+	Extensions      map[string]string `json:"-"`
+}
+
+var cloudEventAttributes = map[string]bool{
+	"id": true, "source": true, "specversion": true, "type": true,
+	"datacontenttype": true, "dataschema": true, "subject": true, "time": true,
+	"data": true, "data_base64": true,
+}
+
+// UnmarshalCloudEvent binds a CloudEvent in either HTTP mode. Structured mode
+// events (application/cloudevents+json) carry everything in the body, binary
+// mode events carry the attributes in ce-* headers and the data as the body.
+// The attributes go into meta and JSON data is bound into v like
+// UnmarshalBody.
+func UnmarshalCloudEvent(r *http.Request, meta *CloudEvent, v interface{}) error {
+	return defaultBinder.UnmarshalCloudEvent(r, meta, v)
+}
+
+// UnmarshalCloudEvent binds a CloudEvent like the package level
+// UnmarshalCloudEvent, with the binder's options applied to the data
+func (b *Binder) UnmarshalCloudEvent(r *http.Request, meta *CloudEvent, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	bodyBytes, err := b.readBody(r)
+	if err != nil {
+		return err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var data []byte
+	if mediaType == CloudEventsContentType {
+		data, err = parseStructuredCloudEvent(bodyBytes, meta)
+	} else {
+		data, err = parseBinaryCloudEvent(r.Header, bodyBytes, meta)
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkMetadata(meta); err != nil {
+		return err
+	}
+
+	presence := bodyPresence(data)
+	if len(data) > 0 {
+		if ct, _, _ := mime.ParseMediaType(meta.DataContentType); ct != "" && ct != "application/json" && !strings.HasSuffix(ct, "+json") {
+			return fmt.Errorf("cannot bind data of type %s", meta.DataContentType)
+		}
+		if err := b.decodeInto(v, func(v interface{}) error {
+			return b.decodeBody(data, v)
+		}); err != nil {
+			return err
+		}
+	}
+	return checkStruct(v, b.checkOptions(r, &presence), "")
+}
+
+func parseStructuredCloudEvent(body []byte, meta *CloudEvent) ([]byte, error) {
+	if err := json.Unmarshal(body, meta); err != nil {
+		return nil, err
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	meta.Extensions = map[string]string{}
+	for name, value := range raw {
+		if cloudEventAttributes[name] {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		meta.Extensions[name] = s
+	}
+
+	if encoded, ok := raw["data_base64"]; ok {
+		var s string
+		if err := json.Unmarshal(encoded, &s); err != nil {
+			return nil, fmt.Errorf("invalid data_base64")
+		}
+		return base64.StdEncoding.DecodeString(s)
+	}
+	if data, ok := raw["data"]; ok && string(data) != "null" {
+		return data, nil
+	}
+	return nil, nil
+}
+
+func parseBinaryCloudEvent(header http.Header, body []byte, meta *CloudEvent) ([]byte, error) {
+	meta.Extensions = map[string]string{}
+	for key := range header {
+		name, ok := strings.CutPrefix(strings.ToLower(key), "ce-")
+		if !ok {
+			continue
+		}
+		value := header.Get(key)
+		switch name {
+		case "id":
+			meta.ID = value
+		case "source":
+			meta.Source = value
+		case "specversion":
+			meta.SpecVersion = value
+		case "type":
+			meta.Type = value
+		case "dataschema":
+			meta.DataSchema = value
+		case "subject":
+			meta.Subject = value
+		case "time":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("field Time is invalid: %s", err)
+			}
+			meta.Time = t
+		default:
+			meta.Extensions[name] = value
+		}
+	}
+	meta.DataContentType = header.Get("Content-Type")
+	return body, nil
+}
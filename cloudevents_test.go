@@ -0,0 +1,91 @@
+package reqbind
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cloudEventOrder struct {
+	OrderID string `json:"orderId" required:"true"`
+}
+
+func TestCloudEventBinary(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{"orderId":"o1"}`)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("ce-id", "1")
+	request.Header.Set("ce-source", "/orders")
+	request.Header.Set("ce-specversion", "1.0")
+	request.Header.Set("ce-type", "order.created")
+	request.Header.Set("ce-time", "2023-01-02T03:04:05Z")
+	request.Header.Set("ce-traceparent", "abc")
+
+	meta := &CloudEvent{}
+	k := &cloudEventOrder{}
+	require.NoError(t, UnmarshalCloudEvent(request, meta, k))
+	require.Equal(t, "order.created", meta.Type)
+	require.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), meta.Time)
+	require.Equal(t, map[string]string{"traceparent": "abc"}, meta.Extensions)
+	require.Equal(t, "o1", k.OrderID)
+}
+
+func TestCloudEventStructured(t *testing.T) {
+	body := `{"specversion":"1.0","id":"1","source":"/orders","type":"order.created","datacontenttype":"application/json","traceparent":"abc","data":{"orderId":"o1"}}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+
+	meta := &CloudEvent{}
+	k := &cloudEventOrder{}
+	require.NoError(t, UnmarshalCloudEvent(request, meta, k))
+	require.Equal(t, "1", meta.ID)
+	require.Equal(t, map[string]string{"traceparent": "abc"}, meta.Extensions)
+	require.Equal(t, "o1", k.OrderID)
+
+	body = `{"specversion":"1.0","id":"1","source":"/orders","type":"order.created","data_base64":"eyJvcmRlcklkIjoibzIifQ=="}`
+	request = httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set("Content-Type", CloudEventsContentType)
+	require.NoError(t, UnmarshalCloudEvent(request, meta, k))
+	require.Equal(t, "o2", k.OrderID)
+}
+
+func TestCloudEventInvalid(t *testing.T) {
+	// missing ce-type
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{"orderId":"o1"}`)))
+	request.Header.Set("ce-id", "1")
+	request.Header.Set("ce-source", "/orders")
+	request.Header.Set("ce-specversion", "1.0")
+	require.Error(t, UnmarshalCloudEvent(request, &CloudEvent{}, &cloudEventOrder{}))
+
+	// data fails validation
+	body := `{"specversion":"1.0","id":"1","source":"/orders","type":"order.created","data":{}}`
+	request = httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set("Content-Type", CloudEventsContentType)
+	require.Error(t, UnmarshalCloudEvent(request, &CloudEvent{}, &cloudEventOrder{}))
+
+	// data isn't json
+	request = httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`<order/>`)))
+	request.Header.Set("Content-Type", "application/xml")
+	request.Header.Set("ce-id", "1")
+	request.Header.Set("ce-source", "/orders")
+	request.Header.Set("ce-specversion", "1.0")
+	request.Header.Set("ce-type", "order.created")
+	require.Error(t, UnmarshalCloudEvent(request, &CloudEvent{}, &cloudEventOrder{}))
+}
+
+func TestCloudEventBinderOptions(t *testing.T) {
+	type order struct {
+		OrderID string `json:"orderId"`
+		Status  string `json:"status" readonly:"true"`
+	}
+	body := `{"specversion":"1.0","id":"1","source":"/orders","type":"order.created","data":{"orderId":"o1","status":"paid"}}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set("Content-Type", CloudEventsContentType)
+	require.EqualError(t, UnmarshalCloudEvent(request, &CloudEvent{}, &order{}), "field Status is read only")
+
+	request = httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set("Content-Type", CloudEventsContentType)
+	require.ErrorIs(t, New(WithMaxBodyBytes(16)).UnmarshalCloudEvent(request, &CloudEvent{}, &order{}), ErrBodyTooLarge)
+}
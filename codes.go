@@ -0,0 +1,110 @@
+package reqbind
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a stable machine readable reason for a failed check, clients can
+// branch on it instead of parsing the message
+type Code string
+
+const (
+	// CodeRequired is a required field that wasn't sent
+	CodeRequired Code = "REQUIRED"
+	// CodeTooLong is a string over its max-length
+	CodeTooLong Code = "TOO_LONG"
+	// CodeTooManyItems is a list over its max-items
+	CodeTooManyItems Code = "TOO_MANY_ITEMS"
+	// CodeTooFewItems is a list under its min-items
+	CodeTooFewItems Code = "TOO_FEW_ITEMS"
+	// CodeDuplicateItems is a unique list with repeated items
+	CodeDuplicateItems Code = "DUPLICATE_ITEMS"
+	// CodeTooManyKeys is a map over its max-keys
+	CodeTooManyKeys Code = "TOO_MANY_KEYS"
+	// CodeOutOfRange is a value outside what's allowed, e.g. a page size
+	// over the maximum
+	CodeOutOfRange Code = "OUT_OF_RANGE"
+	// CodeInvalidEmail is an email address that's malformed or whose domain
+	// isn't allowed
+	CodeInvalidEmail Code = "INVALID_EMAIL"
+	// CodeInvalidPhone is a phone number with too few digits
+	CodeInvalidPhone Code = "INVALID_PHONE"
+	// CodeInvalidUUID is a malformed uuid
+	CodeInvalidUUID Code = "INVALID_UUID"
+	// CodeInvalidCountry is an unknown country code
+	CodeInvalidCountry Code = "INVALID_COUNTRY"
+	// CodeInvalidAddress is a postal address the normalizer couldn't make
+	// sense of
+	CodeInvalidAddress Code = "INVALID_ADDRESS"
+	// CodeInvalidLocation is a malformed geohash
+	CodeInvalidLocation Code = "INVALID_LOCATION"
+	// CodeInvalidFile is an upload whose name or content isn't allowed
+	CodeInvalidFile Code = "INVALID_FILE"
+	// CodeInvalidSlug is a malformed slug
+	CodeInvalidSlug Code = "INVALID_SLUG"
+	// CodeWeakPassword is a password that doesn't meet the policy
+	CodeWeakPassword Code = "WEAK_PASSWORD"
+	// CodeInvalidURL is a resource url that doesn't match its pattern
+	CodeInvalidURL Code = "INVALID_URL"
+	// CodeInvalidFormat is a string that doesn't match its regexp
+	CodeInvalidFormat Code = "INVALID_FORMAT"
+	// CodeInvalidType is a value of the wrong json type
+	CodeInvalidType Code = "INVALID_TYPE"
+	// CodeInvalid is any other invalid value, e.g. a bad sort or filter
+	CodeInvalid Code = "INVALID"
+	// CodeNotOneOf is a discriminator that isn't one of the variants
+	CodeNotOneOf Code = "NOT_ONE_OF"
+	// CodeNotAllowed is a field sent outside its scenario, version or flag
+	CodeNotAllowed Code = "NOT_ALLOWED"
+	// CodeReadOnly is a read only field that was sent
+	CodeReadOnly Code = "READ_ONLY"
+	// CodeForbidden is a field the caller's roles can't set
+	CodeForbidden Code = "FORBIDDEN"
+	// CodeConflict is a parameter sent more than once with different values
+	CodeConflict Code = "CONFLICT"
+	// CodeTooDeep is a value nested too deeply or referring back to itself
+	CodeTooDeep Code = "TOO_DEEP"
+	// CodeInvalidTag is a struct tag that's wrong, a bug on the server
+	// rather than in the request
+	CodeInvalidTag Code = "INVALID_TAG"
+)
+
+// FieldError is a failed check of one field. Its message is the same as
+// the plain error's, find it with errors.As.
+type FieldError struct {
+	// Field is the Go field name, or the key for maps, rules and request
+	// parameters
+	Field string
+	// Code says what went wrong
+	Code Code
+	// subject starts the message, "field", "parameter" or "body"
+	subject string
+	err     error
+}
+
+func (e *FieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+// fieldErrorf makes a FieldError whose message is "field <field> " and
+// the formatted text
+func fieldErrorf(code Code, field string, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Code: code, subject: "field", err: errors.New("field " + field + " " + fmt.Sprintf(format, args...))}
+}
+
+// paramErrorf is fieldErrorf for request parameters, the message starts
+// "parameter <key> "
+func paramErrorf(code Code, key string, format string, args ...interface{}) error {
+	return &FieldError{Field: key, Code: code, subject: "parameter", err: errors.New("parameter " + key + " " + fmt.Sprintf(format, args...))}
+}
+
+// bodyErrorf is fieldErrorf for the body as a whole, such as a body over
+// the json limits. Field is empty and the message starts "body ".
+func bodyErrorf(code Code, format string, args ...interface{}) error {
+	return &FieldError{Code: code, subject: "body", err: errors.New("body " + fmt.Sprintf(format, args...))}
+}
@@ -0,0 +1,170 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type codedLine struct {
+	SKU string `json:"sku" required:"true"`
+}
+
+type codedOrder struct {
+	Name    string      `json:"name" max-length:"5"`
+	Email   string      `json:"email" validate:"email"`
+	Country string      `json:"country" validate:"country"`
+	Code    string      `json:"code" regexp:"^[A-Z]*$"`
+	Tags    []string    `json:"tags" max-items:"1"`
+	Lines   []codedLine `json:"lines"`
+	Owner   string      `json:"owner" readonly:"true"`
+	Admin   bool        `json:"admin" allow-roles:"admin"`
+	Bad     string      `json:"bad" max-length:"x"`
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		body  string
+		field string
+		code  Code
+	}{
+		{body: `{"name":"too long"}`, field: "Name", code: CodeTooLong},
+		{body: `{"email":"nope"}`, field: "Email", code: CodeInvalidEmail},
+		{body: `{"country":"XX"}`, field: "Country", code: CodeInvalidCountry},
+		{body: `{"code":"abc"}`, field: "Code", code: CodeInvalidFormat},
+		{body: `{"tags":["a","b"]}`, field: "Tags", code: CodeTooManyItems},
+		{body: `{"lines":[{}]}`, field: "SKU", code: CodeRequired},
+		{body: `{"owner":"me"}`, field: "Owner", code: CodeReadOnly},
+		{body: `{"admin":true}`, field: "Admin", code: CodeForbidden},
+		{body: `{"bad":"a"}`, field: "Bad", code: CodeInvalidTag},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.co","country":"US",`+test.body[1:]))
+			require.NoError(t, err)
+			err = New(WithDescriptionHints()).UnmarshalBody(request, &codedOrder{})
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr), err)
+			require.Equal(t, test.field, fieldErr.Field)
+			require.Equal(t, test.code, fieldErr.Code)
+		})
+	}
+}
+
+func TestErrorCodesKeepMessages(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.co","country":"US","admin":true}`))
+	require.NoError(t, err)
+	err = UnmarshalBody(request, &codedOrder{})
+	require.EqualError(t, err, "forbidden: field Admin can not be set")
+	require.True(t, errors.Is(err, ErrForbidden))
+
+	// every aggregated error has its own code
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"email":"nope","country":"US","name":"too long"}`))
+	require.NoError(t, err)
+	var errs Errors
+	require.True(t, errors.As(New(WithAggregateErrors()).UnmarshalBody(request, &codedOrder{}), &errs))
+	var codes []Code
+	for _, err := range errs {
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		codes = append(codes, fieldErr.Code)
+	}
+	require.Equal(t, []Code{CodeTooLong, CodeInvalidEmail, CodeInvalidTag}, codes)
+
+	// keys are never read as format verbs
+	request, err = http.NewRequest("GET", "/?a%25d=1&a%25d=2", nil)
+	require.NoError(t, err)
+	err = New(WithConflictPolicy(ConflictError)).UnmarshalQuery(request, &struct {
+		A string `json:"a%d"`
+	}{})
+	require.EqualError(t, err, "parameter a%d was sent more than once")
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	require.Equal(t, CodeConflict, fieldErr.Code)
+}
+
+func TestErrorCodesOutsideFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		bind    func(r *http.Request) error
+		target  string
+		body    string
+		code    Code
+		message string
+	}{
+		{
+			name:    "unknown query parameter",
+			bind:    func(r *http.Request) error { return New(WithStrictQuery()).UnmarshalQuery(r, &codedLine{}) },
+			target:  "/?sku=a&skuu=b",
+			code:    CodeNotAllowed,
+			message: "parameter skuu is unknown",
+		},
+		{
+			name:    "json:api document without data",
+			bind:    func(r *http.Request) error { return UnmarshalJSONAPI(r, "lines", &codedLine{}) },
+			body:    `{}`,
+			code:    CodeRequired,
+			message: "field data is required",
+		},
+		{
+			name:    "json:api document of another type",
+			bind:    func(r *http.Request) error { return UnmarshalJSONAPI(r, "lines", &codedLine{}) },
+			body:    `{"data":{"type":"orders"}}`,
+			code:    CodeInvalid,
+			message: "field type is orders, not lines",
+		},
+		{
+			name: "too many keys",
+			bind: func(r *http.Request) error {
+				return New(WithJSONLimits(JSONLimits{MaxKeys: 1})).UnmarshalBody(r, &codedLine{})
+			},
+			body:    `{"sku":"a","b":1}`,
+			code:    CodeTooManyKeys,
+			message: "body has more than 1 keys",
+		},
+		{
+			name: "too many items",
+			bind: func(r *http.Request) error {
+				return New(WithJSONLimits(JSONLimits{MaxItems: 1})).UnmarshalBody(r, &codedLine{})
+			},
+			body:    `{"sku":"a","b":[1,2]}`,
+			code:    CodeTooManyItems,
+			message: "body has an array with more than 1 items",
+		},
+		{
+			name: "too deep",
+			bind: func(r *http.Request) error {
+				return New(WithJSONLimits(JSONLimits{MaxDepth: 1})).UnmarshalBody(r, &codedLine{})
+			},
+			body:    `{"sku":"a","b":{}}`,
+			code:    CodeTooDeep,
+			message: "body is nested more than 1 levels deep",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := test.target
+			if target == "" {
+				target = "/"
+			}
+			request, err := http.NewRequest("POST", target, strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = test.bind(request)
+			require.EqualError(t, err, test.message)
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr))
+			require.Equal(t, test.code, fieldErr.Code)
+		})
+	}
+
+	// production verbosity words a body error without the field name
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"sku":"a","b":{}}`))
+	require.NoError(t, err)
+	err = New(WithJSONLimits(JSONLimits{MaxDepth: 1}), WithVerbosity(VerbosityProduction)).UnmarshalBody(request, &codedLine{})
+	require.EqualError(t, err, "body is nested too deeply")
+}
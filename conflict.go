@@ -0,0 +1,221 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ConflictPolicy decides which value is bound when a parameter is sent more
+// than once, either repeated in the query or in both the query and the body
+type ConflictPolicy int
+
+const (
+	// ConflictFirstWins binds the first value, the query wins over the body
+	ConflictFirstWins ConflictPolicy = iota
+	// ConflictLastWins binds the last value, the body wins over the query
+	ConflictLastWins
+	// ConflictError fails the bind when the values differ
+	ConflictError
+)
+
+// WithConflictPolicy sets how repeated parameters are resolved, the default
+// is ConflictFirstWins
+func WithConflictPolicy(policy ConflictPolicy) Option {
+	return func(b *Binder) {
+		b.conflicts = policy
+	}
+}
+
+// WithSingleValues treats every parameter as if it were tagged
+// single:"true"
+func WithSingleValues() Option {
+	return func(b *Binder) {
+		b.single = true
+	}
+}
+
+// checkSingle rejects parameter pollution such as ?user=me&user=admin for
+// fields tagged single:"true". A single parameter can't be repeated in the
+// query or sent in both the query and the body, even with the same value.
+func (b *Binder) checkSingle(query url.Values, body *Presence, v interface{}) error {
+	t := reflect.TypeOf(v).Elem()
+	for key, values := range query {
+		f, ok := fieldByJSONNameFold(t, querySegments(t, key)[0])
+		if !b.single && (!ok || f.Tag.Get("single") != "true") {
+			continue
+		}
+		if len(values) > 1 || (body != nil && body.Has(key)) {
+			return paramErrorf(CodeConflict, key, "must only be sent once")
+		}
+	}
+	return nil
+}
+
+// pickValue chooses one of a query parameter's values
+func pickValue(key string, values []string, policy ConflictPolicy) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	switch policy {
+	case ConflictLastWins:
+		return values[len(values)-1], nil
+	case ConflictError:
+		for _, value := range values[1:] {
+			if value != values[0] {
+				return "", paramErrorf(CodeConflict, key, "was sent more than once")
+			}
+		}
+	}
+	return values[0], nil
+}
+
+// Conflict is a field that was sent with different values in more than one
+// place, e.g. ?id=1 on the route /users/2
+type Conflict struct {
+	// Path is the json name of the field
+	Path string
+	// Sources are where the field was sent: "path", "query" or "body", in
+	// that order. A repeated query parameter is listed once per value.
+	Sources []string
+	// Values are the values that were sent, in the same order as Sources
+	Values []string
+	// Source is where the bound value came from
+	Source string
+}
+
+// Conflicts are the conflicts of one request in field order
+type Conflicts []Conflict
+
+// BindWithConflicts binds like Bind and also returns the fields that were
+// sent with different values, which says why a field didn't get the value
+// the client thinks it sent. Fields with a source tag are left out because
+// their chain already decides.
+func BindWithConflicts(r *http.Request, v interface{}) (Conflicts, error) {
+	return defaultBinder.BindWithConflicts(r, v)
+}
+
+// BindWithConflicts binds like the package level BindWithConflicts
+func (b *Binder) BindWithConflicts(r *http.Request, v interface{}) (_ Conflicts, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	conflicts := Conflicts{}
+	err = b.bind(r, v, func(opts *checkOptions) {
+		opts.conflicts = &conflicts
+	})
+	return conflicts, err
+}
+
+// findConflicts compares the top level values of the path, query and body.
+// Values are compared as text, so 42 in the body is the same as "42" in the
+// path.
+func (b *Binder) findConflicts(r *http.Request, rctx *chi.Context, bodyBytes []byte, v interface{}) Conflicts {
+	t := reflect.TypeOf(v).Elem()
+	sent := map[string]*Conflict{}
+	var order []string
+	add := func(key string, source string, value string) {
+		f, ok := fieldByJSONNameFold(t, key)
+		if !ok || f.Tag.Get("source") != "" {
+			return
+		}
+		c, ok := sent[f.Name]
+		if !ok {
+			c = &Conflict{Path: jsonName(f)}
+			sent[f.Name] = c
+			order = append(order, f.Name)
+		}
+		c.Sources = append(c.Sources, source)
+		c.Values = append(c.Values, value)
+	}
+
+	if rctx != nil {
+		for i, key := range rctx.URLParams.Keys {
+			add(key, "path", rctx.URLParams.Values[i])
+		}
+	}
+	for key, values := range r.URL.Query() {
+		if len(querySegments(t, key)) > 1 {
+			continue
+		}
+		for _, value := range values {
+			add(key, "query", value)
+		}
+	}
+	var body map[string]json.RawMessage
+	_ = json.Unmarshal(bodyBytes, &body)
+	for key, raw := range body {
+		add(key, "body", rawText(raw))
+	}
+
+	conflicts := Conflicts{}
+	for _, name := range order {
+		c := sent[name]
+		if !differs(c.Values) {
+			continue
+		}
+		c.Source = b.winner(c)
+		conflicts = append(conflicts, *c)
+	}
+	return conflicts
+}
+
+// winner is the source whose value ends up bound, path parameters are
+// bound last and win, the query and body are picked by the conflict policy
+func (b *Binder) winner(c *Conflict) string {
+	has := map[string]bool{}
+	for _, source := range c.Sources {
+		has[source] = true
+	}
+	order := []string{"path", "query", "body"}
+	if b.conflicts == ConflictLastWins {
+		order = []string{"path", "body", "query"}
+	}
+	for _, source := range order {
+		if has[source] {
+			return source
+		}
+	}
+	return ""
+}
+
+// conflictError is the strict mode error for the first conflict
+func conflictError(conflicts Conflicts) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	c := conflicts[0]
+	for _, source := range c.Sources[1:] {
+		if source != c.Sources[0] {
+			return paramErrorf(CodeConflict, c.Path, "was sent with different values in the %s and the %s", c.Sources[0], source)
+		}
+	}
+	return paramErrorf(CodeConflict, c.Path, "was sent more than once")
+}
+
+func differs(values []string) bool {
+	for _, value := range values[1:] {
+		if value != values[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// rawText is a json value as text, strings without their quotes
+func rawText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
@@ -0,0 +1,167 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type conflictQuery struct {
+	User string `json:"user"`
+}
+
+func TestConflictPolicyQuery(t *testing.T) {
+	tests := []struct {
+		policy     ConflictPolicy
+		query      string
+		expected   string
+		shouldPass bool
+	}{
+		{policy: ConflictFirstWins, query: "user=me&user=admin", expected: "me", shouldPass: true},
+		{policy: ConflictLastWins, query: "user=me&user=admin", expected: "admin", shouldPass: true},
+		{policy: ConflictError, query: "user=me&user=admin", shouldPass: false},
+		{policy: ConflictError, query: "user=me&user=me", expected: "me", shouldPass: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			k := &conflictQuery{}
+			request, err := http.NewRequest("GET", "/?"+test.query, nil)
+			require.NoError(t, err)
+			err = New(WithConflictPolicy(test.policy)).UnmarshalQuery(request, k)
+			if !test.shouldPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, k.User)
+		})
+	}
+}
+
+func TestConflictPolicyQueryAndBody(t *testing.T) {
+	tests := []struct {
+		policy     ConflictPolicy
+		expected   string
+		shouldPass bool
+	}{
+		{policy: ConflictFirstWins, expected: "me", shouldPass: true},
+		{policy: ConflictLastWins, expected: "admin", shouldPass: true},
+		{policy: ConflictError, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			k := &conflictQuery{}
+			request, err := http.NewRequest("POST", "/?user=me", strings.NewReader(`{"User":"admin"}`))
+			require.NoError(t, err)
+			_, err = New(WithConflictPolicy(test.policy)).BindWithPresence(request, k)
+			if !test.shouldPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, k.User)
+		})
+	}
+}
+
+func TestSingle(t *testing.T) {
+	type query struct {
+		User   string `json:"user" single:"true"`
+		Status string `json:"status"`
+	}
+
+	tests := []struct {
+		binder     *Binder
+		query      string
+		body       string
+		shouldPass bool
+	}{
+		{binder: defaultBinder, query: "user=me&status=a&status=b", shouldPass: true},
+		{binder: defaultBinder, query: "user=me&user=admin", shouldPass: false},
+		{binder: defaultBinder, query: "user=me&user=me", shouldPass: false},
+		{binder: defaultBinder, query: "user=me", body: `{"user":"admin"}`, shouldPass: false},
+		{binder: defaultBinder, query: "status=a", body: `{"status":"b"}`, shouldPass: true},
+		{binder: New(WithSingleValues()), query: "status=a&status=b", shouldPass: false},
+		{binder: New(WithSingleValues()), query: "status=a", body: `{"status":"b"}`, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query+test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?"+test.query, strings.NewReader(test.body))
+			require.NoError(t, err)
+			_, err = test.binder.BindWithPresence(request, &query{})
+			if !test.shouldPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	request, err := http.NewRequest("GET", "/?user=me&user=admin", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, &query{}))
+}
+
+type conflictOrder struct {
+	ID    string `json:"id"`
+	Count int    `json:"count"`
+	Note  string `json:"note" source:"query,body"`
+}
+
+func TestBindWithConflicts(t *testing.T) {
+	tests := []struct {
+		policy   ConflictPolicy
+		query    string
+		body     string
+		expected Conflicts
+		id       string
+	}{
+		{policy: ConflictFirstWins, query: "id=1", expected: Conflicts{{Path: "id", Sources: []string{"path", "query"}, Values: []string{"7", "1"}, Source: "path"}}, id: "7"},
+		{policy: ConflictFirstWins, query: "id=7", body: `{"count":2}`, expected: Conflicts{}, id: "7"},
+		{policy: ConflictFirstWins, query: "count=3", body: `{"count":2,"note":"a"}`, expected: Conflicts{{Path: "count", Sources: []string{"query", "body"}, Values: []string{"3", "2"}, Source: "query"}}, id: "7"},
+		{policy: ConflictFirstWins, query: "count=2", body: `{"count":2}`, expected: Conflicts{}, id: "7"},
+		{policy: ConflictLastWins, body: `{"id":"8"}`, expected: Conflicts{{Path: "id", Sources: []string{"path", "body"}, Values: []string{"7", "8"}, Source: "path"}}, id: "7"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query+test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?"+test.query, strings.NewReader(test.body))
+			require.NoError(t, err)
+			request = withURLParams(request, map[string]string{"id": "7"})
+			k := &conflictOrder{}
+			conflicts, err := New(WithConflictPolicy(test.policy)).BindWithConflicts(request, k)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, conflicts)
+			require.Equal(t, test.id, k.ID)
+		})
+	}
+}
+
+func TestConflictErrorWithPath(t *testing.T) {
+	binder := New(WithConflictPolicy(ConflictError))
+
+	request, err := http.NewRequest("GET", "/?id=1", nil)
+	require.NoError(t, err)
+	err = binder.Bind(withURLParams(request, map[string]string{"id": "7"}), &conflictOrder{})
+	require.EqualError(t, err, "parameter id was sent with different values in the path and the query")
+
+	request, err = http.NewRequest("GET", "/?id=7", nil)
+	require.NoError(t, err)
+	require.NoError(t, binder.Bind(withURLParams(request, map[string]string{"id": "7"}), &conflictOrder{}))
+}
+
+// withURLParams adds a chi route context with params to request
+func withURLParams(request *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+}
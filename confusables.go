@@ -0,0 +1,70 @@
+package reqbind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// zeroWidth are invisible characters that make two handles look the same
+var zeroWidth = map[rune]bool{
+	'\u00ad': true, // soft hyphen
+	'\u180e': true, // mongolian vowel separator
+	'\u200b': true, // zero width space
+	'\u200c': true, // zero width non-joiner
+	'\u200d': true, // zero width joiner
+	'\u2060': true, // word joiner
+	'\ufeff': true, // zero width no-break space
+}
+
+// homoglyphs maps common lookalikes of latin letters to the letter
+var homoglyphs = map[rune]rune{
+	// cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'к': 'k', 'м': 'm',
+	'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c', 'ѕ': 's', 'т': 't', 'у': 'y', 'х': 'x',
+	'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'І': 'I', 'Ј': 'J', 'К': 'K', 'М': 'M', 'Н': 'H',
+	'О': 'O', 'Р': 'P', 'С': 'C', 'Ѕ': 'S', 'Т': 'T', 'У': 'Y', 'Х': 'X',
+	// greek
+	'α': 'a', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+	'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
+
+// foldConfusables strips zero width characters and maps homoglyphs and
+// fullwidth forms to the ascii characters they look like
+func foldConfusables(s string) string {
+	return strings.Map(func(r rune) rune {
+		if zeroWidth[r] {
+			return -1
+		}
+		if mapped, ok := homoglyphs[r]; ok {
+			return mapped
+		}
+		// fullwidth ! to ~
+		if r >= '\uff01' && r <= '\uff5e' {
+			return r - 0xfee0
+		}
+		return r
+	}, s)
+}
+
+// applyModifiers runs the modifier tag, a comma separated list of changes
+// made to a string before it's checked
+func applyModifiers(f reflect.StructField, value reflect.Value) error {
+	modifiers := f.Tag.Get("modifier")
+	if modifiers == "" {
+		return nil
+	}
+	if value.Kind() != reflect.String {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has modifier but is not a string")
+	}
+	for _, modifier := range strings.Split(modifiers, ",") {
+		switch strings.TrimSpace(modifier) {
+		case "confusables":
+			value.SetString(foldConfusables(value.String()))
+		default:
+			return fieldErrorf(CodeInvalidTag, f.Name, "has unknown modifier %s", modifier)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFoldConfusables(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "admin", expected: "admin"},
+		{value: "аdmin", expected: "admin"},
+		{value: "ad\u200bmin", expected: "admin"},
+		{value: "\ufeffpaypal", expected: "paypal"},
+		{value: "ＡＤＭＩＮ", expected: "ADMIN"},
+		{value: "ΡΑΥΡΑΙ", expected: "PAYPAI"},
+		{value: "日本", expected: "日本"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			require.Equal(t, test.expected, foldConfusables(test.value))
+		})
+	}
+}
+
+func TestConfusablesModifier(t *testing.T) {
+	k := &struct {
+		Handle string `json:"handle" modifier:"confusables" trimlower:"true" max-length:"5"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader("{\"handle\":\"Аd\u200bmin\"}"))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "admin", k.Handle)
+
+	require.Error(t, checkMetadata(&struct {
+		Handle string `modifier:"shiny"`
+	}{}))
+	require.Error(t, checkMetadata(&struct {
+		Count int `modifier:"confusables"`
+	}{}))
+}
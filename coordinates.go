@@ -0,0 +1,123 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LatLng is a point on the earth in degrees. It binds from a "lat,lng"
+// string in the query, headers or body, or a {"lat":..,"lng":..} object in
+// the body, and anything outside -90..90 or -180..180 is rejected.
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+var latLngType = reflect.TypeOf(LatLng{})
+
+// ParseLatLng parses a "lat,lng" string such as "37.7749,-122.4194"
+func ParseLatLng(value string) (LatLng, error) {
+	latStr, lngStr, ok := strings.Cut(value, ",")
+	if !ok {
+		return LatLng{}, fmt.Errorf("invalid coordinates, expected lat,lng")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return LatLng{}, fmt.Errorf("invalid latitude")
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return LatLng{}, fmt.Errorf("invalid longitude")
+	}
+	l := LatLng{Lat: lat, Lng: lng}
+	return l, l.validate()
+}
+
+// validate checks the point is on the earth
+func (l LatLng) validate() error {
+	if math.IsNaN(l.Lat) || l.Lat < -90 || l.Lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(l.Lng) || l.Lng < -180 || l.Lng > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// UnmarshalText lets a LatLng be bound straight from a header or query value
+func (l *LatLng) UnmarshalText(text []byte) error {
+	parsed, err := ParseLatLng(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts a "lat,lng" string or a {"lat","lng"} object
+func (l *LatLng) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return l.UnmarshalText([]byte(s))
+	}
+	// without the methods, so the object decodes the usual way
+	type latLng LatLng
+	var parsed latLng
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	if err := LatLng(parsed).validate(); err != nil {
+		return err
+	}
+	*l = LatLng(parsed)
+	return nil
+}
+
+func (l LatLng) String() string {
+	return strconv.FormatFloat(l.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lng, 'f', -1, 64)
+}
+
+// geohashAlphabet is the base32 alphabet of geohashes, without a, i, l and o
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashLength is the longest geohash accepted, 12 characters are
+// already finer than 4cm
+const MaxGeohashLength = 12
+
+// checkGeohash lower cases a geohash and checks its characters and length,
+// a precision=N option rejects hashes coarser than N characters
+func checkGeohash(f reflect.StructField, value reflect.Value, options map[string]string) error {
+	hash := strings.ToLower(value.String())
+	// leave empty values to the required check
+	if hash == "" {
+		return nil
+	}
+	precision := 1
+	if p, ok := options["precision"]; ok {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 || n > MaxGeohashLength {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid geohash precision")
+		}
+		precision = n
+	}
+	if len(hash) > MaxGeohashLength {
+		return fieldErrorf(CodeInvalidLocation, f.Name, "is invalid: geohash is longer than %d characters", MaxGeohashLength)
+	}
+	if len(hash) < precision {
+		return fieldErrorf(CodeInvalidLocation, f.Name, "is invalid: geohash must be at least %d characters", precision)
+	}
+	for _, r := range hash {
+		if !strings.ContainsRune(geohashAlphabet, r) {
+			return fieldErrorf(CodeInvalidLocation, f.Name, "is invalid: invalid geohash character %q", r)
+		}
+	}
+	value.SetString(hash)
+	return nil
+}
@@ -0,0 +1,107 @@
+package reqbind
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatLng(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected LatLng
+		err      string
+	}{
+		{name: "string", body: `{"at":"37.7749,-122.4194"}`, expected: LatLng{Lat: 37.7749, Lng: -122.4194}},
+		{name: "spaces", body: `{"at":" -33.8688 , 151.2093 "}`, expected: LatLng{Lat: -33.8688, Lng: 151.2093}},
+		{name: "object", body: `{"at":{"lat":51.5,"lng":-0.12}}`, expected: LatLng{Lat: 51.5, Lng: -0.12}},
+		{name: "edges", body: `{"at":"-90,180"}`, expected: LatLng{Lat: -90, Lng: 180}},
+		{name: "no comma", body: `{"at":"37.7749"}`, err: "invalid coordinates, expected lat,lng"},
+		{name: "bad latitude", body: `{"at":"north,1"}`, err: "invalid latitude"},
+		{name: "bad longitude", body: `{"at":"1,east"}`, err: "invalid longitude"},
+		{name: "latitude out of range", body: `{"at":"90.1,0"}`, err: "latitude must be between -90 and 90"},
+		{name: "longitude out of range", body: `{"at":{"lat":0,"lng":-181}}`, err: "longitude must be between -180 and 180"},
+		{name: "nan", body: `{"at":"NaN,0"}`, err: "latitude must be between -90 and 90"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k := &struct {
+				At LatLng `json:"at"`
+			}{}
+			err := UnmarshalBody(httptest.NewRequest("POST", "/", bytes.NewBufferString(test.body)), k)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, k.At)
+		})
+	}
+}
+
+func TestLatLngQuery(t *testing.T) {
+	k := &struct {
+		Near *LatLng `json:"near" required:"true"`
+	}{}
+	require.NoError(t, UnmarshalQuery(httptest.NewRequest("GET", "/?near=37.7749,-122.4194", nil), k))
+	require.Equal(t, &LatLng{Lat: 37.7749, Lng: -122.4194}, k.Near)
+	require.Equal(t, "37.7749,-122.4194", k.Near.String())
+
+	require.EqualError(t, UnmarshalQuery(httptest.NewRequest("GET", "/?near=95,0", nil), k), "latitude must be between -90 and 90")
+}
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		tag      string
+		value    string
+		expected string
+		err      string
+	}{
+		{tag: "geohash", value: "9q8yyk8yt", expected: "9q8yyk8yt"},
+		{tag: "geohash", value: "9Q8YY", expected: "9q8yy"},
+		{tag: "geohash", value: "9q8ya", err: "field Hash is invalid: invalid geohash character 'a'"},
+		{tag: "geohash", value: "9q8yyk8ytpxr0", err: "field Hash is invalid: geohash is longer than 12 characters"},
+		{tag: "geohash,precision=6", value: "9q8yyk", expected: "9q8yyk"},
+		{tag: "geohash,precision=6", value: "9q8yy", err: "field Hash is invalid: geohash must be at least 6 characters"},
+		{tag: "geohash,precision=13", value: "9q8yy", err: "field Hash has invalid geohash precision"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag+" "+test.value, func(t *testing.T) {
+			k := reflect.New(reflect.StructOf([]reflect.StructField{
+				{Name: "Hash", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`json:"hash" validate:"` + test.tag + `"`)},
+			}))
+			body := `{"hash":"` + test.value + `"}`
+			err := UnmarshalBody(httptest.NewRequest("POST", "/", bytes.NewBufferString(body)), k.Interface())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, k.Elem().Field(0).String())
+		})
+	}
+}
+
+func TestLatLngGenerated(t *testing.T) {
+	type place struct {
+		At   LatLng `json:"at" required:"true"`
+		Hash string `json:"hash" required:"true" validate:"geohash,precision=10"`
+	}
+	body, _, err := Example[place]()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"at":"37.7749,-122.4194","hash":"9q8yyk8ytpxr"}`, string(body))
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		body, err := GenerateValid[place](r)
+		require.NoError(t, err)
+		require.NoError(t, checkGenerated[place](body), string(body))
+	}
+}
@@ -0,0 +1,154 @@
+package reqbind
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// DefaultDataURIMaxBytes caps the decoded size of a data URI when its field
+// has no max-file-size tag
+const DefaultDataURIMaxBytes = 1 << 20
+
+// sniffedTypes are the types http.DetectContentType recognizes for sure,
+// content declared as one of them has to sniff as it
+var sniffedTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"application/pdf": true,
+}
+
+// dataURIFields adds the []byte fields of t tagged datauri:"image/png,..."
+// to fields by json name, including the ones of embedded structs
+func dataURIFields(t reflect.Type, fields map[string]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" {
+			dataURIFields(f.Type, fields)
+			continue
+		}
+		if f.Tag.Get("datauri") != "" && !isIgnored(f) {
+			fields[strings.ToLower(jsonName(f))] = f
+		}
+	}
+}
+
+// decodeDataURIs replaces the data URIs sent for the datauri fields of t
+// with their base64 content, so they decode into []byte the usual way.
+// Objects without datauri fields are returned as they are.
+func decodeDataURIs(data []byte, t reflect.Type) ([]byte, error) {
+	fields := make(map[string]reflect.StructField)
+	dataURIFields(t, fields)
+	if len(fields) == 0 || len(data) == 0 {
+		return data, nil
+	}
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		// not an object, decoding reports the problem
+		return data, nil
+	}
+	changed := false
+	for key, raw := range object {
+		f, ok := fields[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+		var uri string
+		if err := json.Unmarshal(raw, &uri); err != nil {
+			continue
+		}
+		content, err := parseDataURI(f, uri)
+		if err != nil {
+			return nil, err
+		}
+		if object[key], err = json.Marshal(content); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(object)
+}
+
+// parseDataURI decodes a data:image/png;base64,... uri for f and checks its
+// media type against the datauri tag, its content against the media type
+// and its decoded size against max-file-size. Images are checked against
+// max-width and max-height too.
+func parseDataURI(f reflect.StructField, uri string) ([]byte, error) {
+	if f.Type != bytesType {
+		return nil, fieldErrorf(CodeInvalidTag, f.Name, "has datauri but is not a []byte")
+	}
+	if uri == "" {
+		return nil, nil
+	}
+	rest, ok := strings.CutPrefix(uri, "data:")
+	if !ok {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: expected a data URI")
+	}
+	header, payload, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: data URI has no content")
+	}
+	header, isBase64 := strings.CutSuffix(header, ";base64")
+	if header == "" {
+		header = "text/plain;charset=US-ASCII"
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: data URI has an invalid media type")
+	}
+	if !matchesMediaType(splitList(f.Tag.Get("datauri")), mediaType) {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: content type %s is not allowed", mediaType)
+	}
+
+	maxSize, limit := int64(DefaultDataURIMaxBytes), "1MB"
+	if tag := f.Tag.Get("max-file-size"); tag != "" {
+		if maxSize, err = maxFileSize(f); err != nil {
+			return nil, err
+		}
+		limit = tag
+	}
+	tooLarge := fieldErrorf(CodeInvalidFile, f.Name, "is invalid: file is larger than %s", limit)
+	var content []byte
+	if isBase64 {
+		// the size is known before decoding, so nothing big is allocated
+		if int64(base64.StdEncoding.DecodedLen(len(payload))) > maxSize+2 {
+			return nil, tooLarge
+		}
+		if content, err = base64.StdEncoding.DecodeString(payload); err != nil {
+			if content, err = base64.RawStdEncoding.DecodeString(payload); err != nil {
+				return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: data URI is not valid base64")
+			}
+		}
+	} else {
+		if int64(len(payload)) > maxSize*3 {
+			return nil, tooLarge
+		}
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: data URI is not valid url encoding")
+		}
+		content = []byte(unescaped)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, tooLarge
+	}
+
+	sniffed, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if sniffed != mediaType && (sniffable(sniffed) || sniffedTypes[mediaType]) {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: content is %s, not %s", sniffed, mediaType)
+	}
+	if err := checkImageSize(f, &File{data: content, Size: int64(len(content)), ContentType: mediaType}); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
@@ -0,0 +1,75 @@
+package reqbind
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataURI(t *testing.T) {
+	type avatar struct {
+		Name  string `json:"name"`
+		Image []byte `json:"image" datauri:"image/png,image/gif" max-file-size:"1KB"`
+	}
+	pngURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(pngHeader+"pixels"))
+	tests := []struct {
+		name  string
+		image string
+		want  string
+		err   string
+	}{
+		{"png", pngURI, pngHeader + "pixels", ""},
+		{"unpadded", strings.TrimRight("data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte(pngHeader+"p")), "="), pngHeader + "p", ""},
+		{"empty", "", "", ""},
+		{"plain base64", base64.StdEncoding.EncodeToString([]byte(pngHeader)), "", "field Image is invalid: expected a data URI"},
+		{"no content", "data:image/png;base64", "", "field Image is invalid: data URI has no content"},
+		{"not allowed", "data:image/jpeg;base64,AAAA", "", "field Image is invalid: content type image/jpeg is not allowed"},
+		{"mismatch", "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("GIF89a....")), "", "field Image is invalid: content is image/gif, not image/png"},
+		{"not an image", "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte{0, 1, 2, 3}), "", "field Image is invalid: content is application/octet-stream, not image/png"},
+		{"bad base64", "data:image/png;base64,!!!!", "", "field Image is invalid: data URI is not valid base64"},
+		{"too large", "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(pngHeader+strings.Repeat("x", 1024))), "", "field Image is invalid: file is larger than 1KB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &avatar{}
+			request := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"me","image":"`+tt.image+`"}`))
+			err := UnmarshalBody(request, v)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "me", v.Name)
+			require.Equal(t, tt.want, string(v.Image))
+		})
+	}
+}
+
+func TestDataURIImageSize(t *testing.T) {
+	v := &struct {
+		Icon []byte `json:"icon" datauri:"image/*" max-width:"8"`
+	}{}
+	uri := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(encodePNG(t, 16, 4)))
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"icon":"`+uri+`"}`))
+	require.EqualError(t, UnmarshalBody(request, v), "field Icon is invalid: image is wider than 8 pixels")
+}
+
+func TestDataURIText(t *testing.T) {
+	v := &struct {
+		Note []byte `json:"note" datauri:"text/plain"`
+	}{}
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"note":"data:,hello%20world"}`))
+	require.NoError(t, UnmarshalBody(request, v))
+	require.Equal(t, "hello world", string(v.Note))
+}
+
+func TestDataURIInvalidTag(t *testing.T) {
+	v := &struct {
+		Note string `json:"note" datauri:"text/plain"`
+	}{}
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"note":"data:,hi"}`))
+	require.EqualError(t, UnmarshalBody(request, v), "field Note has datauri but is not a []byte")
+}
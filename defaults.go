@@ -0,0 +1,160 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// applyDefaultFrom fills a field that wasn't sent from a sibling, the tag is
+// the sibling's name with an optional offset:
+//
+//	EndDate time.Time `default-from:"StartDate+24h"`
+//	Limit   int       `default-from:"PageSize*2"`
+//
+// Times and durations take a duration offset, numbers take a number and
+// strings are copied as they are. Nothing happens when the sibling is zero.
+func applyDefaultFrom(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	expression := f.Tag.Get("default-from")
+	if expression == "" || !parent.IsValid() {
+		return nil
+	}
+	if opts.presence != nil && opts.presence.Has(path) {
+		return nil
+	}
+	if !value.IsZero() {
+		return nil
+	}
+
+	name, op, operand := splitExpression(expression)
+	sibling := parent.FieldByName(name)
+	if !sibling.IsValid() {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has default-from but there is no field %s", name)
+	}
+	if sibling.Kind() == reflect.Ptr {
+		if sibling.IsNil() {
+			return nil
+		}
+		sibling = sibling.Elem()
+	}
+	if sibling.IsZero() {
+		return nil
+	}
+
+	result, err := evaluateDefault(sibling, op, operand)
+	if err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid default-from: %s", err)
+	}
+	target := value
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+	if !result.Type().ConvertibleTo(target.Type()) {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has default-from %s of a different type", name)
+	}
+	target.Set(result.Convert(target.Type()))
+	return nil
+}
+
+// splitExpression splits StartDate+24h into StartDate, + and 24h
+func splitExpression(expression string) (string, byte, string) {
+	expression = strings.TrimSpace(expression)
+	if i := strings.IndexAny(expression, "+-*"); i > 0 {
+		return strings.TrimSpace(expression[:i]), expression[i], strings.TrimSpace(expression[i+1:])
+	}
+	return expression, 0, ""
+}
+
+// evaluateDefault applies the operator and operand to the sibling's value
+func evaluateDefault(sibling reflect.Value, op byte, operand string) (reflect.Value, error) {
+	if op == 0 {
+		return sibling, nil
+	}
+
+	switch {
+	case sibling.Type() == timeType:
+		if op == '*' {
+			return reflect.Value{}, fmt.Errorf("times can only be added to")
+		}
+		d, err := parseOffset(op, operand)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(sibling.Interface().(time.Time).Add(d)), nil
+	case sibling.Type() == durationType && op != '*':
+		d, err := parseOffset(op, operand)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(time.Duration(sibling.Int()) + d).Convert(sibling.Type()), nil
+	}
+
+	switch sibling.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(operand, 10, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s is not an integer", operand)
+		}
+		result := reflect.New(sibling.Type()).Elem()
+		result.SetInt(applyOp(sibling.Int(), op, n))
+		return result, nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(operand, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s is not a number", operand)
+		}
+		result := reflect.New(sibling.Type()).Elem()
+		switch op {
+		case '+':
+			result.SetFloat(sibling.Float() + n)
+		case '-':
+			result.SetFloat(sibling.Float() - n)
+		default:
+			result.SetFloat(sibling.Float() * n)
+		}
+		return result, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%s values can't be offset", sibling.Type())
+	}
+}
+
+func applyOp(a int64, op byte, b int64) int64 {
+	switch op {
+	case '+':
+		return a + b
+	case '-':
+		return a - b
+	default:
+		return a * b
+	}
+}
+
+// parseOffset parses a duration, with d for days on top of time.ParseDuration
+func parseOffset(op byte, operand string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(operand, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a duration", operand)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(operand)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a duration", operand)
+		}
+		d = parsed
+	}
+	if op == '-' {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,81 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type booking struct {
+	StartDate time.Time     `json:"startDate" required:"true"`
+	EndDate   time.Time     `json:"endDate" default-from:"StartDate+24h"`
+	Reminder  *time.Time    `json:"reminder" default-from:"StartDate-1d"`
+	Timeout   time.Duration `json:"timeout" default-from:"Grace+30s"`
+	Grace     time.Duration `json:"grace"`
+	PageSize  int           `json:"pageSize"`
+	Limit     int           `json:"limit" default-from:"PageSize*2"`
+	Name      string        `json:"name"`
+	Label     string        `json:"label" default-from:"Name"`
+}
+
+func TestDefaultFrom(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	k := &booking{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"startDate":"2024-03-01T09:00:00Z","grace":1000000000,"pageSize":10,"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, start.Add(24*time.Hour), k.EndDate)
+	require.Equal(t, start.Add(-24*time.Hour), *k.Reminder)
+	require.Equal(t, 31*time.Second, k.Timeout)
+	require.Equal(t, 20, k.Limit)
+	require.Equal(t, "a", k.Label)
+
+	// sent values win, even zero ones
+	k = &booking{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"startDate":"2024-03-01T09:00:00Z","endDate":"2024-03-05T09:00:00Z","pageSize":10,"limit":0}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, start.Add(4*24*time.Hour), k.EndDate)
+	require.Equal(t, 0, k.Limit)
+
+	// nothing to copy from
+	k = &booking{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"startDate":"2024-03-01T09:00:00Z"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, time.Duration(0), k.Timeout)
+	require.Equal(t, "", k.Label)
+}
+
+func TestDefaultFromErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "missing sibling", v: &struct {
+			End time.Time `default-from:"Start+1h"`
+		}{}},
+		{name: "bad duration", v: &struct {
+			Start time.Time
+			End   time.Time `default-from:"Start+1y"`
+		}{Start: time.Now()}},
+		{name: "different type", v: &struct {
+			Start time.Time
+			End   string `default-from:"Start+1h"`
+		}{Start: time.Now()}},
+		{name: "string offset", v: &struct {
+			Name  string
+			Label string `default-from:"Name+1"`
+		}{Name: "a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Error(t, checkMetadata(test.v))
+		})
+	}
+}
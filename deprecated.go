@@ -0,0 +1,61 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// sunsetLayout is the date format of the sunset tag
+const sunsetLayout = "2006-01-02"
+
+// checkDeprecated warns when a field tagged deprecated:"use customerId" is
+// sent. An optional sunset:"2026-01-31" tag is the date it stops working.
+func checkDeprecated(f reflect.StructField, opts checkOptions, path string) error {
+	note := f.Tag.Get("deprecated")
+	if note == "" || opts.warnings == nil {
+		return nil
+	}
+	var sunset time.Time
+	if tag := f.Tag.Get("sunset"); tag != "" {
+		var err error
+		if sunset, err = time.Parse(sunsetLayout, tag); err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid sunset")
+		}
+	}
+	if opts.presence == nil || !opts.presence.Has(path) {
+		return nil
+	}
+	*opts.warnings = append(*opts.warnings, Warning{
+		Path:       path,
+		Message:    fmt.Sprintf("field %s is deprecated: %s", f.Name, note),
+		Deprecated: true,
+		Sunset:     sunset,
+	})
+	return nil
+}
+
+// SetDeprecationHeaders tells the client it used something deprecated. When
+// any of the warnings are deprecations it sets Deprecation: true, and
+// Sunset to the earliest sunset date among them.
+func SetDeprecationHeaders(h http.Header, warnings Warnings) {
+	var deprecated bool
+	var sunset time.Time
+	for _, warning := range warnings {
+		if !warning.Deprecated {
+			continue
+		}
+		deprecated = true
+		if !warning.Sunset.IsZero() && (sunset.IsZero() || warning.Sunset.Before(sunset)) {
+			sunset = warning.Sunset
+		}
+	}
+	if !deprecated {
+		return
+	}
+	h.Set("Deprecation", "true")
+	if !sunset.IsZero() {
+		h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,75 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type customerRequest struct {
+	CustomerID string `json:"customerId"`
+	ClientID   string `json:"clientId" deprecated:"use customerId" sunset:"2026-12-31"`
+	Account    string `json:"account" deprecated:"use customerId" sunset:"2026-06-30"`
+	Legacy     string `json:"legacy" deprecated:"no longer used"`
+}
+
+func TestDeprecated(t *testing.T) {
+	request, err := http.NewRequest("POST", "/?clientId=c1", strings.NewReader(`{"customerId":"c1"}`))
+	require.NoError(t, err)
+	warnings, err := BindWithWarnings(request, &customerRequest{})
+	require.NoError(t, err)
+	require.Equal(t, Warnings{{
+		Path:       "clientId",
+		Message:    "field ClientID is deprecated: use customerId",
+		Deprecated: true,
+		Sunset:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	}}, warnings)
+
+	recorder := httptest.NewRecorder()
+	SetDeprecationHeaders(recorder.Header(), warnings)
+	require.Equal(t, "true", recorder.Header().Get("Deprecation"))
+	require.Equal(t, "Thu, 31 Dec 2026 00:00:00 GMT", recorder.Header().Get("Sunset"))
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		sunset string
+	}{
+		{name: "not sent", body: `{"customerId":"c1"}`},
+		{name: "earliest sunset", body: `{"clientId":"c1","account":"a"}`, sunset: "Tue, 30 Jun 2026 00:00:00 GMT"},
+		{name: "no sunset", body: `{"legacy":"x"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			warnings, err := BindWithWarnings(request, &customerRequest{})
+			require.NoError(t, err)
+
+			header := http.Header{}
+			SetDeprecationHeaders(header, warnings)
+			if len(warnings) == 0 {
+				require.Empty(t, header)
+				return
+			}
+			require.Equal(t, "true", header.Get("Deprecation"))
+			require.Equal(t, test.sunset, header.Get("Sunset"))
+		})
+	}
+}
+
+func TestDeprecatedInvalidSunset(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	_, err = BindWithWarnings(request, &struct {
+		Old string `json:"old" deprecated:"use new" sunset:"next year"`
+	}{})
+	require.EqualError(t, err, "field Old has invalid sunset")
+}
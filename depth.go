@@ -0,0 +1,52 @@
+package reqbind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxDepth is how deeply structs can nest when checking, unless the
+// binder sets its own limit with WithMaxDepth
+const DefaultMaxDepth = 64
+
+// WithMaxDepth caps how deeply nested structs are checked, deeper requests
+// fail rather than risk the stack. The default is DefaultMaxDepth.
+func WithMaxDepth(depth int) Option {
+	return func(b *Binder) {
+		b.maxDepth = depth
+	}
+}
+
+// ancestor is one of the structs being checked above the current one. The
+// chain is never changed once built so parallel item checks can share it.
+type ancestor struct {
+	pointer uintptr
+	t       reflect.Type
+	parent  *ancestor
+	depth   int
+}
+
+// enter records that the struct at parent is being checked. It fails when
+// the struct is already being checked further up, e.g. a Parent pointer
+// that leads back to a child, or when it's nested too deeply.
+func (opts checkOptions) enter(parent reflect.Value, prefix string) (checkOptions, error) {
+	pointer, t := parent.Addr().Pointer(), parent.Type()
+	for a := opts.ancestors; a != nil; a = a.parent {
+		if a.pointer == pointer && a.t == t {
+			return opts, fieldErrorf(CodeTooDeep, strings.TrimSuffix(prefix, "."), "refers back to itself")
+		}
+	}
+	maxDepth := opts.maxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	depth := 1
+	if opts.ancestors != nil {
+		depth = opts.ancestors.depth + 1
+	}
+	if depth > maxDepth {
+		return opts, fieldErrorf(CodeTooDeep, strings.TrimSuffix(prefix, "."), "nests deeper than %d levels", maxDepth)
+	}
+	opts.ancestors = &ancestor{pointer: pointer, t: t, parent: opts.ancestors, depth: depth}
+	return opts, nil
+}
@@ -0,0 +1,49 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type category struct {
+	Name     string      `json:"name" required:"true"`
+	Parent   *category   `json:"parent"`
+	Children []*category `json:"children"`
+}
+
+func TestCycleDetection(t *testing.T) {
+	root := &category{Name: "root"}
+	child := &category{Name: "child", Parent: root}
+	root.Children = []*category{child}
+	require.EqualError(t, checkStruct(root, checkOptions{}, ""), "field Children item 0: field children.0.parent refers back to itself")
+
+	self := &category{Name: "self"}
+	self.Parent = self
+	require.EqualError(t, checkStruct(self, checkOptions{}, ""), "field parent refers back to itself")
+
+	// the same object twice, but not inside itself, is fine
+	shared := &category{Name: "shared"}
+	require.NoError(t, checkStruct(&category{Name: "a", Children: []*category{shared, shared}}, checkOptions{}, ""))
+}
+
+func TestMaxDepth(t *testing.T) {
+	body := strings.Repeat(`{"name":"a","parent":`, 10) + `null` + strings.Repeat(`}`, 10)
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, &category{}))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	err = New(WithMaxDepth(5)).UnmarshalBody(request, &category{})
+	require.EqualError(t, err, "field parent.parent.parent.parent.parent nests deeper than 5 levels")
+
+	// the default stops runaway nesting too
+	body = strings.Repeat(`{"name":"a","parent":`, DefaultMaxDepth+1) + `null` + strings.Repeat(`}`, DefaultMaxDepth+1)
+	request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.ErrorContains(t, UnmarshalBody(request, &category{}), "nests deeper than 64 levels")
+}
@@ -0,0 +1,87 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Deriver computes a derived value from a string, see WithDeriver
+type Deriver func(value string) string
+
+// derivers are the functions every binder can use in derive tags
+var derivers = map[string]Deriver{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"slug":  slugify,
+}
+
+// WithDeriver registers a function for derive tags, e.g. a deriver named
+// hash is used as derive:"hash(lower(Email))"
+func WithDeriver(name string, deriver Deriver) Option {
+	return func(b *Binder) {
+		if b.derivers == nil {
+			b.derivers = make(map[string]Deriver)
+		}
+		b.derivers[name] = deriver
+	}
+}
+
+// deriveField sets a field tagged derive from its siblings. The tag is a
+// sibling's name wrapped in any number of derivers, e.g.
+// derive:"lower(trim(Email))". Derived fields are always overwritten, a
+// value sent by the client is never kept.
+func deriveField(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions) error {
+	result, err := evaluateDerive(parent, strings.TrimSpace(f.Tag.Get("derive")), opts)
+	if err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid derive: %s", err)
+	}
+	if err := setFromString(value, result); err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has derive but %s", err)
+	}
+	return nil
+}
+
+func evaluateDerive(parent reflect.Value, expression string, opts checkOptions) (string, error) {
+	open := strings.Index(expression, "(")
+	if open < 0 {
+		sibling := parent.FieldByName(expression)
+		if !sibling.IsValid() {
+			return "", fmt.Errorf("there is no field %s", expression)
+		}
+		for sibling.Kind() == reflect.Ptr || sibling.Kind() == reflect.Interface {
+			if sibling.IsNil() {
+				return "", nil
+			}
+			sibling = sibling.Elem()
+		}
+		return interfaceString(sibling.Interface()), nil
+	}
+	if !strings.HasSuffix(expression, ")") {
+		return "", fmt.Errorf("missing )")
+	}
+
+	name := strings.TrimSpace(expression[:open])
+	deriver, ok := opts.derivers[name]
+	if !ok {
+		deriver, ok = derivers[name]
+	}
+	if !ok {
+		return "", fmt.Errorf("unknown deriver %s", name)
+	}
+	inner, err := evaluateDerive(parent, strings.TrimSpace(expression[open+1:len(expression)-1]), opts)
+	if err != nil {
+		return "", err
+	}
+	return deriver(inner), nil
+}
+
+// slugify lower cases s and joins its runs of letters and digits with
+// hyphens, "Hello, World!" becomes hello-world
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,69 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerive(t *testing.T) {
+	type signup struct {
+		Email      string `json:"email" required:"true" validate:"email"`
+		EmailLower string `json:"emailLower" derive:"lower(trim(Email))"`
+		Name       string `json:"name"`
+		NameSlug   string `json:"nameSlug" derive:"slug(Name)" max-length:"10"`
+	}
+
+	k := &signup{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"Bob@Example.com","name":"Bob's Shop!","emailLower":"sent@by.client"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "bob@example.com", k.EmailLower)
+	require.Equal(t, "bob-s-shop", k.NameSlug)
+
+	// derived fields are checked after they're derived
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"email":"a@b.com","name":"a much longer name"}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &signup{}), "field NameSlug is too long")
+}
+
+func TestWithDeriver(t *testing.T) {
+	type lookup struct {
+		Code string `json:"code"`
+		Key  string `json:"key" derive:"reverse(upper(Code))"`
+	}
+	binder := New(WithDeriver("reverse", func(value string) string {
+		runes := []rune(value)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes)
+	}))
+
+	k := &lookup{}
+	request, err := http.NewRequest("GET", "/?code=abc", nil)
+	require.NoError(t, err)
+	require.NoError(t, binder.UnmarshalQuery(request, k))
+	require.Equal(t, "CBA", k.Key)
+
+	// only the binder it was registered on knows it
+	request, err = http.NewRequest("GET", "/?code=abc", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, &lookup{}))
+}
+
+func TestDeriveErrors(t *testing.T) {
+	require.Error(t, checkMetadata(&struct {
+		Key string `derive:"lower(Missing)"`
+	}{}))
+	require.Error(t, checkMetadata(&struct {
+		Name string
+		Key  string `derive:"lower(Name"`
+	}{}))
+	require.Error(t, checkMetadata(&struct {
+		Name string
+		Key  int `derive:"Name"`
+	}{Name: "a"}))
+}
@@ -0,0 +1,52 @@
+package reqbind
+
+import (
+	"errors"
+	"reflect"
+)
+
+// WithDescriptionHints adds a field's desc tag to its validation errors,
+// e.g. "field Limit is invalid (Number of items per page, max 100)", so the
+// error says what the field was for
+func WithDescriptionHints() Option {
+	return func(b *Binder) {
+		b.hints = true
+	}
+}
+
+// hintError is a field error with the field's description
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string {
+	if e.hint == "" {
+		return e.err.Error()
+	}
+	return e.err.Error() + " (" + e.hint + ")"
+}
+
+func (e *hintError) Unwrap() error {
+	return e.err
+}
+
+// withHint adds f's description to err. Errors from nested fields already
+// have their own field's description, or none, and are left alone.
+func withHint(err error, f reflect.StructField, opts checkOptions) error {
+	if err == nil || !opts.hints {
+		return err
+	}
+	if list, ok := err.(Errors); ok {
+		hinted := make(Errors, len(list))
+		for i, err := range list {
+			hinted[i] = withHint(err, f, opts)
+		}
+		return hinted
+	}
+	var h *hintError
+	if errors.As(err, &h) {
+		return err
+	}
+	return &hintError{err: err, hint: f.Tag.Get("desc")}
+}
@@ -0,0 +1,56 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type descLine struct {
+	SKU string `json:"sku" required:"true"`
+}
+
+type descSearch struct {
+	Limit string   `json:"limit" required:"true" desc:"Number of items per page"`
+	Email string   `json:"email" validate:"email" desc:"Where to send the results"`
+	Line  descLine `json:"line" desc:"The line to search for"`
+}
+
+func TestDescriptionHints(t *testing.T) {
+	tests := []struct {
+		binder   *Binder
+		body     string
+		expected string
+	}{
+		{binder: New(WithDescriptionHints()), body: `{"email":"a@b.co","line":{"sku":"a"}}`, expected: "field Limit is required (Number of items per page)"},
+		{binder: New(), body: `{"email":"a@b.co","line":{"sku":"a"}}`, expected: "field Limit is required"},
+		// a nested field only gets its own description
+		{binder: New(WithDescriptionHints()), body: `{"limit":"1","email":"a@b.co"}`, expected: "field SKU is required"},
+		{binder: New(WithDescriptionHints(), WithAggregateErrors()), body: `{"email":"nope"}`, expected: "field Limit is required (Number of items per page); field Email is invalid: invalid email address (Where to send the results); field SKU is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = test.binder.UnmarshalBody(request, &descSearch{})
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestDescriptionExports(t *testing.T) {
+	schema, err := SchemaOf[descSearch]()
+	require.NoError(t, err)
+	require.Equal(t, "Number of items per page", schema.Fields[0].Description)
+
+	ts, err := TypeScript(descSearch{})
+	require.NoError(t, err)
+	require.Contains(t, ts, "  /** Number of items per page */\n  \"limit\": string;\n")
+
+	zod, err := Zod(descSearch{})
+	require.NoError(t, err)
+	require.Contains(t, zod, `"limit": z.string().describe("Number of items per page"),`)
+}
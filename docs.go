@@ -0,0 +1,246 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Endpoint maps a route to the struct its handler binds, for Endpoints
+type Endpoint struct {
+	// Method is the http method, e.g. GET
+	Method string
+	// Path is the route with {name} placeholders for path parameters
+	Path string
+	// Summary is a sentence about what the endpoint does
+	Summary string
+	// Request is a value of the struct type the handler binds
+	Request interface{}
+}
+
+// Endpoints is a registry of endpoints for generating parameter docs
+type Endpoints []Endpoint
+
+// Param is one row of an endpoint's parameter table
+type Param struct {
+	// Name is the dotted json path, items of a list are written name[]
+	Name string
+	// In is where the parameter is sent: path, header, query or body
+	In string
+	// Type is the json type, e.g. string or array of integer
+	Type string
+	// Required is set for required fields and path parameters
+	Required bool
+	// Constraints are the tag rules in words, e.g. max length 64
+	Constraints []string
+	// Description is the field's desc tag
+	Description string
+}
+
+// Params lists the parameters of the endpoint in field order. Fields the
+// server fills, such as readonly ones, are left out.
+func (e Endpoint) Params() ([]Param, error) {
+	t := reflect.TypeOf(e.Request)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("request for %s %s is not a struct", e.Method, e.Path)
+	}
+	pathParams := map[string]bool{}
+	for _, match := range urlParamRegex.FindAllStringSubmatch(e.Path, -1) {
+		pathParams[strings.ToLower(match[1])] = true
+	}
+	var params []Param
+	for _, f := range docFields(t) {
+		in := paramPlace(f, pathParams[strings.ToLower(jsonName(f))], sendsQuery(e.Method))
+		if in == "" {
+			continue
+		}
+		name := jsonName(f)
+		if in == "header" && f.Tag.Get("header") != "" {
+			name = f.Tag.Get("header")
+		}
+		params = appendParams(params, f, f.Type, name, in, 0)
+	}
+	return params, nil
+}
+
+// appendParams adds the row for f and the rows of its nested fields
+func appendParams(params []Param, f reflect.StructField, t reflect.Type, name string, in string, depth int) []Param {
+	params = append(params, Param{
+		Name:        name,
+		In:          in,
+		Type:        docType(t),
+		Required:    in == "path" || f.Tag.Get("required") != "",
+		Constraints: docConstraints(f),
+		Description: f.Tag.Get("desc"),
+	})
+	if in != "body" && in != "query" || depth >= 3 {
+		return params
+	}
+	t = derefType(t)
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t, name = derefType(t.Elem()), name+"[]"
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return params
+	}
+	for _, nested := range docFields(t) {
+		if generated(nested) {
+			params = appendParams(params, nested, nested.Type, name+"."+jsonName(nested), in, depth+1)
+		}
+	}
+	return params
+}
+
+// docFields are the fields of t with embedded structs flattened
+func docFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" && indirectType(f.Type).Kind() == reflect.Struct {
+			fields = append(fields, docFields(indirectType(f.Type))...)
+			continue
+		}
+		if !isIgnored(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func docType(t reflect.Type) string {
+	t = derefType(t)
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return "date-time"
+	case t == reflect.TypeOf(json.RawMessage{}), reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		return "any"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array of " + docType(t.Elem())
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return "any"
+}
+
+// docConstraints are the rules of f's tags in words
+func docConstraints(f reflect.StructField) []string {
+	var constraints []string
+	add := func(tag string, format string) {
+		if value := f.Tag.Get(tag); value != "" {
+			constraints = append(constraints, fmt.Sprintf(format, value))
+		}
+	}
+	if vType, options := parseValidateTag(f.Tag.Get("validate")); vType == "resource-url" {
+		constraints = append(constraints, "resource url "+options["pattern"])
+	} else {
+		add("validate", "%s")
+	}
+	add("regexp", "matches %s")
+	add("max-length", "max length %s")
+	add("min-items", "at least %s items")
+	add("max-items", "at most %s items")
+	if unique := f.Tag.Get("unique"); unique == "true" {
+		constraints = append(constraints, "unique items")
+	} else {
+		add("unique", "unique by %s")
+	}
+	add("max-keys", "at most %s keys")
+	if f.Tag.Get("trimlower") == "true" {
+		constraints = append(constraints, "trimmed and lower cased")
+	}
+	if f.Tag.Get("single") == "true" {
+		constraints = append(constraints, "sent once")
+	}
+	add("allow-roles", "roles %s")
+	add("versions", "versions %s")
+	add("flag", "flag %s")
+	if note, ok := f.Tag.Lookup("deprecated"); ok {
+		constraints = append(constraints, strings.TrimSpace("deprecated "+note))
+	}
+	return constraints
+}
+
+// Markdown writes a section with a parameter table for each endpoint
+func (e Endpoints) Markdown() (string, error) {
+	sb := &strings.Builder{}
+	for i, endpoint := range e {
+		params, err := endpoint.Params()
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(sb, "## %s %s\n\n", endpoint.Method, endpoint.Path)
+		if endpoint.Summary != "" {
+			fmt.Fprintf(sb, "%s\n\n", endpoint.Summary)
+		}
+		if len(params) == 0 {
+			sb.WriteString("No parameters.\n")
+			continue
+		}
+		sb.WriteString("| Name | In | Type | Required | Constraints | Description |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, p := range params {
+			cells := []string{p.Name, p.In, p.Type, yesNo(p.Required), strings.Join(p.Constraints, ", "), p.Description}
+			for i, cell := range cells {
+				cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			fmt.Fprintf(sb, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+	return sb.String(), nil
+}
+
+// HTML writes a section with a parameter table for each endpoint
+func (e Endpoints) HTML() (string, error) {
+	sb := &strings.Builder{}
+	for _, endpoint := range e {
+		params, err := endpoint.Params()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(sb, "<section>\n<h2>%s %s</h2>\n", html.EscapeString(endpoint.Method), html.EscapeString(endpoint.Path))
+		if endpoint.Summary != "" {
+			fmt.Fprintf(sb, "<p>%s</p>\n", html.EscapeString(endpoint.Summary))
+		}
+		if len(params) == 0 {
+			sb.WriteString("<p>No parameters.</p>\n</section>\n")
+			continue
+		}
+		sb.WriteString("<table>\n<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Constraints</th><th>Description</th></tr>\n")
+		for _, p := range params {
+			sb.WriteString("<tr>")
+			for _, cell := range []string{p.Name, p.In, p.Type, yesNo(p.Required), strings.Join(p.Constraints, ", "), p.Description} {
+				fmt.Fprintf(sb, "<td>%s</td>", html.EscapeString(cell))
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</table>\n</section>\n")
+	}
+	return sb.String(), nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
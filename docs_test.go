@@ -0,0 +1,81 @@
+package reqbind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type docsLine struct {
+	SKU string `json:"sku" required:"true" desc:"Stock keeping unit"`
+	Qty int    `json:"qty"`
+}
+
+type docsOrder struct {
+	ProjectID string     `json:"projectId" desc:"Project the order is for"`
+	Tenant    string     `json:"tenant" header:"X-Tenant"`
+	Email     string     `json:"email" required:"true" validate:"email" trimlower:"true"`
+	Note      string     `json:"note" max-length:"200" desc:"Free text, a | b"`
+	Lines     []docsLine `json:"lines" max-items:"50" unique:"sku"`
+	Owner     string     `json:"owner" readonly:"true"`
+}
+
+type docsSearch struct {
+	Page int `json:"page" desc:"Page number"`
+}
+
+func TestEndpointParams(t *testing.T) {
+	params, err := Endpoint{Method: "POST", Path: "/projects/{projectId}/orders", Request: &docsOrder{}}.Params()
+	require.NoError(t, err)
+	require.Equal(t, []Param{
+		{Name: "projectId", In: "path", Type: "string", Required: true, Description: "Project the order is for"},
+		{Name: "X-Tenant", In: "header", Type: "string"},
+		{Name: "email", In: "body", Type: "string", Required: true, Constraints: []string{"email", "trimmed and lower cased"}},
+		{Name: "note", In: "body", Type: "string", Constraints: []string{"max length 200"}, Description: "Free text, a | b"},
+		{Name: "lines", In: "body", Type: "array of object", Constraints: []string{"at most 50 items", "unique by sku"}},
+		{Name: "lines[].sku", In: "body", Type: "string", Required: true, Description: "Stock keeping unit"},
+		{Name: "lines[].qty", In: "body", Type: "integer"},
+	}, params)
+
+	_, err = Endpoint{Method: "GET", Path: "/", Request: "nope"}.Params()
+	require.EqualError(t, err, "request for GET / is not a struct")
+}
+
+func TestEndpointsMarkdown(t *testing.T) {
+	endpoints := Endpoints{
+		{Method: "GET", Path: "/orders", Summary: "Lists orders.", Request: docsSearch{}},
+		{Method: "DELETE", Path: "/cache", Request: struct{}{}},
+	}
+	md, err := endpoints.Markdown()
+	require.NoError(t, err)
+	require.Equal(t, `## GET /orders
+
+Lists orders.
+
+| Name | In | Type | Required | Constraints | Description |
+| --- | --- | --- | --- | --- | --- |
+| page | query | integer | no |  | Page number |
+
+## DELETE /cache
+
+No parameters.
+`, md)
+
+	md, err = Endpoints{{Method: "POST", Path: "/projects/{projectId}/orders", Request: docsOrder{}}}.Markdown()
+	require.NoError(t, err)
+	require.Contains(t, md, `| note | body | string | no | max length 200 | Free text, a \| b |`)
+}
+
+func TestEndpointsHTML(t *testing.T) {
+	out, err := Endpoints{{Method: "GET", Path: "/orders", Summary: "Lists <orders>.", Request: docsSearch{}}}.HTML()
+	require.NoError(t, err)
+	require.Equal(t, `<section>
+<h2>GET /orders</h2>
+<p>Lists &lt;orders&gt;.</p>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Constraints</th><th>Description</th></tr>
+<tr><td>page</td><td>query</td><td>integer</td><td>no</td><td></td><td>Page number</td></tr>
+</table>
+</section>
+`, out)
+}
@@ -0,0 +1,53 @@
+package reqbind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ValidationTypes maps names to the request structs a dry run endpoint
+// checks, each value is a value of the struct type, e.g.
+// {"signup": Signup{}}
+type ValidationTypes map[string]interface{}
+
+// ValidateHandler is a dry run endpoint for frontends to check a form
+// against the server's rules. It binds the request into the struct named by
+// the {type} path parameter with Bind and replies {"valid":true}, or the
+// WriteError document with a 400, without running any business logic. An
+// unknown type is a 404.
+//
+// The endpoint's own path parameters aren't bound, fields the real route
+// reads from its path can be sent in the body.
+func ValidateHandler(b *Binder, types ValidationTypes) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "type")
+		sample, ok := types[name]
+		t := reflect.TypeOf(sample)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if !ok || t == nil || t.Kind() != reflect.Struct {
+			WriteError(w, r, &StatusError{Status: http.StatusNotFound, Err: fmt.Errorf("unknown type %s", name)})
+			return
+		}
+
+		v := reflect.New(t).Interface()
+		bindRequest := r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, nil))
+		if err := b.Bind(bindRequest, v); err != nil {
+			WriteError(w, r, &StatusError{Status: http.StatusBadRequest, Err: err})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]bool{"valid": true})
+	})
+}
+
+// MountValidate adds ValidateHandler to router at POST /validate/{type}
+func MountValidate(router chi.Router, b *Binder, types ValidationTypes) {
+	router.Method(http.MethodPost, "/validate/{type}", ValidateHandler(b, types))
+}
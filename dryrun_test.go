@@ -0,0 +1,44 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type dryRunSignup struct {
+	Email string `json:"email" required:"true" validate:"email"`
+	Type  string `json:"type"`
+}
+
+func TestValidateHandler(t *testing.T) {
+	router := chi.NewRouter()
+	MountValidate(router, New(), ValidationTypes{"signup": dryRunSignup{}, "pointer": &dryRunSignup{}})
+
+	tests := []struct {
+		path     string
+		body     string
+		status   int
+		expected string
+	}{
+		{path: "/validate/signup", body: `{"email":"jane@example.com"}`, status: http.StatusOK, expected: `{"valid":true}`},
+		{path: "/validate/pointer", body: `{"email":"jane@example.com"}`, status: http.StatusOK, expected: `{"valid":true}`},
+		{path: "/validate/signup", body: `{"email":"jane"}`, status: http.StatusBadRequest, expected: `{"status":400,"message":"field Email is invalid: invalid email address","request_id":"req-1","errors":[{"field":"Email","code":"INVALID_EMAIL","message":"field Email is invalid: invalid email address"}]}`},
+		{path: "/validate/order", body: `{}`, status: http.StatusNotFound, expected: `{"status":404,"message":"unknown type order","request_id":"req-1"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path+" "+test.body, func(t *testing.T) {
+			request := httptest.NewRequest("POST", test.path, strings.NewReader(test.body))
+			request.Header.Set(RequestIDHeader, "req-1")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, test.status, recorder.Code)
+			require.JSONEq(t, test.expected, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package reqbind
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// BindEnv binds the fields of a config struct tagged env:"PORT" from the
+// environment and checks v's tags, the same way a query string is bound. A
+// default:"8080" tag is used when the variable isn't set, and slices take a
+// comma separated list:
+//
+//	type Config struct {
+//		Port  int      `env:"PORT" default:"8080"`
+//		DSN   string   `env:"DATABASE_URL" required:"true"`
+//		Hosts []string `env:"ALLOWED_HOSTS"`
+//	}
+func BindEnv(v interface{}) error {
+	return defaultBinder.BindEnv(v)
+}
+
+// BindEnv binds the environment like the package level BindEnv
+func (b *Binder) BindEnv(v interface{}) error {
+	values := url.Values{}
+	envValues(reflect.TypeOf(v).Elem(), values)
+	return b.BindValues(values, v)
+}
+
+// envValues adds the values of the env tagged fields of t to values, keyed
+// by their query path
+func envValues(t reflect.Type, values url.Values) {
+	walkTagged(t, "env", "", func(f reflect.StructField, key string) {
+		value, ok := os.LookupEnv(f.Tag.Get("env"))
+		if !ok {
+			if value, ok = f.Tag.Lookup("default"); !ok {
+				return
+			}
+		}
+		addFieldValue(values, key, f.Type, value)
+	})
+}
+
+// walkTagged calls fn with the fields of t that have tag and their query
+// path, including the ones of nested structs
+func walkTagged(t reflect.Type, tag string, prefix string, fn func(f reflect.StructField, key string)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if isIgnored(f) {
+			continue
+		}
+		if f.Tag.Get(tag) != "" {
+			fn(f, prefix+jsonName(f))
+			continue
+		}
+		if ft := derefType(f.Type); ft.Kind() == reflect.Struct && !reflect.PtrTo(ft).Implements(textUnmarshalerType) {
+			if f.Anonymous && f.Tag.Get("json") == "" {
+				walkTagged(ft, tag, prefix, fn)
+			} else {
+				walkTagged(ft, tag, prefix+jsonName(f)+".", fn)
+			}
+		}
+	}
+}
+
+// addFieldValue adds a value for a field of type t, a slice gets each item
+// of a comma separated list and a time.Duration takes 30s as well as
+// nanoseconds
+func addFieldValue(values url.Values, key string, t reflect.Type, value string) {
+	if derefType(t) == durationType {
+		if d, err := time.ParseDuration(value); err == nil {
+			value = strconv.FormatInt(int64(d), 10)
+		}
+	}
+	if t := derefType(t); t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		values.Add(key, value)
+		return
+	}
+	for _, item := range splitList(value) {
+		values.Add(key+"[]", item)
+	}
+}
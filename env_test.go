@@ -0,0 +1,47 @@
+package reqbind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type envConfig struct {
+	Port    int           `env:"TEST_PORT" default:"8080"`
+	DSN     string        `env:"TEST_DATABASE_URL" required:"true"`
+	Hosts   []string      `env:"TEST_ALLOWED_HOSTS"`
+	Timeout time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Started time.Time     `env:"TEST_STARTED"`
+	Mode    string        `env:"TEST_MODE" default:"Dev" trimlower:"true"`
+	Cache   struct {
+		Size int `env:"TEST_CACHE_SIZE" default:"64"`
+	} `json:"cache"`
+	Internal string
+}
+
+func TestBindEnv(t *testing.T) {
+	t.Setenv("TEST_DATABASE_URL", "postgres://db/app?sslmode=disable&x=1+1")
+	t.Setenv("TEST_ALLOWED_HOSTS", "a.example.com, b.example.com")
+	t.Setenv("TEST_PORT", "9090")
+	t.Setenv("TEST_STARTED", "2026-01-02T03:04:05Z")
+	t.Setenv("TEST_CACHE_SIZE", "128")
+
+	v := &envConfig{}
+	require.NoError(t, BindEnv(v))
+	require.Equal(t, 9090, v.Port)
+	require.Equal(t, "postgres://db/app?sslmode=disable&x=1+1", v.DSN)
+	require.Equal(t, []string{"a.example.com", "b.example.com"}, v.Hosts)
+	require.Equal(t, 5*time.Second, v.Timeout)
+	require.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), v.Started)
+	require.Equal(t, "dev", v.Mode)
+	require.Equal(t, 128, v.Cache.Size)
+}
+
+func TestBindEnvErrors(t *testing.T) {
+	require.EqualError(t, BindEnv(&envConfig{}), "field DSN is required")
+
+	t.Setenv("TEST_DATABASE_URL", "postgres://db/app")
+	t.Setenv("TEST_PORT", "eighty")
+	require.EqualError(t, BindEnv(&envConfig{}), "json: cannot unmarshal string into Go struct field envConfig.port of type int")
+}
@@ -0,0 +1,226 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exampleStrings are the example values of the validate types
+var exampleStrings = map[string]string{
+	"email":    "jane@example.com",
+	"phone":    "+15555550100",
+	"uuid":     "3f2504e0-4f89-41d3-9a0c-0305e82c3301",
+	"slug":     "example-slug",
+	"country":  "US",
+	"password": "Correct-Horse-9",
+	"address":  "1 Main St, Springfield, IL 62701, US",
+	"geohash":  "9q8yyk8ytpxr",
+}
+
+// Example makes a sample json body and query string for T, for docs,
+// OpenAPI examples and client fixtures. Every field that's sent in the body
+// is filled, an example:"..." tag wins and otherwise the value follows the
+// field's type and validate, regexp and max-length tags. The body is checked
+// against the tags so a bad example tag is an error.
+func Example[T any]() ([]byte, string, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	doc := exampleStruct(t, 0)
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkGenerated[T](body); err != nil {
+		return nil, "", fmt.Errorf("example for %s fails its tags: %s", t, err)
+	}
+	var query []string
+	flattenQuery(doc, "", &query)
+	return body, strings.Join(query, "&"), nil
+}
+
+// exampleStruct is the example json object for the struct type t, the keys
+// are kept in field order
+func exampleStruct(t reflect.Type, depth int) orderedObject {
+	var doc orderedObject
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !generated(f) {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			doc = append(doc, exampleStruct(indirectType(f.Type), depth)...)
+			continue
+		}
+		if value, ok := exampleValue(f, f.Type, depth); ok {
+			doc = append(doc, orderedField{name: jsonName(f), value: value})
+		}
+	}
+	return doc
+}
+
+// exampleValue is the example json value of type t for the field f
+func exampleValue(f reflect.StructField, t reflect.Type, depth int) (interface{}, bool) {
+	if example, ok := f.Tag.Lookup("example"); ok {
+		if derefType(t).Kind() != reflect.String && json.Valid([]byte(example)) {
+			return json.RawMessage(example), true
+		}
+		return example, true
+	}
+
+	t = derefType(t)
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC).Format(time.RFC3339), true
+	case t == latLngType:
+		return "37.7749,-122.4194", true
+	case t == reflect.TypeOf(json.RawMessage{}):
+		return map[string]interface{}{}, true
+	case reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		return nil, false
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return exampleString(f), true
+	case reflect.Bool:
+		return true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 1, true
+	case reflect.Float32, reflect.Float64:
+		return 1.5, true
+	case reflect.Struct:
+		if depth >= 3 {
+			return nil, false
+		}
+		return exampleStruct(t, depth+1), true
+	case reflect.Slice, reflect.Array:
+		n, _ := strconv.Atoi(f.Tag.Get("min-items"))
+		if n == 0 {
+			n = 1
+		}
+		if t.Kind() == reflect.Array {
+			n = t.Len()
+		}
+		item, ok := exampleValue(reflect.StructField{Name: f.Name}, t.Elem(), depth+1)
+		if !ok {
+			return nil, false
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i] = item
+		}
+		return items, true
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, false
+		}
+		keyTag, _, _ := compileRules(f.Name, f.Tag.Get("keys"))
+		valueTag, _, _ := compileRules(f.Name, f.Tag.Get("values"))
+		value, ok := exampleValue(reflect.StructField{Name: f.Name, Tag: valueTag}, t.Elem(), depth+1)
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{exampleString(reflect.StructField{Name: f.Name, Tag: keyTag}): value}, true
+	}
+	return nil, false
+}
+
+// exampleString is the example for a string field
+func exampleString(f reflect.StructField) string {
+	s := "example"
+	vType, options := parseValidateTag(f.Tag.Get("validate"))
+	switch {
+	case f.Tag.Get("regexp") != "":
+		// a fixed seed keeps the example the same between runs
+		s, _ = generateRegexp(rand.New(rand.NewSource(1)), f.Tag.Get("regexp"))
+	case vType == "resource-url":
+		parts := strings.Split(options["pattern"], "/")
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				parts[i] = "1"
+			}
+		}
+		s = strings.Join(parts, "/")
+	case exampleStrings[vType] != "":
+		s = exampleStrings[vType]
+	}
+	if n, err := strconv.Atoi(f.Tag.Get("max-length")); err == nil && len(s) > n {
+		s = s[:n]
+	}
+	return s
+}
+
+// flattenQuery flattens a json value into dotted query parameters, items
+// repeat their key and nulls are left out
+func flattenQuery(value interface{}, key string, query *[]string) {
+	switch value := value.(type) {
+	case orderedObject:
+		for _, field := range value {
+			flattenQuery(field.value, joinKey(key, field.name), query)
+		}
+	case map[string]interface{}:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			flattenQuery(value[name], joinKey(key, name), query)
+		}
+	case []interface{}:
+		for _, v := range value {
+			flattenQuery(v, key, query)
+		}
+	case json.RawMessage:
+		var decoded interface{}
+		if json.Unmarshal(value, &decoded) == nil {
+			if _, nested := decoded.(map[string]interface{}); !nested {
+				flattenQuery(decoded, key, query)
+			}
+		}
+	case nil:
+	default:
+		*query = append(*query, url.QueryEscape(key)+"="+url.QueryEscape(fmt.Sprint(value)))
+	}
+}
+
+func joinKey(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// orderedObject is a json object that keeps its keys in order
+type orderedObject []orderedField
+
+type orderedField struct {
+	name  string
+	value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, field := range o {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, name...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
@@ -0,0 +1,58 @@
+package reqbind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type exampleAddress struct {
+	Country string `json:"country" validate:"country"`
+	Zip     string `json:"zip" regexp:"^[0-9]{5}$"`
+}
+
+type exampleSignup struct {
+	Email   string            `json:"email" required:"true" validate:"email"`
+	Handle  string            `json:"handle" validate:"slug" max-length:"7"`
+	Plan    string            `json:"plan" example:"pro"`
+	Seats   int               `json:"seats" example:"5"`
+	Tags    []string          `json:"tags" min-items:"2"`
+	Address exampleAddress    `json:"address"`
+	Labels  map[string]string `json:"labels"`
+	Since   *time.Time        `json:"since"`
+	Owner   string            `json:"owner" readonly:"true"`
+}
+
+func TestExample(t *testing.T) {
+	body, query, err := Example[exampleSignup]()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"email": "jane@example.com",
+		"handle": "example",
+		"plan": "pro",
+		"seats": 5,
+		"tags": ["example", "example"],
+		"address": {"country": "US", "zip": "`+exampleZip(t)+`"},
+		"labels": {"example": "example"},
+		"since": "2024-01-02T15:04:05Z"
+	}`, string(body))
+	require.Regexp(t, `^email=jane%40example.com&handle=example&plan=pro&seats=5&tags=example&tags=example&address.country=US&address.zip=[0-9]{5}&labels.example=example&since=2024-01-02T15%3A04%3A05Z$`, query)
+
+	// the same every time
+	again, _, err := Example[exampleSignup]()
+	require.NoError(t, err)
+	require.Equal(t, body, again)
+
+	// examples are checked against the tags
+	_, _, err = Example[struct {
+		Email string `json:"email" validate:"email" example:"nope"`
+	}]()
+	require.Error(t, err)
+}
+
+func exampleZip(t *testing.T) string {
+	body, _, err := Example[exampleAddress]()
+	require.NoError(t, err)
+	return string(body[len(`{"country":"US","zip":"`) : len(body)-2])
+}
@@ -0,0 +1,101 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// fieldMaskParams are the query parameters an explicit mask is read from
+var fieldMaskParams = []string{"update_mask", "updateMask", "field_mask", "fieldMask"}
+
+// FieldMask lists the dotted json paths a client wants to update, in the
+// comma separated form gRPC-gateway uses, e.g. name,address.zip
+type FieldMask struct {
+	Paths []string
+}
+
+// UnmarshalText parses a comma separated mask
+func (m *FieldMask) UnmarshalText(text []byte) error {
+	m.Paths = nil
+	for _, path := range strings.Split(string(text), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			m.Paths = append(m.Paths, path)
+		}
+	}
+	return nil
+}
+
+func (m FieldMask) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m FieldMask) String() string {
+	return strings.Join(m.Paths, ",")
+}
+
+// Has reports whether path, or one of its parents, is in the mask
+func (m FieldMask) Has(path string) bool {
+	for _, p := range m.Paths {
+		if p == path || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalBodyMask binds the body like UnmarshalBody and returns the fields
+// to update. An explicit update_mask (or fieldMask) query parameter wins,
+// otherwise the mask is every key the client sent in the body.
+func UnmarshalBodyMask(r *http.Request, v interface{}) (_ FieldMask, err error) {
+	defer defaultBinder.redact(&err)
+	defer recoverPanic(&err)
+	r, done := defaultBinder.withBindTimeout(r)
+	defer done(&err)
+	mask := FieldMask{}
+	bodyBytes, err := getBodyBytes(r, defaultBinder.maxBodyBytes)
+	if err != nil {
+		return mask, err
+	}
+	r.Body = newBodyReader(bodyBytes)
+	if err := UnmarshalBody(r, v); err != nil {
+		return mask, err
+	}
+
+	for _, param := range fieldMaskParams {
+		if value := r.URL.Query().Get(param); value != "" {
+			_ = mask.UnmarshalText([]byte(value))
+			for _, path := range mask.Paths {
+				if err := checkFieldPath(reflect.TypeOf(v), path); err != nil {
+					return FieldMask{}, fmt.Errorf("invalid %s: %s", param, err)
+				}
+			}
+			return mask, nil
+		}
+	}
+
+	if len(bodyBytes) > 0 {
+		mask.Paths = sentPaths(bodyBytes)
+	}
+	return mask, nil
+}
+
+// checkFieldPath makes sure each part of a dotted path is a json field
+func checkFieldPath(t reflect.Type, path string) error {
+	return FieldTree{}.add(t, strings.Split(path, "."))
+}
+
+// sentPaths returns the sorted dotted paths of every leaf key in a json
+// object, nested objects contribute their leaf keys rather than their own name
+func sentPaths(data []byte) []string {
+	var paths []string
+	walkJSONKeys(data, "", func(path string, leaf bool) {
+		if leaf {
+			paths = append(paths, path)
+		}
+	})
+	sort.Strings(paths)
+	return paths
+}
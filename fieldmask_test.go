@@ -0,0 +1,58 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fieldMaskProject struct {
+	Name    string `json:"name"`
+	Size    int    `json:"size"`
+	Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	} `json:"address"`
+}
+
+func TestUnmarshalBodyMaskFromBody(t *testing.T) {
+	request, err := http.NewRequest("PATCH", "/", strings.NewReader(`{"size":0,"address":{"zip":"97201"}}`))
+	require.NoError(t, err)
+
+	k := &fieldMaskProject{}
+	mask, err := UnmarshalBodyMask(request, k)
+	require.NoError(t, err)
+	require.Equal(t, []string{"address.zip", "size"}, mask.Paths)
+	require.True(t, mask.Has("size"))
+	require.False(t, mask.Has("address.city"))
+	require.Equal(t, "97201", k.Address.Zip)
+}
+
+func TestUnmarshalBodyMaskFromQuery(t *testing.T) {
+	request, err := http.NewRequest("PATCH", "/?update_mask=name,address", strings.NewReader(`{"name":"a","size":3}`))
+	require.NoError(t, err)
+
+	mask, err := UnmarshalBodyMask(request, &fieldMaskProject{})
+	require.NoError(t, err)
+	require.Equal(t, "name,address", mask.String())
+	require.True(t, mask.Has("address.city"))
+	require.False(t, mask.Has("size"))
+
+	request, err = http.NewRequest("PATCH", "/?updateMask=owner", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	_, err = UnmarshalBodyMask(request, &fieldMaskProject{})
+	require.Error(t, err)
+}
+
+func TestFieldMaskField(t *testing.T) {
+	k := &struct {
+		Mask FieldMask `json:"update_mask"`
+		Name string    `json:"name"`
+	}{}
+	request, err := http.NewRequest("PATCH", "/", strings.NewReader(`{"update_mask":"name","name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, []string{"name"}, k.Mask.Paths)
+}
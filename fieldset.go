@@ -0,0 +1,118 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldTree is a nested field selection, owner.email becomes
+// {"owner": {"email": {}}}
+type FieldTree map[string]FieldTree
+
+// FieldSet binds a sparse fieldset such as ?fields=id,name,owner.email and
+// checks every path against the json field names of the response type T
+type FieldSet[T any] struct {
+	Tree FieldTree
+}
+
+// UnmarshalText parses and validates a comma separated list of field paths
+func (fs *FieldSet[T]) UnmarshalText(text []byte) error {
+	tree := FieldTree{}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for _, path := range strings.Split(string(text), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if err := tree.add(t, strings.Split(path, ".")); err != nil {
+			return fmt.Errorf("invalid field %s: %s", path, err)
+		}
+	}
+	fs.Tree = tree
+	return nil
+}
+
+// Empty is true when no fields were selected, i.e. return everything
+func (fs FieldSet[T]) Empty() bool {
+	return len(fs.Tree) == 0
+}
+
+// Has reports whether a dotted path was selected. Selecting a parent, e.g.
+// owner, selects all of its children.
+func (fs FieldSet[T]) Has(path string) bool {
+	if fs.Empty() {
+		return true
+	}
+	node := fs.Tree
+	for _, part := range strings.Split(path, ".") {
+		child, ok := node[part]
+		if !ok {
+			return false
+		}
+		if len(child) == 0 {
+			return true
+		}
+		node = child
+	}
+	return true
+}
+
+func (tree FieldTree) add(t reflect.Type, parts []string) error {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s has no fields", parts[0])
+	}
+	f, ok := fieldByJSONName(t, parts[0])
+	if !ok {
+		return fmt.Errorf("unknown field %s", parts[0])
+	}
+	child, ok := tree[parts[0]]
+	if !ok {
+		child = FieldTree{}
+		tree[parts[0]] = child
+	}
+	if len(parts) == 1 {
+		return nil
+	}
+	return child.add(f.Type, parts[1:])
+}
+
+// indirectType strips pointers, slices and maps down to the element type
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldByJSONName finds an exported field by the name encoding/json would
+// write it as
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	return findJSONField(t, func(jsonName string) bool { return jsonName == name })
+}
+
+// fieldByJSONNameFold is fieldByJSONName ignoring case, the way encoding/json
+// matches keys when decoding
+func fieldByJSONNameFold(t reflect.Type, name string) (reflect.StructField, bool) {
+	return findJSONField(t, func(jsonName string) bool { return strings.EqualFold(jsonName, name) })
+}
+
+func findJSONField(t reflect.Type, match func(jsonName string) bool) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if isIgnored(f) {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			if inner, ok := findJSONField(indirectType(f.Type), match); ok {
+				return inner, true
+			}
+			continue
+		}
+		if match(jsonName(f)) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,63 @@
+package reqbind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fieldSetOwner struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+type fieldSetUser struct {
+	ID     int            `json:"id"`
+	Name   string         `json:"name"`
+	Owner  *fieldSetOwner `json:"owner"`
+	Tags   []fieldSetOwner
+	secret string
+}
+
+func TestFieldSet(t *testing.T) {
+	k := &struct {
+		Fields FieldSet[fieldSetUser]
+	}{}
+
+	request, err := http.NewRequest("GET", "/?fields=id,name,owner.email,Tags", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, FieldTree{"id": {}, "name": {}, "owner": {"email": {}}, "Tags": {}}, k.Fields.Tree)
+	require.True(t, k.Fields.Has("owner.email"))
+	require.False(t, k.Fields.Has("owner.name"))
+	require.True(t, k.Fields.Has("Tags.email"))
+	require.False(t, k.Fields.Empty())
+}
+
+func TestFieldSetEmpty(t *testing.T) {
+	k := &struct {
+		Fields FieldSet[fieldSetUser]
+	}{}
+
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.True(t, k.Fields.Empty())
+	require.True(t, k.Fields.Has("owner.name"))
+}
+
+func TestFieldSetInvalid(t *testing.T) {
+	tests := []string{"password", "owner.phone", "id.value", "secret"}
+
+	for _, fields := range tests {
+		t.Run(fields, func(t *testing.T) {
+			k := &struct {
+				Fields FieldSet[fieldSetUser]
+			}{}
+			request, err := http.NewRequest("GET", "/?fields="+fields, nil)
+			require.NoError(t, err)
+			require.Error(t, UnmarshalQuery(request, k))
+		})
+	}
+}
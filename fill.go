@@ -0,0 +1,44 @@
+package reqbind
+
+import "reflect"
+
+// WithFillMissing only binds the query and body into fields that are still
+// at their zero value, so defaults the handler set beforehand, e.g. from
+// the route, aren't overwritten. Nested structs are filled field by field.
+func WithFillMissing() Option {
+	return func(b *Binder) {
+		b.fillMissing = true
+	}
+}
+
+// decodeInto runs decode against v, or in fill missing mode against a new
+// value that's then merged into v's zero fields
+func (b *Binder) decodeInto(v interface{}, decode func(target interface{}) error) error {
+	rv := reflect.ValueOf(v).Elem()
+	if !b.fillMissing || rv.Kind() != reflect.Struct {
+		return decode(v)
+	}
+	decoded := reflect.New(rv.Type())
+	err := decode(decoded.Interface())
+	fillMissing(rv, decoded.Elem())
+	return err
+}
+
+// fillMissing copies the fields of src into the zero fields of dst
+func fillMissing(dst reflect.Value, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		f := dst.Type().Field(i)
+		if isIgnored(f) {
+			continue
+		}
+		field := dst.Field(i)
+		switch {
+		case field.IsZero():
+			field.Set(src.Field(i))
+		case field.Kind() == reflect.Struct:
+			fillMissing(field, src.Field(i))
+		case field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct && !src.Field(i).IsNil():
+			fillMissing(field.Elem(), src.Field(i).Elem())
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package reqbind
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fillPaging struct {
+	Limit int    `json:"limit"`
+	Sort  string `json:"sort"`
+}
+
+type fillSearch struct {
+	Tenant string      `json:"tenant"`
+	Status string      `json:"status"`
+	Paging fillPaging  `json:"paging"`
+	Extra  *fillPaging `json:"extra"`
+}
+
+func TestFillMissing(t *testing.T) {
+	binder := New(WithFillMissing())
+
+	request, err := http.NewRequest("GET", "/?tenant=evil&status=open&paging.limit=50&paging.sort=name", nil)
+	require.NoError(t, err)
+	k := &fillSearch{Tenant: "acme", Paging: fillPaging{Limit: 10}}
+	require.NoError(t, binder.UnmarshalQuery(request, k))
+	require.Equal(t, &fillSearch{Tenant: "acme", Status: "open", Paging: fillPaging{Limit: 10, Sort: "name"}}, k)
+
+	// without the option the query wins
+	k = &fillSearch{Tenant: "acme"}
+	require.NoError(t, New().UnmarshalQuery(request, k))
+	require.Equal(t, "evil", k.Tenant)
+
+	// bodies are filled the same way, including through pointers
+	request, err = http.NewRequest("POST", "/", bytes.NewBufferString(`{"tenant":"evil","extra":{"limit":5,"sort":"id"}}`))
+	require.NoError(t, err)
+	k = &fillSearch{Tenant: "acme", Extra: &fillPaging{Sort: "date"}}
+	require.NoError(t, binder.Bind(request, k))
+	require.Equal(t, &fillSearch{Tenant: "acme", Extra: &fillPaging{Limit: 5, Sort: "date"}}, k)
+}
@@ -0,0 +1,45 @@
+package reqbind
+
+import "context"
+
+// FlagMode decides what happens when a client sends a field whose feature
+// flag is off
+type FlagMode int
+
+const (
+	// FlagReject fails the bind with an error
+	FlagReject FlagMode = iota
+	// FlagIgnore silently resets the field to its zero value
+	FlagIgnore
+)
+
+// FlagEvaluator reports whether a feature flag is on for a request
+type FlagEvaluator interface {
+	Enabled(ctx context.Context, flag string) bool
+}
+
+// FlagEvaluatorFunc adapts a function to FlagEvaluator
+type FlagEvaluatorFunc func(ctx context.Context, flag string) bool
+
+// Enabled calls f
+func (f FlagEvaluatorFunc) Enabled(ctx context.Context, flag string) bool {
+	return f(ctx, flag)
+}
+
+// WithFlags sets the evaluator for fields tagged flag:"new-pricing", they're
+// only bound and checked when their flag is on for the request. Sent fields
+// whose flag is off are handled with mode. Without an evaluator every flag
+// is off.
+func WithFlags(evaluator FlagEvaluator, mode FlagMode) Option {
+	return func(b *Binder) {
+		b.flags = evaluator
+		b.flagMode = mode
+	}
+}
+
+// flagEnabled returns the check for a request's flags
+func (b *Binder) flagEnabled(ctx context.Context) func(flag string) bool {
+	return func(flag string) bool {
+		return b.flags != nil && b.flags.Enabled(ctx, flag)
+	}
+}
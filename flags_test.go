@@ -0,0 +1,63 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type betaKey struct{}
+
+type pricingRequest struct {
+	Plan  string `json:"plan" required:"true"`
+	Tiers []int  `json:"tiers" flag:"new-pricing" required:"true" max-items:"3"`
+}
+
+func betaFlags(ctx context.Context, flag string) bool {
+	beta, _ := ctx.Value(betaKey{}).(bool)
+	return beta && flag == "new-pricing"
+}
+
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		beta     bool
+		mode     FlagMode
+		body     string
+		expected pricingRequest
+		error    string
+	}{
+		{name: "on", beta: true, body: `{"plan":"pro","tiers":[1,2]}`, expected: pricingRequest{Plan: "pro", Tiers: []int{1, 2}}},
+		{name: "on checks the field", beta: true, body: `{"plan":"pro"}`, error: "field Tiers is required"},
+		{name: "on checks the tags", beta: true, body: `{"plan":"pro","tiers":[1,2,3,4]}`, error: "field Tiers has more than 3 items"},
+		{name: "off skips the field", body: `{"plan":"pro"}`, expected: pricingRequest{Plan: "pro"}},
+		{name: "off rejects", body: `{"plan":"pro","tiers":[1]}`, error: "field Tiers is not allowed"},
+		{name: "off ignores", mode: FlagIgnore, body: `{"plan":"pro","tiers":[1]}`, expected: pricingRequest{Plan: "pro"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binder := New(WithFlags(FlagEvaluatorFunc(betaFlags), test.mode))
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			request = request.WithContext(context.WithValue(request.Context(), betaKey{}, test.beta))
+			k := &pricingRequest{}
+			err = binder.Bind(request, k)
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &test.expected, k)
+		})
+	}
+}
+
+func TestFlagsWithoutEvaluator(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"plan":"pro","tiers":[1]}`))
+	require.NoError(t, err)
+	require.EqualError(t, Bind(request, &pricingRequest{}), "field Tiers is not allowed")
+}
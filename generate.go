@@ -0,0 +1,434 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// generateTries is how many bodies are generated before giving up on one
+// that passes, or fails, the checks
+const generateTries = 50
+
+// GenerateValid makes a random json body for T that passes its tags, for
+// property tests and fuzzing handlers. Required fields are always sent,
+// optional ones sometimes, and strings follow validate, regexp and
+// max-length. Every body is bound and checked before it's returned.
+func GenerateValid[T any](r *rand.Rand) ([]byte, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	var lastErr error
+	for i := 0; i < generateTries; i++ {
+		body, err := json.Marshal(generateStruct(r, t, 0))
+		if err != nil {
+			return nil, err
+		}
+		if lastErr = checkGenerated[T](body); lastErr == nil {
+			return body, nil
+		}
+	}
+	return nil, fmt.Errorf("no valid body for %s: %s", t, lastErr)
+}
+
+// GenerateInvalid makes a random json body for T that fails its tags and
+// returns the dotted json path of the one field it breaks, e.g. by leaving
+// out a required field or sending too many items. Only a body that passes
+// is broken, and it's only returned when the error is for that field.
+func GenerateInvalid[T any](r *rand.Rand) ([]byte, string, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for i := 0; i < generateTries; i++ {
+		doc := generateStruct(r, t, 0)
+		breakers := breakersFor(r, t, doc, "")
+		if len(breakers) == 0 {
+			return nil, "", fmt.Errorf("%s has no tags to break", t)
+		}
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return nil, "", err
+		}
+		if checkGenerated[T](body) != nil {
+			continue
+		}
+		b := breakers[r.Intn(len(breakers))]
+		b.apply()
+		if body, err = json.Marshal(doc); err != nil {
+			return nil, "", err
+		}
+		var fieldErr *FieldError
+		if err := checkGenerated[T](body); errors.As(err, &fieldErr) && fieldErr.Field == b.field {
+			return body, b.path, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no invalid body for %s", t)
+}
+
+// checkGenerated binds body into a new T the way UnmarshalBody does
+func checkGenerated[T any](body []byte) error {
+	v := new(T)
+	if err := unmarshalFields(body, v, false); err != nil {
+		return err
+	}
+	presence := bodyPresence(body)
+	return checkStruct(v, checkOptions{presence: &presence}, "")
+}
+
+// generated reports whether f is sent in the body, fields filled from the
+// request or the server are left out
+func generated(f reflect.StructField) bool {
+	if isIgnored(f) {
+		return false
+	}
+	for _, tag := range []string{"header", "clientip", "useragent", "geo", "derive", "source", "file"} {
+		if f.Tag.Get(tag) != "" {
+			return false
+		}
+	}
+	return f.Tag.Get("readonly") != "true"
+}
+
+// generateStruct makes a json object for the struct type t
+func generateStruct(r *rand.Rand, t reflect.Type, depth int) map[string]interface{} {
+	doc := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !generated(f) {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			for name, value := range generateStruct(r, indirectType(f.Type), depth) {
+				doc[name] = value
+			}
+			continue
+		}
+		if skippable(t, f) && r.Intn(2) == 0 {
+			continue
+		}
+		if value, ok := generateValue(r, f, f.Type, depth); ok {
+			doc[jsonName(f)] = value
+		}
+	}
+	return doc
+}
+
+// skippable reports whether the field f of the struct type t passes its
+// tags when it isn't sent, e.g. an optional field with validate:"phone"
+// doesn't since an empty string isn't a phone number
+func skippable(t reflect.Type, f reflect.StructField) bool {
+	if f.Tag.Get("required") != "" {
+		return false
+	}
+	parent := reflect.New(t).Elem()
+	presence := Presence{}
+	return checkField(parent, f, parent.FieldByIndex(f.Index), checkOptions{presence: &presence}, jsonName(f)) == nil
+}
+
+// generateValue makes a json value of type t for the field f
+func generateValue(r *rand.Rand, f reflect.StructField, t reflect.Type, depth int) (interface{}, bool) {
+	t = derefType(t)
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return time.Unix(r.Int63n(2e9), 0).UTC().Format(time.RFC3339), true
+	case t == latLngType:
+		return LatLng{Lat: float64(r.Intn(18000))/100 - 90, Lng: float64(r.Intn(36000))/100 - 180}.String(), true
+	case t == reflect.TypeOf(json.RawMessage{}):
+		return map[string]interface{}{}, true
+	case reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		// custom decoding means the shape isn't known
+		return nil, false
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return generateString(r, f), true
+	case reflect.Bool:
+		return r.Intn(2) == 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return r.Intn(100), true
+	case reflect.Float32, reflect.Float64:
+		return float64(r.Intn(10000)) / 100, true
+	case reflect.Struct:
+		if depth >= 3 {
+			return nil, false
+		}
+		return generateStruct(r, t, depth+1), true
+	case reflect.Slice, reflect.Array:
+		lo, hi := itemBounds(f)
+		n := lo + r.Intn(hi-lo+1)
+		if t.Kind() == reflect.Array {
+			n = t.Len()
+		}
+		items := make([]interface{}, 0, n)
+		item := reflect.StructField{Name: f.Name}
+		for i := 0; i < n; i++ {
+			value, ok := generateValue(r, item, t.Elem(), depth+1)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, value)
+		}
+		return items, true
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, false
+		}
+		keyTag, _, _ := compileRules(f.Name, f.Tag.Get("keys"))
+		valueTag, _, _ := compileRules(f.Name, f.Tag.Get("values"))
+		n := r.Intn(4)
+		if maxKeys, err := strconv.Atoi(f.Tag.Get("max-keys")); err == nil && n > maxKeys {
+			n = maxKeys
+		}
+		m := map[string]interface{}{}
+		for i := 0; i < n; i++ {
+			value, ok := generateValue(r, reflect.StructField{Name: f.Name, Tag: valueTag}, t.Elem(), depth+1)
+			if !ok {
+				return nil, false
+			}
+			m[generateString(r, reflect.StructField{Name: f.Name, Tag: keyTag})] = value
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// itemBounds are the min-items and max-items of f, at most 3 items more
+// than the minimum are generated when there's no maximum
+func itemBounds(f reflect.StructField) (int, int) {
+	lo, _ := strconv.Atoi(f.Tag.Get("min-items"))
+	hi, err := strconv.Atoi(f.Tag.Get("max-items"))
+	if err != nil || hi > lo+3 {
+		hi = lo + 3
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// generateString makes a string that follows the validate, regexp and
+// max-length tags of f
+func generateString(r *rand.Rand, f reflect.StructField) string {
+	var s string
+	vType, options := parseValidateTag(f.Tag.Get("validate"))
+	switch {
+	case f.Tag.Get("regexp") != "":
+		s, _ = generateRegexp(r, f.Tag.Get("regexp"))
+	case vType == "email":
+		s = generateWord(r, 1, 10) + "@example.com"
+	case vType == "phone":
+		s = "+1"
+		for i := 0; i < 10; i++ {
+			s += strconv.Itoa(r.Intn(10))
+		}
+	case vType == "uuid":
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		s = fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case vType == "slug":
+		words := make([]string, 1+r.Intn(3))
+		for i := range words {
+			words[i] = generateWord(r, 1, 8)
+		}
+		s = strings.Join(words, "-")
+	case vType == "country":
+		codes := make([]string, 0, len(countryCodes))
+		for code := range countryCodes {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		s = codes[r.Intn(len(codes))]
+	case vType == "password":
+		s = "Aa1!" + generateWord(r, 8, 16)
+	case vType == "geohash":
+		n := 9
+		if p, err := strconv.Atoi(options["precision"]); err == nil && p > n {
+			n = p
+		}
+		for i := 0; i < n; i++ {
+			s += string(geohashAlphabet[r.Intn(len(geohashAlphabet))])
+		}
+	case vType == "resource-url":
+		parts := strings.Split(options["pattern"], "/")
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				parts[i] = strconv.Itoa(1 + r.Intn(1000))
+			}
+		}
+		s = strings.Join(parts, "/")
+	default:
+		s = generateWord(r, 1, 12)
+	}
+	if n, err := strconv.Atoi(f.Tag.Get("max-length")); err == nil && len(s) > n {
+		s = s[:n]
+	}
+	return s
+}
+
+// generateWord makes a lower case word of between lo and hi letters
+func generateWord(r *rand.Rand, lo int, hi int) string {
+	b := make([]byte, lo+r.Intn(hi-lo+1))
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return string(b)
+}
+
+// generateRegexp makes a string the pattern matches, repeats are kept short
+func generateRegexp(r *rand.Rand, pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	sb := &strings.Builder{}
+	if err := writeRegexp(r, sb, re.Simplify()); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func writeRegexp(r *rand.Rand, sb *strings.Builder, re *syntax.Regexp) error {
+	repeat := func(lo int, hi int) error {
+		if hi < 0 {
+			hi = lo + 3
+		}
+		for i := lo + r.Intn(hi-lo+1); i > 0; i-- {
+			if err := writeRegexp(r, sb, re.Sub[0]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return fmt.Errorf("empty character class")
+		}
+		i := r.Intn(len(re.Rune)/2) * 2
+		lo, hi := re.Rune[i], re.Rune[i+1]
+		if hi-lo > 94 {
+			hi = lo + 94
+		}
+		sb.WriteRune(lo + rune(r.Intn(int(hi-lo)+1)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte(byte('a' + r.Intn(26)))
+	case syntax.OpCapture:
+		return writeRegexp(r, sb, re.Sub[0])
+	case syntax.OpStar:
+		return repeat(0, 3)
+	case syntax.OpPlus:
+		return repeat(1, 3)
+	case syntax.OpQuest:
+		return repeat(0, 1)
+	case syntax.OpRepeat:
+		return repeat(re.Min, re.Max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writeRegexp(r, sb, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		return writeRegexp(r, sb, re.Sub[r.Intn(len(re.Sub))])
+	case syntax.OpNoMatch:
+		return fmt.Errorf("pattern never matches")
+	}
+	return nil
+}
+
+// breaker is one way of making a generated body fail its tags, field is
+// the Go name the error is for
+type breaker struct {
+	path  string
+	field string
+	apply func()
+}
+
+// breakersFor lists the ways of breaking the fields of doc, a generated
+// object for the struct type t
+func breakersFor(r *rand.Rand, t reflect.Type, doc map[string]interface{}, prefix string) []breaker {
+	var breakers []breaker
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !generated(f) {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			breakers = append(breakers, breakersFor(r, indirectType(f.Type), doc, prefix)...)
+			continue
+		}
+		name := jsonName(f)
+		path := prefix + name
+		set := func(value interface{}) breaker {
+			return breaker{path: path, field: f.Name, apply: func() { doc[name] = value }}
+		}
+
+		if f.Tag.Get("required") != "" {
+			breakers = append(breakers, breaker{path: path, field: f.Name, apply: func() { delete(doc, name) }})
+		}
+		if f.Tag.Get("validate") != "" || f.Tag.Get("regexp") != "" {
+			breakers = append(breakers, set("!"))
+		}
+		if n, err := strconv.Atoi(f.Tag.Get("max-length")); err == nil {
+			breakers = append(breakers, set(strings.Repeat("x", n+1)))
+		}
+		item, _ := generateValue(r, reflect.StructField{Name: f.Name}, elemType(f.Type), 1)
+		if n, err := strconv.Atoi(f.Tag.Get("max-items")); err == nil {
+			breakers = append(breakers, set(repeatItem(item, n+1)))
+		}
+		if n, err := strconv.Atoi(f.Tag.Get("min-items")); err == nil && n > 0 {
+			breakers = append(breakers, set(repeatItem(item, n-1)))
+		}
+		if f.Tag.Get("unique") != "" {
+			breakers = append(breakers, set(repeatItem(item, 2)))
+		}
+		if n, err := strconv.Atoi(f.Tag.Get("max-keys")); err == nil {
+			m := map[string]interface{}{}
+			value, _ := generateValue(r, reflect.StructField{Name: f.Name}, elemType(f.Type), 1)
+			for i := 0; i <= n; i++ {
+				m["k"+strconv.Itoa(i)] = value
+			}
+			breakers = append(breakers, set(m))
+		}
+
+		// nested objects are broken through their own fields
+		if nested, ok := doc[name].(map[string]interface{}); ok && indirectType(f.Type).Kind() == reflect.Struct {
+			breakers = append(breakers, breakersFor(r, indirectType(f.Type), nested, path+".")...)
+		}
+	}
+	return breakers
+}
+
+// elemType is the item type of a slice, array or map type
+func elemType(t reflect.Type) reflect.Type {
+	switch t = derefType(t); t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	}
+	return t
+}
+
+// derefType is t without its pointers
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func repeatItem(item interface{}, n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = item
+	}
+	return items
+}
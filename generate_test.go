@@ -0,0 +1,77 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type generatedAddress struct {
+	Country string `json:"country" required:"true" validate:"country"`
+	Zip     string `json:"zip" regexp:"^[0-9]{5}(-[0-9]{4})?$"`
+}
+
+type generatedSignup struct {
+	ID       string            `json:"id" required:"true" validate:"uuid"`
+	Email    string            `json:"email" required:"true" validate:"email"`
+	Phone    string            `json:"phone" validate:"phone"`
+	Handle   string            `json:"handle" required:"true" validate:"slug" max-length:"12"`
+	Password string            `json:"password" validate:"password"`
+	Tags     []string          `json:"tags" min-items:"1" max-items:"3" unique:"true"`
+	Labels   map[string]string `json:"labels" max-keys:"2" keys:"validate=slug"`
+	Address  generatedAddress  `json:"address" required:"true"`
+	Owner    string            `json:"owner" readonly:"true"`
+	Agent    string            `json:"agent" header:"User-Agent"`
+}
+
+func TestGenerateValid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		body, err := GenerateValid[generatedSignup](r)
+		require.NoError(t, err)
+		require.NoError(t, checkGenerated[generatedSignup](body), string(body))
+
+		doc := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(body, &doc))
+		require.NotContains(t, doc, "owner")
+		require.NotContains(t, doc, "agent")
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	fields := map[string]string{
+		"id": "ID", "email": "Email", "phone": "Phone", "handle": "Handle", "password": "Password",
+		"tags": "Tags", "labels": "Labels", "address": "Address", "address.country": "Country", "address.zip": "Zip",
+	}
+	paths := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		body, path, err := GenerateInvalid[generatedSignup](r)
+		require.NoError(t, err)
+		var fieldErr *FieldError
+		require.ErrorAs(t, checkGenerated[generatedSignup](body), &fieldErr, string(body))
+		require.Equal(t, fields[path], fieldErr.Field, string(body))
+		paths[path] = true
+	}
+	require.True(t, paths["address.country"])
+	require.True(t, paths["tags"])
+	require.True(t, paths["phone"])
+
+	_, _, err := GenerateInvalid[struct{ Name string }](r)
+	require.Error(t, err)
+}
+
+func TestGenerateRegexp(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, pattern := range []string{`^[A-Z]{2}-\d{3,5}$`, `^(red|green|blue)$`, `^a+b*c?\.x$`} {
+		re, err := compilePattern(pattern)
+		require.NoError(t, err)
+		for i := 0; i < 50; i++ {
+			s, err := generateRegexp(r, pattern)
+			require.NoError(t, err)
+			require.Regexp(t, re, s)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// geoHeaders are the headers CDNs set with the caller's location, tried in
+// order. Only use geo fields behind a CDN that sets or strips them, anyone
+// else can send them.
+var geoHeaders = map[string][]string{
+	"country": {"CF-IPCountry", "CloudFront-Viewer-Country", "X-Appengine-Country", "Fastly-Geo-Country-Code"},
+	"region":  {"CloudFront-Viewer-Country-Region", "X-Appengine-Region", "Fastly-Geo-Region"},
+	"city":    {"CloudFront-Viewer-City", "X-Appengine-City", "Fastly-Geo-City"},
+}
+
+// unknownCountries are the placeholders CDNs send when they don't know, XX
+// and ZZ for unknown and T1 for Tor
+var unknownCountries = map[string]bool{"XX": true, "ZZ": true, "T1": true}
+
+// countryCodes are the ISO 3166-1 alpha-2 codes
+var countryCodes = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR
+		GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP
+		KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT
+		MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW
+		SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG
+		UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`) {
+		countryCodes[code] = true
+	}
+}
+
+// validateCountry accepts an ISO 3166-1 alpha-2 code
+func validateCountry(value string) error {
+	if !countryCodes[value] {
+		return fmt.Errorf("invalid country code")
+	}
+	return nil
+}
+
+// bindGeo sets the fields tagged geo:"country", geo:"region" or geo:"city"
+// from the first CDN header that has a value. Countries are upper cased and
+// checked against ISO 3166, unknown placeholders like XX leave the field
+// empty.
+func bindGeo(r *http.Request, v interface{}, presence *Presence) error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		part := f.Tag.Get("geo")
+		if part == "" || isIgnored(f) {
+			continue
+		}
+		headers, ok := geoHeaders[part]
+		if !ok {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has unknown geo %s", part)
+		}
+
+		var value string
+		for _, header := range headers {
+			if value = strings.TrimSpace(r.Header.Get(header)); value != "" {
+				break
+			}
+		}
+		if part == "country" {
+			value = strings.ToUpper(value)
+			if unknownCountries[value] {
+				value = ""
+			}
+			if value != "" {
+				if err := validateCountry(value); err != nil {
+					return fieldErrorf(CodeInvalid, f.Name, "is invalid: %s", err)
+				}
+			}
+		}
+		if value == "" {
+			continue
+		}
+		if err := setFromString(rv.Field(i), value); err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has geo but %s", err)
+		}
+		if presence != nil {
+			presence.add(jsonName(f))
+		}
+	}
+	return nil
+}
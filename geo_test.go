@@ -0,0 +1,80 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryCodes(t *testing.T) {
+	require.Len(t, countryCodes, 249)
+	require.NoError(t, validateCountry("NZ"))
+	require.Error(t, validateCountry("nz"))
+	require.Error(t, validateCountry("UK"))
+}
+
+func TestOptionalCountryAndSlug(t *testing.T) {
+	type profile struct {
+		Name    string `json:"name"`
+		Country string `json:"country" validate:"country"`
+		Handle  string `json:"handle" validate:"slug"`
+	}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, &profile{}))
+
+	for _, body := range []string{`{"country":"UK"}`, `{"handle":"Not A Slug"}`} {
+		request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+		require.NoError(t, err)
+		require.Error(t, UnmarshalBody(request, &profile{}), body)
+	}
+}
+
+func TestBindGeo(t *testing.T) {
+	type gated struct {
+		Country string `json:"country" geo:"country"`
+		Region  string `json:"region" geo:"region"`
+		City    string `json:"city" geo:"city"`
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected gated
+		error    bool
+	}{
+		{name: "cloudflare", headers: map[string]string{"CF-IPCountry": "nz"}, expected: gated{Country: "NZ"}},
+		{name: "cloudfront", headers: map[string]string{"CloudFront-Viewer-Country": "US", "CloudFront-Viewer-Country-Region": "WA", "CloudFront-Viewer-City": "Seattle"}, expected: gated{Country: "US", Region: "WA", City: "Seattle"}},
+		{name: "app engine", headers: map[string]string{"X-Appengine-Country": "DE", "X-Appengine-City": "berlin"}, expected: gated{Country: "DE", City: "berlin"}},
+		{name: "unknown", headers: map[string]string{"CF-IPCountry": "XX"}},
+		{name: "tor", headers: map[string]string{"CF-IPCountry": "T1"}},
+		{name: "none"},
+		{name: "invalid", headers: map[string]string{"CF-IPCountry": "Narnia"}, error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("GET", "/?country=FR", nil)
+			require.NoError(t, err)
+			for k, v := range test.headers {
+				request.Header.Set(k, v)
+			}
+			k := &gated{}
+			err = Bind(request, k)
+			if test.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, *k)
+		})
+	}
+
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalHeaders(request, &struct {
+		Planet string `geo:"planet"`
+	}{}))
+}
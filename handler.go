@@ -0,0 +1,216 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+)
+
+// ErrorHook is told about every error a Handler writes, e.g. to log it.
+// Panics arrive as a *PanicError, RequestID(r) is the id sent to the client.
+type ErrorHook func(r *http.Request, err error)
+
+// WithErrorHook sets the hook Handler calls with the errors it writes
+func WithErrorHook(hook ErrorHook) Option {
+	return func(b *Binder) {
+		b.errorHook = hook
+	}
+}
+
+// PanicError is a panic recovered from a handler
+type PanicError struct {
+	// Value is what the handler panicked with
+	Value interface{}
+	// Stack is the stack trace of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.Value)
+}
+
+// StatusError is an error with the http status WriteError sends for it
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorDocument is the json body WriteError sends
+type ErrorDocument struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	// RequestID is the request's correlation id, for support tickets
+	RequestID string        `json:"request_id"`
+	Errors    []ErrorDetail `json:"errors,omitempty"`
+}
+
+// ErrorDetail is one failed field of an ErrorDocument
+type ErrorDetail struct {
+	Field   string `json:"field"`
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler binds a T with Bind, or UnmarshalMultipart for multipart bodies,
+// and calls fn with it. Uploads are released with Cleanup once fn returns.
+// Bind errors and errors fn returns are written with WriteError, which picks
+// the status from the error, e.g. a 403 for a field the caller's roles can't
+// set, and otherwise a bind error is a 400 and an error from fn a 500. A
+// panic in fn is recovered and written as a 500, so services don't need a
+// separate recovery middleware. The response's X-Request-Id header is the
+// request's correlation id, see RequestID.
+func Handler[T any](fn func(w http.ResponseWriter, r *http.Request, v *T) error) http.Handler {
+	return HandlerWith(defaultBinder, fn)
+}
+
+// HandlerWith is Handler with the binder's options and error hook
+func HandlerWith[T any](b *Binder, fn func(w http.ResponseWriter, r *http.Request, v *T) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, id := withRequestID(r)
+		w.Header().Set(RequestIDHeader, id)
+		hw := &handlerWriter{ResponseWriter: w}
+		fail := func(err error) {
+			if b.errorHook != nil {
+				b.errorHook(r, err)
+			}
+			// once the handler has started the response the status can't
+			// change
+			if !hw.wrote {
+				WriteError(hw, r, err)
+			}
+		}
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				fail(&PanicError{Value: recovered, Stack: debug.Stack()})
+			}
+		}()
+
+		v := new(T)
+		defer Cleanup(v)
+		bind := b.Bind
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+			bind = b.UnmarshalMultipart
+		}
+		if err := bind(r, v); err != nil {
+			// bind errors WriteError has no status for are the client's
+			if errorStatus(err) == 0 {
+				err = &StatusError{Status: http.StatusBadRequest, Err: err}
+			}
+			fail(err)
+			return
+		}
+		if err := fn(hw, r, v); err != nil {
+			fail(err)
+		}
+	})
+}
+
+// handlerWriter notes whether the handler has written the response
+type handlerWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *handlerWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *handlerWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *handlerWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WriteError writes err as a json ErrorDocument. A ThrottledError is a 429
+// with a Retry-After header, a TimeoutError a 408 for a slow body or a 503
+// for a slow hook and a body over the size limit a 413, even inside a
+// StatusError. Otherwise the status comes from a StatusError, ErrForbidden
+// is a 403, ErrInvalidSignature a 401, ErrNotWebSocket, ErrTruncatedBody,
+// ErrContentLength, ErrChecksum and ErrInfected a 400 and anything else a
+// 500. Below 500 the message is the error's and each FieldError is listed, a
+// 500 only says internal server error so nothing about the server leaks. The
+// document carries the request's correlation id, see RequestID.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	status := errorStatus(err)
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	var throttled *ThrottledError
+	if errors.As(err, &throttled) && throttled.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(throttled.RetryAfter)))
+	}
+
+	doc := ErrorDocument{Status: status, Message: http.StatusText(status), RequestID: RequestID(r)}
+	if status < http.StatusInternalServerError {
+		doc.Message = err.Error()
+		doc.Errors = errorDetails(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+// errorStatus is the status WriteError gives err, or zero when nothing in
+// err maps to one
+func errorStatus(err error) int {
+	var statusErr *StatusError
+	var throttled *ThrottledError
+	var timeout *TimeoutError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &throttled):
+		return http.StatusTooManyRequests
+	case errors.As(err, &timeout) && timeout.Body:
+		return http.StatusRequestTimeout
+	case errors.As(err, &timeout):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrBodyTooLarge), errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.As(err, &statusErr):
+		return statusErr.Status
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrNotWebSocket), errors.Is(err, ErrTruncatedBody), errors.Is(err, ErrContentLength), errors.Is(err, ErrChecksum), errors.Is(err, ErrInfected):
+		return http.StatusBadRequest
+	}
+	return 0
+}
+
+// errorDetails lists the field errors in err, one for each of Errors
+func errorDetails(err error) []ErrorDetail {
+	var list Errors
+	if !errors.As(err, &list) {
+		list = Errors{err}
+	}
+	var details []ErrorDetail
+	for _, err := range list {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			details = append(details, ErrorDetail{Field: fieldErr.Field, Code: fieldErr.Code, Message: err.Error()})
+		}
+	}
+	return details
+}
@@ -0,0 +1,122 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type handlerOrder struct {
+	SKU string `json:"sku" required:"true"`
+	Qty int    `json:"qty"`
+}
+
+func TestHandler(t *testing.T) {
+	var hooked []error
+	binder := New(WithErrorHook(func(r *http.Request, err error) {
+		hooked = append(hooked, err)
+	}))
+	handler := HandlerWith(binder, func(w http.ResponseWriter, r *http.Request, order *handlerOrder) error {
+		switch order.SKU {
+		case "panic":
+			panic("out of stock")
+		case "gone":
+			return &StatusError{Status: http.StatusNotFound, Err: errors.New("sku gone not found")}
+		case "secret":
+			return errors.New("database password is hunter2")
+		}
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+
+	tests := []struct {
+		body     string
+		status   int
+		expected string
+	}{
+		{body: `{"sku":"a"}`, status: http.StatusCreated},
+		{body: `{"qty":1}`, status: http.StatusBadRequest, expected: `{"status":400,"message":"field SKU is required","request_id":"req-1","errors":[{"field":"SKU","code":"REQUIRED","message":"field SKU is required"}]}`},
+		{body: `{"sku":"gone"}`, status: http.StatusNotFound, expected: `{"status":404,"message":"sku gone not found","request_id":"req-1"}`},
+		{body: `{"sku":"secret"}`, status: http.StatusInternalServerError, expected: `{"status":500,"message":"Internal Server Error","request_id":"req-1"}`},
+		{body: `{"sku":"panic"}`, status: http.StatusInternalServerError, expected: `{"status":500,"message":"Internal Server Error","request_id":"req-1"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/", strings.NewReader(test.body))
+			request.Header.Set(RequestIDHeader, "req-1")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			require.Equal(t, test.status, recorder.Code)
+			require.Equal(t, "req-1", recorder.Header().Get(RequestIDHeader))
+			if test.expected != "" {
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				require.JSONEq(t, test.expected, recorder.Body.String())
+			}
+		})
+	}
+
+	require.Len(t, hooked, 4)
+	var panicErr *PanicError
+	require.True(t, errors.As(hooked[3], &panicErr))
+	require.Equal(t, "out of stock", panicErr.Value)
+	require.NotEmpty(t, panicErr.Stack)
+}
+
+func TestHandlerPanicAfterWrite(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request, order *handlerOrder) error {
+		w.WriteHeader(http.StatusAccepted)
+		panic("late")
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/", strings.NewReader(`{"sku":"a"}`)))
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	require.Empty(t, recorder.Body.String())
+}
+
+func TestWriteErrorStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{err: ErrForbidden, status: http.StatusForbidden},
+		{err: ErrInvalidSignature, status: http.StatusUnauthorized},
+		{err: errors.New("boom"), status: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, httptest.NewRequest("GET", "/", nil), test.err)
+			require.Equal(t, test.status, recorder.Code)
+			var doc ErrorDocument
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+			require.Equal(t, test.status, doc.Status)
+		})
+	}
+}
+
+func TestHandlerBindErrorStatus(t *testing.T) {
+	type account struct {
+		Name  string `json:"name"`
+		Admin bool   `json:"admin" allow-roles:"admin"`
+	}
+	handler := Handler(func(w http.ResponseWriter, r *http.Request, a *account) error {
+		return nil
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a","admin":true}`)))
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	request.Header.Set("X-Signature", "00")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, WithVerifier(request, HMACSHA256Verifier("X-Signature", "", []byte("secret"))))
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,63 @@
+package reqbind
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyHeader is the header clients send the key in
+const IdempotencyHeader = "Idempotency-Key"
+
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyStore is the storage backend used for replay detection. Reserve
+// records the key and returns false if it has been seen before.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key string) (bool, error)
+}
+
+// IdempotencyKey returns the Idempotency-Key header, or "" if it wasn't sent.
+// Keys must be at most 255 visible ASCII characters.
+func IdempotencyKey(r *http.Request) (string, error) {
+	key := r.Header.Get(IdempotencyHeader)
+	if len(key) > maxIdempotencyKeyLength {
+		return "", fmt.Errorf("idempotency key is too long")
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < '!' || key[i] > '~' {
+			return "", fmt.Errorf("idempotency key has invalid characters")
+		}
+	}
+	return key, nil
+}
+
+// IdempotencyMiddleware rejects invalid keys with a 400 and replayed keys
+// with a 409. Requests without a key are passed through, use a required
+// header field on the request struct to insist on one.
+func IdempotencyMiddleware(store IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := IdempotencyKey(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			fresh, err := store.Reserve(r.Context(), key)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if !fresh {
+				http.Error(w, "idempotency key has already been used", http.StatusConflict)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package reqbind
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIdempotencyStore struct {
+	seen map[string]bool
+	err  error
+}
+
+func (s *fakeIdempotencyStore) Reserve(ctx context.Context, key string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if s.seen[key] {
+		return false, nil
+	}
+	s.seen[key] = true
+	return true, nil
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	k := &struct {
+		Key string `header:"Idempotency-Key" required:"true" validate:"uuid"`
+	}{}
+
+	request, err := http.NewRequest("POST", "/", nil)
+	require.NoError(t, err)
+	request.Header.Set(IdempotencyHeader, "0b6c6c4e-6c1f-4a8e-9a55-3d4c7b1c2f10")
+	require.NoError(t, UnmarshalHeaders(request, k))
+	require.Equal(t, "0b6c6c4e-6c1f-4a8e-9a55-3d4c7b1c2f10", k.Key)
+
+	request.Header.Set(IdempotencyHeader, "not-a-uuid")
+	require.Error(t, UnmarshalHeaders(request, k))
+
+	// required still rejects a missing key
+	k.Key = ""
+	request.Header.Del(IdempotencyHeader)
+	require.Error(t, UnmarshalHeaders(request, k))
+}
+
+func TestOptionalUUID(t *testing.T) {
+	k := &struct {
+		Name      string `json:"name"`
+		RequestID string `json:"requestId" validate:"uuid"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"requestId":"nope"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, k))
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", nil)
+	require.NoError(t, err)
+
+	request.Header.Set(IdempotencyHeader, "order-1234")
+	key, err := IdempotencyKey(request)
+	require.NoError(t, err)
+	require.Equal(t, "order-1234", key)
+
+	request.Header.Set(IdempotencyHeader, strings.Repeat("a", 256))
+	_, err = IdempotencyKey(request)
+	require.Error(t, err)
+
+	request.Header.Set(IdempotencyHeader, "has space")
+	_, err = IdempotencyKey(request)
+	require.Error(t, err)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	store := &fakeIdempotencyStore{seen: map[string]bool{}}
+	handler := IdempotencyMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	serve := func(key string) int {
+		request := httptest.NewRequest("POST", "/", nil)
+		if key != "" {
+			request.Header.Set(IdempotencyHeader, key)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusCreated, serve("abc"))
+	require.Equal(t, http.StatusConflict, serve("abc"))
+	require.Equal(t, http.StatusCreated, serve(""))
+	require.Equal(t, http.StatusBadRequest, serve(strings.Repeat("a", 256)))
+
+	store.err = errors.New("down")
+	require.Equal(t, http.StatusInternalServerError, serve("def"))
+}
@@ -0,0 +1,183 @@
+package reqbind
+
+import (
+	"reflect"
+	"sync"
+)
+
+// isIgnored reports whether a field is left alone by binding and
+// validation: unexported fields, json:"-" and reqbind:"-". A reqbind:"-"
+// field keeps whatever the server put in it, even if the client sends its
+// key.
+func isIgnored(f reflect.StructField) bool {
+	return !f.IsExported() || f.Tag.Get("json") == "-" || f.Tag.Get("reqbind") == "-"
+}
+
+// unmarshalFields is json.Unmarshal into v that leaves reqbind:"-" fields,
+// including the ones in nested structs, as they were. Fields filled in from
+// the request itself, like clientip, are kept the same way so the client
+// can't send its own. Structs the decoder allocates, behind a nil pointer
+// or in a slice or map, get those fields zeroed. Data URIs sent for datauri
+// fields are decoded first.
+func unmarshalFields(data []byte, v interface{}, useNumber bool) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return unmarshalJSON(data, v, useNumber)
+	}
+	data, err := decodeDataURIs(data, rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	if !hasProtectedFields(rv.Elem().Type()) {
+		return unmarshalJSON(data, v, useNumber)
+	}
+
+	saved := &protectedFields{seen: make(map[uintptr]bool)}
+	saved.save(rv.Elem())
+	err = unmarshalJSON(data, v, useNumber)
+	for _, field := range saved.fields {
+		field.field.Set(field.value)
+	}
+	saved.clear(rv.Elem(), false)
+	return err
+}
+
+// isProtected is true for the fields the client can't set: exported
+// reqbind:"-" fields and request fields
+func isProtected(f reflect.StructField) bool {
+	return f.IsExported() && f.Tag.Get("json") != "-" && (f.Tag.Get("reqbind") == "-" || isRequestField(f))
+}
+
+// protectedFields holds the protected fields of a struct and the structs
+// already reachable from it, which the decoder reuses, so they can be put
+// back after decoding
+type protectedFields struct {
+	fields []savedField
+	// seen are the structs behind pointers that were saved
+	seen map[uintptr]bool
+}
+
+type savedField struct {
+	field reflect.Value
+	value reflect.Value
+}
+
+// save records the protected fields of the struct sv and of the structs it
+// holds or points to
+func (p *protectedFields) save(sv reflect.Value) {
+	for i := 0; i < sv.NumField(); i++ {
+		f := sv.Type().Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		field := sv.Field(i)
+		if isProtected(f) {
+			value := reflect.New(field.Type()).Elem()
+			value.Set(field)
+			p.fields = append(p.fields, savedField{field: field, value: value})
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Struct:
+			p.save(field)
+		case field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.Struct && !p.seen[field.Pointer()]:
+			p.seen[field.Pointer()] = true
+			p.save(field.Elem())
+		}
+	}
+}
+
+// clear zeroes the protected fields of the structs the decoder allocated.
+// fresh is set when sv itself is one of them.
+func (p *protectedFields) clear(sv reflect.Value, fresh bool) {
+	for i := 0; i < sv.NumField(); i++ {
+		f := sv.Type().Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		field := sv.Field(i)
+		if isProtected(f) {
+			if fresh {
+				field.Set(reflect.Zero(field.Type()))
+			}
+			continue
+		}
+		if !hasProtectedFields(f.Type) {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			p.clear(field, fresh)
+		case reflect.Ptr:
+			if !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+				p.clear(field.Elem(), fresh || !p.seen[field.Pointer()])
+			}
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < field.Len(); j++ {
+				p.clearItem(field.Index(j))
+			}
+		case reflect.Map:
+			iter := field.MapRange()
+			for iter.Next() {
+				item := reflect.New(iter.Value().Type()).Elem()
+				item.Set(iter.Value())
+				p.clearItem(item)
+				field.SetMapIndex(iter.Key(), item)
+			}
+		}
+	}
+}
+
+// clearItem clears a slice, array or map item, a struct or a pointer to one
+func (p *protectedFields) clearItem(item reflect.Value) {
+	if item.Kind() == reflect.Ptr && !item.IsNil() {
+		item = item.Elem()
+	}
+	if item.Kind() == reflect.Struct {
+		p.clear(item, true)
+	}
+}
+
+// protectedTypes caches whether a type has protected fields anywhere in it
+var protectedTypes sync.Map
+
+// hasProtectedFields reports whether t, or a struct it holds, points to or
+// has items of, has protected fields
+func hasProtectedFields(t reflect.Type) bool {
+	if cached, ok := protectedTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	has := typeHasProtected(t, make(map[reflect.Type]bool))
+	protectedTypes.Store(t, has)
+	return has
+}
+
+func typeHasProtected(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHasProtected(t.Elem(), visiting)
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if isProtected(f) {
+			return true
+		}
+		if f.IsExported() && f.Tag.Get("json") != "-" && typeHasProtected(f.Type, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequestField is true for fields filled in from the request itself rather
+// than anything the client sends as a value
+func isRequestField(f reflect.StructField) bool {
+	return f.Tag.Get("clientip") == "true" || f.Tag.Get("useragent") != "" || f.Tag.Get("geo") != "" || f.Tag.Get("file") != ""
+}
@@ -0,0 +1,77 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type ignoreRequest struct {
+	Name     string `json:"name" required:"true"`
+	OwnerID  string `json:"ownerId" reqbind:"-" required:"true" validate:"uuid"`
+	Internal string `json:"-" required:"true"`
+	secret   string
+	Nested   struct {
+		Level string `json:"level" reqbind:"-"`
+		Note  string `json:"note"`
+	} `json:"nested"`
+	Sort []SortField `json:"sort" sort:"name" reqbind:"-"`
+}
+
+func TestIgnoredFields(t *testing.T) {
+	router := chi.NewRouter()
+	router.Post("/{ownerId}", func(w http.ResponseWriter, r *http.Request) {
+		k := &ignoreRequest{OwnerID: "server", secret: "s"}
+		k.Nested.Level = "server"
+		require.NoError(t, Bind(r, k))
+		require.Equal(t, "a", k.Name)
+		require.Equal(t, "server", k.OwnerID)
+		require.Equal(t, "", k.Internal)
+		require.Equal(t, "s", k.secret)
+		require.Equal(t, "server", k.Nested.Level)
+		require.Equal(t, "n", k.Nested.Note)
+		require.Empty(t, k.Sort)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	request, err := http.NewRequest("POST", "/client?ownerId=client&sort=name&internal=x",
+		strings.NewReader(`{"name":"a","ownerId":"client","Internal":"x","secret":"x","nested":{"level":"client","note":"n"}}`))
+	require.NoError(t, err)
+	request.Header.Set("ownerId", "client")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestIgnoredFieldsStrictQuery(t *testing.T) {
+	request, err := http.NewRequest("GET", "/?ownerId=client", nil)
+	require.NoError(t, err)
+	require.Error(t, New(WithStrictQuery()).UnmarshalQuery(request, &ignoreRequest{}))
+}
+
+func TestIgnoredFieldsBehindPointers(t *testing.T) {
+	type inner struct {
+		Owner string `json:"owner" reqbind:"-"`
+		Note  string `json:"note"`
+	}
+	type request struct {
+		In    *inner           `json:"in"`
+		New   *inner           `json:"new"`
+		Items []inner          `json:"items"`
+		Refs  []*inner         `json:"refs"`
+		ByKey map[string]inner `json:"byKey"`
+	}
+	k := &request{In: &inner{Owner: "server"}}
+	body := `{"in":{"owner":"attacker","note":"a"},"new":{"owner":"attacker"},"items":[{"owner":"attacker","note":"b"}],
+		"refs":[{"owner":"attacker"}],"byKey":{"x":{"owner":"attacker","note":"c"}}}`
+	require.NoError(t, UnmarshalBody(httptest.NewRequest("POST", "/", strings.NewReader(body)), k))
+	require.Equal(t, &inner{Owner: "server", Note: "a"}, k.In)
+	require.Equal(t, &inner{}, k.New)
+	require.Equal(t, []inner{{Note: "b"}}, k.Items)
+	require.Equal(t, []*inner{{}}, k.Refs)
+	require.Equal(t, map[string]inner{"x": {Note: "c"}}, k.ByKey)
+}
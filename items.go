@@ -0,0 +1,96 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// checkItems runs the max-items and min-items tags of a slice or array
+// field. A slice that wasn't sent, or was sent as null, isn't counted, tag
+// it required:"true" as well to insist on it.
+func checkItems(f reflect.StructField, value reflect.Value) error {
+	maxItems, minItems := f.Tag.Get("max-items"), f.Tag.Get("min-items")
+	if maxItems == "" && minItems == "" {
+		return nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has max-items or min-items but is not a slice")
+	}
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		return nil
+	}
+
+	if maxItems != "" {
+		n, err := strconv.Atoi(maxItems)
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid max-items")
+		}
+		if value.Len() > n {
+			return fieldErrorf(CodeTooManyItems, f.Name, "has more than %d items", n)
+		}
+	}
+	if minItems != "" {
+		n, err := strconv.Atoi(minItems)
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid min-items")
+		}
+		if value.Len() < n {
+			return fieldErrorf(CodeTooFewItems, f.Name, "has fewer than %d items", n)
+		}
+	}
+	return nil
+}
+
+// checkUnique runs the unique tag of a slice or array field.
+// unique:"true" compares whole items, unique:"sku" compares the sku field of
+// struct items. The error lists the indexes of every duplicated item.
+func checkUnique(f reflect.StructField, value reflect.Value) error {
+	unique := f.Tag.Get("unique")
+	if unique == "" {
+		return nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has unique but is not a slice")
+	}
+
+	seen := make(map[string][]int)
+	var order []string
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i)
+		if unique != "true" {
+			for item.Kind() == reflect.Ptr && !item.IsNil() {
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.Struct {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has unique %s but its items are not structs", unique)
+			}
+			key, ok := fieldByJSONNameFold(item.Type(), unique)
+			if !ok {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has unique %s but its items have no such field", unique)
+			}
+			item = item.FieldByIndex(key.Index)
+		}
+		encoded, err := json.Marshal(item.Interface())
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has items that can't be compared: %s", err)
+		}
+		if _, ok := seen[string(encoded)]; !ok {
+			order = append(order, string(encoded))
+		}
+		seen[string(encoded)] = append(seen[string(encoded)], i)
+	}
+
+	var duplicates []string
+	for _, key := range order {
+		if indexes := seen[key]; len(indexes) > 1 {
+			duplicates = append(duplicates, fmt.Sprint(indexes))
+		}
+	}
+	if len(duplicates) > 0 {
+		return fieldErrorf(CodeDuplicateItems, f.Name, "has duplicate items at %s", strings.Join(duplicates, " "))
+	}
+	return nil
+}
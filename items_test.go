@@ -0,0 +1,102 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems(t *testing.T) {
+	type batch struct {
+		Items []struct {
+			SKU string `json:"sku"`
+		} `json:"items" min-items:"1" max-items:"3"`
+		Tags []string `json:"tags" max-items:"2"`
+	}
+
+	tests := []struct {
+		body       string
+		shouldPass bool
+	}{
+		{body: `{"items":[{"sku":"a"}]}`, shouldPass: true},
+		{body: `{"items":[{"sku":"a"},{"sku":"b"},{"sku":"c"}],"tags":["a","b"]}`, shouldPass: true},
+		{body: `{}`, shouldPass: true},
+		{body: `{"items":null}`, shouldPass: true},
+		{body: `{"items":[]}`, shouldPass: false},
+		{body: `{"items":[{"sku":"a"},{"sku":"b"},{"sku":"c"},{"sku":"d"}]}`, shouldPass: false},
+		{body: `{"tags":["a","b","c"]}`, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = UnmarshalBody(request, &batch{})
+			if test.shouldPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestItemsErrors(t *testing.T) {
+	request, err := http.NewRequest("GET", "/?tags[]=a&tags[]=b&tags[]=c", nil)
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalQuery(request, &struct {
+		Tags []string `json:"tags" max-items:"2"`
+	}{}), "field Tags has more than 2 items")
+
+	require.EqualError(t, checkMetadata(&struct {
+		Name string `max-items:"2"`
+	}{}), "field Name has max-items or min-items but is not a slice")
+
+	require.EqualError(t, checkMetadata(&struct {
+		Tags []string `min-items:"x"`
+	}{Tags: []string{}}), "field Tags has invalid min-items")
+}
+
+func TestUnique(t *testing.T) {
+	type line struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}
+	type batch struct {
+		Tags  []string `json:"tags" unique:"true"`
+		Lines []line   `json:"lines" unique:"sku"`
+		Refs  []*line  `json:"refs" unique:"SKU"`
+	}
+
+	tests := []struct {
+		body  string
+		error string
+	}{
+		{body: `{"tags":["a","b"],"lines":[{"sku":"a","qty":1},{"sku":"b","qty":1}]}`},
+		{body: `{"tags":["a","b","a","c","b","a"]}`, error: "field Tags has duplicate items at [0 2 5] [1 4]"},
+		{body: `{"lines":[{"sku":"a","qty":1},{"sku":"a","qty":2}]}`, error: "field Lines has duplicate items at [0 1]"},
+		{body: `{"refs":[{"sku":"a"},{"sku":"b"},{"sku":"b"}]}`, error: "field Refs has duplicate items at [1 2]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = UnmarshalBody(request, &batch{})
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+
+	require.Error(t, checkMetadata(&struct {
+		Tags []string `unique:"sku"`
+	}{Tags: []string{"a"}}))
+	require.Error(t, checkMetadata(&struct {
+		Lines []line `unique:"price"`
+	}{Lines: []line{{}}}))
+}
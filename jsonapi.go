@@ -0,0 +1,118 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+type jsonAPIDocument struct {
+	Data *struct {
+		Type          string                     `json:"type"`
+		ID            string                     `json:"id"`
+		Attributes    json.RawMessage            `json:"attributes"`
+		Relationships map[string]jsonAPIRelation `json:"relationships"`
+	} `json:"data"`
+}
+
+type jsonAPIRelation struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// UnmarshalJSONAPI binds a JSON:API request document. data.type must equal
+// resourceType, data.attributes are bound like UnmarshalBody, data.id goes to
+// the field tagged jsonapi:"id" and each relationship goes to the string or
+// []string field tagged jsonapi:"rel" with the same json name.
+func UnmarshalJSONAPI(r *http.Request, resourceType string, v interface{}) error {
+	return defaultBinder.UnmarshalJSONAPI(r, resourceType, v)
+}
+
+// UnmarshalJSONAPI binds a JSON:API document like the package level
+// UnmarshalJSONAPI. The sent attributes and relationships count as sent
+// fields for readonly, allow-roles and the other presence checks.
+func (b *Binder) UnmarshalJSONAPI(r *http.Request, resourceType string, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	bodyBytes, err := b.readBody(r)
+	if err != nil {
+		return err
+	}
+
+	doc := jsonAPIDocument{}
+	if err := json.Unmarshal(bodyBytes, &doc); err != nil {
+		return err
+	}
+	if doc.Data == nil {
+		return fieldErrorf(CodeRequired, "data", "is required")
+	}
+	if doc.Data.Type != resourceType {
+		return fieldErrorf(CodeInvalid, "type", "is %s, not %s", doc.Data.Type, resourceType)
+	}
+
+	presence := bodyPresence(doc.Data.Attributes)
+	if len(doc.Data.Attributes) > 0 {
+		if err := b.decodeInto(v, func(v interface{}) error {
+			return b.decodeBody(doc.Data.Attributes, v)
+		}); err != nil {
+			return err
+		}
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch f.Tag.Get("jsonapi") {
+		case "id":
+			if f.Type.Kind() != reflect.String {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has jsonapi id but is not a string")
+			}
+			rv.Field(i).SetString(doc.Data.ID)
+		case "rel":
+			rel, ok := doc.Data.Relationships[jsonName(f)]
+			if !ok {
+				continue
+			}
+			if err := setRelationship(rv.Field(i), rel.Data); err != nil {
+				return fieldErrorf(CodeInvalid, f.Name, "is invalid: %s", err)
+			}
+			presence.add(jsonName(f))
+		}
+	}
+
+	return checkStruct(v, b.checkOptions(r, &presence), "")
+}
+
+// setRelationship writes the id of a to-one relationship into a string
+// field, or the ids of a to-many relationship into a []string field
+func setRelationship(field reflect.Value, data json.RawMessage) error {
+	switch {
+	case field.Kind() == reflect.String:
+		one := jsonAPIIdentifier{}
+		if err := json.Unmarshal(data, &one); err != nil {
+			return fmt.Errorf("relationship must be a single resource")
+		}
+		field.SetString(one.ID)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		many := []jsonAPIIdentifier{}
+		if err := json.Unmarshal(data, &many); err != nil {
+			return fmt.Errorf("relationship must be a list of resources")
+		}
+		ids := reflect.MakeSlice(field.Type(), len(many), len(many))
+		for i, m := range many {
+			ids.Index(i).SetString(m.ID)
+		}
+		field.Set(ids)
+	default:
+		return fmt.Errorf("relationship fields must be string or []string")
+	}
+	return nil
+}
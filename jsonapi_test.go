@@ -0,0 +1,64 @@
+package reqbind
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type jsonAPIArticle struct {
+	ID     string   `jsonapi:"id"`
+	Title  string   `json:"title" required:"true"`
+	Author string   `json:"author" jsonapi:"rel"`
+	Tags   []string `json:"tags" jsonapi:"rel"`
+}
+
+func jsonAPIRequest(t *testing.T, body string) *http.Request {
+	request, err := http.NewRequest("POST", "/", io.NopCloser(bytes.NewReader([]byte(body))))
+	require.NoError(t, err)
+	return request
+}
+
+func TestUnmarshalJSONAPI(t *testing.T) {
+	k := &jsonAPIArticle{}
+	request := jsonAPIRequest(t, `{"data":{"type":"articles","id":"1","attributes":{"title":"Hello"},
+		"relationships":{"author":{"data":{"type":"people","id":"9"}},"tags":{"data":[{"type":"tags","id":"a"},{"type":"tags","id":"b"}]}}}}`)
+	require.NoError(t, UnmarshalJSONAPI(request, "articles", k))
+	require.Equal(t, &jsonAPIArticle{ID: "1", Title: "Hello", Author: "9", Tags: []string{"a", "b"}}, k)
+}
+
+func TestUnmarshalJSONAPIInvalid(t *testing.T) {
+	tests := []string{
+		`{}`,
+		`{"data":{"type":"people","attributes":{"title":"Hello"}}}`,
+		`{"data":{"type":"articles","attributes":{}}}`,
+		`{"data":{"type":"articles","attributes":{"title":"Hello"},"relationships":{"author":{"data":[]}}}}`,
+		`aoeu`,
+	}
+
+	for _, body := range tests {
+		t.Run(body, func(t *testing.T) {
+			require.Error(t, UnmarshalJSONAPI(jsonAPIRequest(t, body), "articles", &jsonAPIArticle{}))
+		})
+	}
+}
+
+func TestUnmarshalJSONAPIBinderOptions(t *testing.T) {
+	type article struct {
+		Title  string `json:"title"`
+		Owner  string `json:"owner" readonly:"true"`
+		Editor string `json:"editor" jsonapi:"rel" readonly:"true"`
+	}
+	err := UnmarshalJSONAPI(jsonAPIRequest(t, `{"data":{"type":"articles","attributes":{"title":"Hello","owner":"me"}}}`), "articles", &article{})
+	require.EqualError(t, err, "field Owner is read only")
+
+	err = UnmarshalJSONAPI(jsonAPIRequest(t, `{"data":{"type":"articles","attributes":{"title":"Hello"},"relationships":{"editor":{"data":{"type":"people","id":"9"}}}}}`), "articles", &article{})
+	require.EqualError(t, err, "field Editor is read only")
+
+	binder := New(WithMaxBodyBytes(16))
+	err = binder.UnmarshalJSONAPI(jsonAPIRequest(t, `{"data":{"type":"articles","attributes":{"title":"Hello"}}}`), "articles", &article{})
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+}
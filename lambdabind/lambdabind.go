@@ -0,0 +1,122 @@
+// Package lambdabind binds API Gateway proxy events through the same tag
+// pipeline as reqbind so request structs can be shared between net/http and
+// Lambda deployments.
+package lambdabind
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/go-chi/chi/v5"
+)
+
+// Request has the fields of events.APIGatewayProxyRequest that binding uses,
+// with the same json names. Use FromEvent to convert the aws type.
+type Request struct {
+	Path                            string              `json:"path"`
+	HTTPMethod                      string              `json:"httpMethod"`
+	Headers                         map[string]string   `json:"headers"`
+	MultiValueHeaders               map[string][]string `json:"multiValueHeaders"`
+	QueryStringParameters           map[string]string   `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string `json:"multiValueQueryStringParameters"`
+	PathParameters                  map[string]string   `json:"pathParameters"`
+	Body                            string              `json:"body"`
+	IsBase64Encoded                 bool                `json:"isBase64Encoded"`
+}
+
+// FromEvent converts an events.APIGatewayProxyRequest (or anything with the
+// same json shape) into a Request without this package depending on the aws
+// sdk
+func FromEvent(event interface{}) (Request, error) {
+	req := Request{}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return req, err
+	}
+	err = json.Unmarshal(b, &req)
+	return req, err
+}
+
+// HTTPRequest builds the *http.Request reqbind would have seen behind API
+// Gateway, path parameters are set on a chi route context
+func HTTPRequest(ctx context.Context, req Request) (*http.Request, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+
+	query := url.Values{}
+	for k, v := range req.QueryStringParameters {
+		query.Set(k, v)
+	}
+	for k, v := range req.MultiValueQueryStringParameters {
+		query[k] = v
+	}
+
+	u := &url.URL{Path: req.Path, RawQuery: query.Encode()}
+	r, err := http.NewRequestWithContext(ctx, req.HTTPMethod, u.String(), io.NopCloser(bytes.NewReader(body)))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		r.Header.Set(k, v)
+	}
+	for k, v := range req.MultiValueHeaders {
+		r.Header.Del(k)
+		for _, value := range v {
+			r.Header.Add(k, value)
+		}
+	}
+
+	rctx := chi.NewRouteContext()
+	for k, v := range req.PathParameters {
+		rctx.URLParams.Add(k, v)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)), nil
+}
+
+// UnmarshalBody binds the (possibly base64 encoded) body like reqbind.UnmarshalBody
+func UnmarshalBody(req Request, v interface{}) error {
+	r, err := HTTPRequest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return reqbind.UnmarshalBody(r, v)
+}
+
+// UnmarshalQuery binds the query string parameters like reqbind.UnmarshalQuery
+func UnmarshalQuery(req Request, v interface{}) error {
+	r, err := HTTPRequest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return reqbind.UnmarshalQuery(r, v)
+}
+
+// UnmarshalURLParams binds the path parameters like reqbind.UnmarshalURLParams
+func UnmarshalURLParams(req Request, v interface{}) error {
+	r, err := HTTPRequest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return reqbind.UnmarshalURLParams(r, v)
+}
+
+// UnmarshalHeaders binds the headers like reqbind.UnmarshalHeaders
+func UnmarshalHeaders(req Request, v interface{}) error {
+	r, err := HTTPRequest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return reqbind.UnmarshalHeaders(r, v)
+}
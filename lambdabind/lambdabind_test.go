@@ -0,0 +1,68 @@
+package lambdabind
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// event has the same shape as events.APIGatewayProxyRequest
+type event struct {
+	Resource              string            `json:"resource"`
+	Path                  string            `json:"path"`
+	HTTPMethod            string            `json:"httpMethod"`
+	Headers               map[string]string `json:"headers"`
+	QueryStringParameters map[string]string `json:"queryStringParameters"`
+	PathParameters        map[string]string `json:"pathParameters"`
+	Body                  string            `json:"body"`
+	IsBase64Encoded       bool              `json:"isBase64Encoded"`
+}
+
+func TestBindEvent(t *testing.T) {
+	req, err := FromEvent(event{
+		Resource:              "/projects/{projectId}",
+		Path:                  "/projects/p1",
+		HTTPMethod:            "POST",
+		Headers:               map[string]string{"X-Tenant": "acme"},
+		QueryStringParameters: map[string]string{"size": "3"},
+		PathParameters:        map[string]string{"projectId": "p1"},
+		Body:                  base64.StdEncoding.EncodeToString([]byte(`{"name":"  Board  "}`)),
+		IsBase64Encoded:       true,
+	})
+	require.NoError(t, err)
+
+	u := &struct {
+		ProjectID string `required:"true"`
+	}{}
+	require.NoError(t, UnmarshalURLParams(req, u))
+	require.Equal(t, "p1", u.ProjectID)
+
+	q := &struct {
+		Size int `required:"true"`
+	}{}
+	require.NoError(t, UnmarshalQuery(req, q))
+	require.Equal(t, 3, q.Size)
+
+	h := &struct {
+		Tenant string `header:"X-Tenant" required:"true"`
+	}{}
+	require.NoError(t, UnmarshalHeaders(req, h))
+	require.Equal(t, "acme", h.Tenant)
+
+	b := &struct {
+		Name string `required:"true" trimlower:"true"`
+	}{}
+	require.NoError(t, UnmarshalBody(req, b))
+	require.Equal(t, "board", b.Name)
+}
+
+func TestBindEventInvalid(t *testing.T) {
+	req := Request{HTTPMethod: "POST", Path: "/", Body: "not base64!", IsBase64Encoded: true}
+	require.Error(t, UnmarshalBody(req, &struct{}{}))
+
+	req = Request{HTTPMethod: "POST", Path: "/", Body: `{}`}
+	require.Error(t, UnmarshalBody(req, &struct {
+		Name string `required:"true"`
+	}{}))
+}
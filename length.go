@@ -0,0 +1,56 @@
+package reqbind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseLengthTag parses the value of a max-length or truncate tag, "64"
+// counts bytes and "64,runes" counts characters
+func parseLengthTag(tag string) (int, bool, error) {
+	value, unit, _ := strings.Cut(tag, ",")
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false, fmt.Errorf("invalid length %s", value)
+	}
+	switch unit {
+	case "", "bytes":
+		return n, false, nil
+	case "runes":
+		return n, true, nil
+	default:
+		return 0, false, fmt.Errorf("unknown unit %s", unit)
+	}
+}
+
+// stringLength is the length of s in bytes or runes
+func stringLength(s string, runes bool) int {
+	if runes {
+		return utf8.RuneCountInString(s)
+	}
+	return len(s)
+}
+
+// truncateString cuts s down to n bytes or runes. A byte limit never splits
+// a multi-byte character, the whole character is dropped instead.
+func truncateString(s string, n int, runes bool) string {
+	if runes {
+		i := 0
+		for count := range s {
+			if i == n {
+				return s[:count]
+			}
+			i++
+		}
+		return s
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
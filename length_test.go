@@ -0,0 +1,67 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		value    string
+		n        int
+		runes    bool
+		expected string
+	}{
+		{value: "hello", n: 3, expected: "hel"},
+		{value: "hello", n: 10, expected: "hello"},
+		{value: "héllo", n: 2, expected: "h"},
+		{value: "héllo", n: 3, expected: "hé"},
+		{value: "日本語", n: 4, expected: "日"},
+		{value: "👍👍", n: 5, expected: "👍"},
+		{value: "日本語", n: 2, runes: true, expected: "日本"},
+		{value: "日本語", n: 5, runes: true, expected: "日本語"},
+		{value: "a👍b", n: 0, runes: true, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			truncated := truncateString(test.value, test.n, test.runes)
+			require.Equal(t, test.expected, truncated)
+			require.True(t, utf8.ValidString(truncated))
+		})
+	}
+}
+
+func TestRuneLengths(t *testing.T) {
+	type profile struct {
+		Name string `json:"name" max-length:"4,runes"`
+		Bio  string `json:"bio" truncate:"3,runes"`
+		Tag  string `json:"tag" max-length:"4"`
+	}
+
+	k := &profile{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"日本語","bio":"👍👍👍👍","tag":"ab"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "👍👍👍", k.Bio)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"日本語です"}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &profile{}), "field Name is too long")
+
+	// bytes are still the default for max-length
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"tag":"日本"}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &profile{}), "field Tag is too long")
+
+	require.Error(t, checkMetadata(&struct {
+		Name string `max-length:"4,words"`
+	}{}))
+	require.Error(t, checkMetadata(&struct {
+		Name string `truncate:"-1"`
+	}{}))
+}
@@ -0,0 +1,100 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+// JSONLimits caps the shape of a json body. Zero means no limit.
+type JSONLimits struct {
+	// MaxDepth is how deeply objects and arrays can nest
+	MaxDepth int
+	// MaxItems is the longest any single array can be
+	MaxItems int
+	// MaxKeys is the number of object keys in the whole document
+	MaxKeys int
+}
+
+// WithJSONLimits checks bodies against the limits before they're decoded,
+// so deeply nested or enormous documents are rejected without building them
+func WithJSONLimits(limits JSONLimits) Option {
+	return func(b *Binder) {
+		b.limits = limits
+	}
+}
+
+// readBody reads the body like the package level readBody and checks it
+// against the binder's json limits and UTF-8 mode
+func (b *Binder) readBody(r *http.Request) ([]byte, error) {
+	bodyBytes, err := readBody(r, b.maxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkUTF8Body(bodyBytes); err != nil {
+		return nil, err
+	}
+	return bodyBytes, b.limits.check(bodyBytes)
+}
+
+// check walks the tokens of data without decoding it. Syntax errors are left
+// for the decoder to report.
+func (limits JSONLimits) check(data []byte) error {
+	if limits == (JSONLimits{}) || len(data) == 0 {
+		return nil
+	}
+
+	type frame struct {
+		object    bool
+		expectKey bool
+		items     int
+	}
+	var stack []*frame
+	keys := 0
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+
+		delim, isDelim := token.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return nil
+			}
+			continue
+		}
+
+		// a key or the start of a value in the enclosing object or array
+		if len(stack) > 0 {
+			top := stack[len(stack)-1]
+			switch {
+			case top.object && top.expectKey:
+				keys++
+				if limits.MaxKeys > 0 && keys > limits.MaxKeys {
+					return bodyErrorf(CodeTooManyKeys, "has more than %d keys", limits.MaxKeys)
+				}
+				top.expectKey = false
+				continue
+			case top.object:
+				top.expectKey = true
+			default:
+				top.items++
+				if limits.MaxItems > 0 && top.items > limits.MaxItems {
+					return bodyErrorf(CodeTooManyItems, "has an array with more than %d items", limits.MaxItems)
+				}
+			}
+		}
+
+		if isDelim {
+			stack = append(stack, &frame{object: delim == '{', expectKey: true})
+			if limits.MaxDepth > 0 && len(stack) > limits.MaxDepth {
+				return bodyErrorf(CodeTooDeep, "is nested more than %d levels deep", limits.MaxDepth)
+			}
+		} else if len(stack) == 0 {
+			return nil
+		}
+	}
+}
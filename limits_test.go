@@ -0,0 +1,59 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type limitsRequest struct {
+	A interface{} `json:"a"`
+	C interface{} `json:"c"`
+}
+
+func TestJSONLimits(t *testing.T) {
+	limits := JSONLimits{MaxDepth: 3, MaxItems: 3, MaxKeys: 5}
+	tests := []struct {
+		name       string
+		body       string
+		shouldPass bool
+	}{
+		{name: "within limits", body: `{"a":{"b":[1,2,3]},"c":"d"}`, shouldPass: true},
+		{name: "empty body", body: ``, shouldPass: true},
+		{name: "too deep", body: `{"a":{"b":[[1]]}}`, shouldPass: false},
+		{name: "too many items", body: `{"a":[1,2,3,4]}`, shouldPass: false},
+		{name: "too many items in a nested array", body: `{"a":[[1],[1,2,3,4]]}`, shouldPass: false},
+		{name: "too many keys", body: `{"a":1,"b":2,"c":{"d":3,"e":4,"f":5}}`, shouldPass: false},
+		{name: "keys in arrays count", body: `{"a":[{"b":1,"d":1},{"b":1,"d":1}],"c":1}`, shouldPass: false},
+		{name: "strings that look like keys", body: `{"a":["b","c","d"]}`, shouldPass: true},
+	}
+
+	binder := New(WithJSONLimits(limits))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = binder.UnmarshalBody(request, &limitsRequest{})
+			if test.shouldPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// syntax errors come from the decoder
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"a":`))
+	require.NoError(t, err)
+	require.Error(t, binder.UnmarshalBody(request, &limitsRequest{}))
+}
+
+func TestJSONLimitsDeepBody(t *testing.T) {
+	body := strings.Repeat("[", 100000) + strings.Repeat("]", 100000)
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"a":`+body+`}`))
+	require.NoError(t, err)
+	err = New(WithJSONLimits(JSONLimits{MaxDepth: 32})).Bind(request, &limitsRequest{})
+	require.EqualError(t, err, "body is nested more than 32 levels deep")
+}
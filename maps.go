@@ -0,0 +1,76 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// checkMap runs the max-keys, keys and values tags of a map field. keys and
+// values take a rule list like Rules, e.g. keys:"validate=slug,max-length=63"
+// and values:"required,max-length=200". Rules that change the value, like
+// trimlower, are written back into the map.
+func checkMap(f reflect.StructField, value reflect.Value) error {
+	maxKeys, keysTag, valuesTag := f.Tag.Get("max-keys"), f.Tag.Get("keys"), f.Tag.Get("values")
+	if maxKeys == "" && keysTag == "" && valuesTag == "" {
+		return nil
+	}
+	if value.Kind() != reflect.Map {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has max-keys, keys or values but is not a map")
+	}
+
+	if maxKeys != "" {
+		n, err := strconv.Atoi(maxKeys)
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid max-keys")
+		}
+		if value.Len() > n {
+			return fieldErrorf(CodeTooManyKeys, f.Name, "has more than %d keys", n)
+		}
+	}
+	keyRules, _, err := compileRules(f.Name, keysTag)
+	if err != nil {
+		return err
+	}
+	valueRules, stringValues, err := compileRules(f.Name, valuesTag)
+	if err != nil {
+		return err
+	}
+	if elem := value.Type().Elem().Kind(); stringValues && elem != reflect.String && elem != reflect.Interface {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has values that only apply to strings")
+	}
+
+	// sorted so the first bad entry is always the one reported
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	for _, key := range keys {
+		name := fmt.Sprintf("%s[%v]", f.Name, key.Interface())
+		item := reflect.New(value.Type().Elem()).Elem()
+		item.Set(value.MapIndex(key))
+
+		if keysTag != "" {
+			if key.Kind() != reflect.String {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has keys but its keys are not strings")
+			}
+			newKey := reflect.New(key.Type()).Elem()
+			newKey.Set(key)
+			if err := checkField(reflect.Value{}, reflect.StructField{Name: name + " key", Type: key.Type(), Tag: keyRules}, newKey, checkOptions{}, ""); err != nil {
+				return err
+			}
+			if newKey.String() != key.String() {
+				value.SetMapIndex(key, reflect.Value{})
+				key = newKey
+			}
+		}
+		if valuesTag != "" {
+			if err := checkField(reflect.Value{}, reflect.StructField{Name: name, Type: item.Type(), Tag: valueRules}, item, checkOptions{}, ""); err != nil {
+				return err
+			}
+		}
+		value.SetMapIndex(key, item)
+	}
+	return nil
+}
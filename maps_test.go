@@ -0,0 +1,83 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapKeysAndValues(t *testing.T) {
+	type resource struct {
+		Labels map[string]string      `json:"labels" keys:"validate=slug,max-length=10" values:"required,max-length=5"`
+		Emails map[string]interface{} `json:"emails" keys:"trimlower" values:"email,trimlower"`
+	}
+
+	tests := []struct {
+		body  string
+		error string
+	}{
+		{body: `{"labels":{"env":"prod","team-a":"x"}}`},
+		{body: `{}`},
+		{body: `{"labels":{"Env":"prod"}}`, error: "field Labels[Env] key is invalid: invalid slug"},
+		{body: `{"labels":{"environments":"prod"}}`, error: "field Labels[environments] key is too long"},
+		{body: `{"labels":{"env":"production"}}`, error: "field Labels[env] is too long"},
+		{body: `{"labels":{"env":""}}`, error: "field Labels[env] is required"},
+		{body: `{"emails":{"work":"a"}}`, error: "field Emails[work] is invalid: invalid email address"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = UnmarshalBody(request, &resource{})
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+
+	// changes are written back to the keys and the values
+	k := &resource{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"emails":{" WORK ":" A@b.com"}}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, map[string]interface{}{"work": "a@b.com"}, k.Emails)
+}
+
+func TestMapTagErrors(t *testing.T) {
+	require.Error(t, checkMetadata(&struct {
+		Labels []string `keys:"slug"`
+	}{}))
+	require.Error(t, checkMetadata(&struct {
+		Counts map[string]int `values:"max-length=3"`
+	}{Counts: map[string]int{"a": 1}}))
+	require.Error(t, checkMetadata(&struct {
+		Labels map[string]string `keys:"shiny"`
+	}{}))
+}
+
+func TestMaxKeys(t *testing.T) {
+	type resource struct {
+		Metadata map[string]interface{} `json:"metadata" max-keys:"2"`
+	}
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"metadata":{"a":1,"b":{"c":1,"d":2,"e":3}}}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, &resource{}))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"metadata":{"a":1,"b":2,"c":3}}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &resource{}), "field Metadata has more than 2 keys")
+
+	request, err = http.NewRequest("GET", "/?metadata[a]=1&metadata[b]=2&metadata[c]=3", nil)
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalQuery(request, &resource{}), "field Metadata has more than 2 keys")
+
+	require.EqualError(t, checkMetadata(&struct {
+		Metadata map[string]string `max-keys:"x"`
+	}{}), "field Metadata has invalid max-keys")
+}
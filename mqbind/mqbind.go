@@ -0,0 +1,127 @@
+// Package mqbind binds Kafka, NATS and SQS message payloads through the same
+// tag pipeline as reqbind, so a message is decoded and validated exactly
+// like the http request carrying the same struct. It doesn't depend on any
+// queue client, the From functions take the parts every client exposes.
+package mqbind
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/codeallthethingz/reqbind"
+)
+
+// Message is a queue message, its headers bind header tagged fields and its
+// json body the rest
+type Message struct {
+	Header http.Header
+	Body   []byte
+}
+
+// KafkaHeader is a Kafka record header, sarama's RecordHeader and
+// kafka-go's Header convert to it
+type KafkaHeader struct {
+	Key   string
+	Value []byte
+}
+
+// FromKafka converts a Kafka record's value and headers into a Message
+func FromKafka(value []byte, headers []KafkaHeader) Message {
+	msg := Message{Header: http.Header{}, Body: value}
+	for _, header := range headers {
+		msg.Header.Add(header.Key, string(header.Value))
+	}
+	return msg
+}
+
+// FromNATS converts a NATS message's headers and data into a Message,
+// nats.Header can be passed as it is
+func FromNATS(header map[string][]string, data []byte) Message {
+	msg := Message{Header: http.Header{}, Body: data}
+	for key, values := range header {
+		for _, value := range values {
+			msg.Header.Add(key, value)
+		}
+	}
+	return msg
+}
+
+// sqsMessage has the fields of an SQS message that binding uses. The json
+// names are the ones of events.SQSMessage, the sdk's types.Message has no
+// tags and matches them case insensitively.
+type sqsMessage struct {
+	Body              *string `json:"body"`
+	MessageAttributes map[string]struct {
+		DataType    *string `json:"dataType"`
+		StringValue *string `json:"stringValue"`
+		BinaryValue []byte  `json:"binaryValue"`
+	} `json:"messageAttributes"`
+}
+
+// FromSQS converts an events.SQSMessage or an aws sdk types.Message (or
+// anything with the same json shape) into a Message without this package
+// depending on the aws sdk. Message attributes become headers, binary ones
+// base64 encoded.
+func FromSQS(message interface{}) (Message, error) {
+	b, err := json.Marshal(message)
+	if err != nil {
+		return Message{}, err
+	}
+	sqs := sqsMessage{}
+	if err := json.Unmarshal(b, &sqs); err != nil {
+		return Message{}, err
+	}
+	msg := Message{Header: http.Header{}}
+	if sqs.Body != nil {
+		msg.Body = []byte(*sqs.Body)
+	}
+	for name, attribute := range sqs.MessageAttributes {
+		switch {
+		case attribute.StringValue != nil:
+			msg.Header.Set(name, *attribute.StringValue)
+		case attribute.BinaryValue != nil:
+			msg.Header.Set(name, base64.StdEncoding.EncodeToString(attribute.BinaryValue))
+		}
+	}
+	return msg, nil
+}
+
+// Request builds the *http.Request reqbind binds the message from, pass it
+// to a reqbind.Binder's methods to bind with its options
+func (msg Message) Request(ctx context.Context) *http.Request {
+	header := msg.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
+	}
+	r := &http.Request{
+		Method:        http.MethodPost,
+		URL:           &url.URL{},
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(msg.Body)),
+		ContentLength: int64(len(msg.Body)),
+	}
+	return r.WithContext(ctx)
+}
+
+// Bind binds the headers and body like reqbind.Bind
+func Bind(ctx context.Context, msg Message, v interface{}) error {
+	return reqbind.Bind(msg.Request(ctx), v)
+}
+
+// UnmarshalBody binds the body like reqbind.UnmarshalBody
+func UnmarshalBody(ctx context.Context, msg Message, v interface{}) error {
+	return reqbind.UnmarshalBody(msg.Request(ctx), v)
+}
+
+// UnmarshalHeaders binds the headers like reqbind.UnmarshalHeaders
+func UnmarshalHeaders(ctx context.Context, msg Message, v interface{}) error {
+	return reqbind.UnmarshalHeaders(msg.Request(ctx), v)
+}
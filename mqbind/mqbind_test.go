@@ -0,0 +1,104 @@
+package mqbind
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlaced struct {
+	TraceID string `header:"X-Trace-Id" required:"true"`
+	OrderID string `json:"orderId" required:"true"`
+	Total   int    `json:"total" required:"nonzero"`
+	Email   string `json:"email" trimlower:"true"`
+}
+
+func TestFromKafka(t *testing.T) {
+	msg := FromKafka([]byte(`{"orderId":"o1","total":5,"email":" Me@Example.com "}`), []KafkaHeader{{Key: "x-trace-id", Value: []byte("t1")}})
+	v := &orderPlaced{}
+	require.NoError(t, Bind(context.Background(), msg, v))
+	require.Equal(t, orderPlaced{TraceID: "t1", OrderID: "o1", Total: 5, Email: "me@example.com"}, *v)
+
+	msg = FromKafka([]byte(`{"total":5}`), []KafkaHeader{{Key: "X-Trace-Id", Value: []byte("t1")}})
+	require.EqualError(t, Bind(context.Background(), msg, &orderPlaced{}), "field OrderID is required")
+}
+
+func TestFromNATS(t *testing.T) {
+	msg := FromNATS(map[string][]string{"X-Trace-Id": {"t2"}}, []byte(`{"orderId":"o2","total":1}`))
+	v := &orderPlaced{}
+	require.NoError(t, Bind(context.Background(), msg, v))
+	require.Equal(t, "t2", v.TraceID)
+
+	require.EqualError(t, Bind(context.Background(), FromNATS(nil, []byte(`{"orderId":"o2"}`)), &orderPlaced{}), "field TraceID is required")
+}
+
+// sqsEvent has the same shape as events.SQSMessage
+type sqsEvent struct {
+	MessageID         string `json:"messageId"`
+	Body              string `json:"body"`
+	MessageAttributes map[string]struct {
+		StringValue *string `json:"stringValue"`
+		BinaryValue []byte  `json:"binaryValue"`
+		DataType    string  `json:"dataType"`
+	} `json:"messageAttributes"`
+}
+
+// sdkMessage has the same shape as the aws sdk's types.Message, no tags
+type sdkMessage struct {
+	Body              *string
+	MessageAttributes map[string]struct {
+		StringValue *string
+		DataType    *string
+	}
+}
+
+func TestFromSQS(t *testing.T) {
+	trace := "t3"
+	event := sqsEvent{MessageID: "m1", Body: `{"orderId":"o3","total":2}`}
+	event.MessageAttributes = map[string]struct {
+		StringValue *string `json:"stringValue"`
+		BinaryValue []byte  `json:"binaryValue"`
+		DataType    string  `json:"dataType"`
+	}{
+		"X-Trace-Id": {StringValue: &trace, DataType: "String"},
+		"Signature":  {BinaryValue: []byte{1, 2}, DataType: "Binary"},
+	}
+	msg, err := FromSQS(event)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte{1, 2}), msg.Header.Get("Signature"))
+	v := &orderPlaced{}
+	require.NoError(t, Bind(context.Background(), msg, v))
+	require.Equal(t, orderPlaced{TraceID: "t3", OrderID: "o3", Total: 2}, *v)
+
+	body := `{"orderId":"o4","total":0}`
+	sdk := sdkMessage{Body: &body}
+	sdk.MessageAttributes = map[string]struct {
+		StringValue *string
+		DataType    *string
+	}{"X-Trace-Id": {StringValue: &trace}}
+	msg, err = FromSQS(sdk)
+	require.NoError(t, err)
+	require.EqualError(t, Bind(context.Background(), msg, &orderPlaced{}), "field Total is required")
+}
+
+func TestUnmarshal(t *testing.T) {
+	msg := FromNATS(map[string][]string{"X-Trace-Id": {"t5"}}, []byte(`{"orderId":"o5"}`))
+	h := &struct {
+		TraceID string `header:"X-Trace-Id" required:"true"`
+	}{}
+	require.NoError(t, UnmarshalHeaders(context.Background(), msg, h))
+	require.Equal(t, "t5", h.TraceID)
+
+	b := &struct {
+		OrderID string `json:"orderId" required:"true"`
+	}{}
+	require.NoError(t, UnmarshalBody(context.Background(), msg, b))
+	require.Equal(t, "o5", b.OrderID)
+
+	// a binder's options apply through Request
+	binder := reqbind.New(reqbind.WithMaxBodyBytes(4))
+	require.ErrorIs(t, binder.UnmarshalBody(msg.Request(context.Background()), b), reqbind.ErrBodyTooLarge)
+}
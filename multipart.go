@@ -0,0 +1,524 @@
+package reqbind
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// DefaultMultipartMaxBytes caps multipart bodies when the binder has no
+// WithMaxBodyBytes limit, the same as http.Request.ParseMultipartForm keeps
+// in memory
+const DefaultMultipartMaxBytes = 32 << 20
+
+// File is an uploaded file bound from a multipart part. Fields tagged
+// file:"name" can be a File, a *File or a []*File for repeated parts, a
+// file:"name,temp" field saves the content to a temp file rather than
+// keeping it in memory.
+type File struct {
+	// Filename is the name the client gave the file, without directories
+	Filename string
+	// Header is the part's MIME header
+	Header textproto.MIMEHeader
+	// Size is the length of the content in bytes
+	Size int64
+	// ContentType is sniffed from the content, not what the client claims
+	ContentType string
+	// Path is the temp file holding the content, empty when it's in memory
+	Path string
+
+	data []byte
+	// sha256 is the digest of the content when there's a checksum-field
+	sha256 []byte
+}
+
+// Open returns a reader over the file's content
+func (f *File) Open() (io.ReadCloser, error) {
+	if f.Path != "" {
+		return os.Open(f.Path)
+	}
+	return io.NopCloser(bytes.NewReader(f.data)), nil
+}
+
+// Remove deletes the file's temp file, if it has one
+func (f *File) Remove() error {
+	if f.Path == "" {
+		return nil
+	}
+	if err := os.Remove(f.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// WithUploadDir sets the directory file:"name,temp" fields save uploads in,
+// the default is os.TempDir
+func WithUploadDir(dir string) Option {
+	return func(b *Binder) {
+		b.uploadDir = dir
+	}
+}
+
+var (
+	fileType       = reflect.TypeOf(File{})
+	filePtrType    = reflect.TypeOf(&File{})
+	readCloserType = reflect.TypeOf((*io.ReadCloser)(nil)).Elem()
+)
+
+// UnmarshalMultipart binds a multipart/form-data body. Parts with a filename
+// go to the fields tagged file:"name", the other parts are bound like query
+// parameters and then every field is checked like the other binders.
+// Files for names without a field are skipped.
+//
+// An io.ReadCloser field streams its part to the handler instead, it has to
+// be the last part as nothing after it is read. A string field gets the
+// path of a temp file. Release temp files and streams with Cleanup,
+// Handler does it when the handler returns.
+func UnmarshalMultipart(r *http.Request, v interface{}) error {
+	return defaultBinder.UnmarshalMultipart(r, v)
+}
+
+// UnmarshalMultipart binds a multipart body like the package level
+// UnmarshalMultipart
+func (b *Binder) UnmarshalMultipart(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return fmt.Errorf("request is not multipart/form-data")
+	}
+	maxBytes := b.maxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMultipartMaxBytes
+	}
+	reader := multipart.NewReader(http.MaxBytesReader(nil, r.Body, maxBytes), params["boundary"])
+
+	fields := make(map[string]fileField)
+	if err := fileFields(reflect.TypeOf(v).Elem(), nil, fields); err != nil {
+		return err
+	}
+	values, files, err := b.readParts(r, reader, reflect.TypeOf(v).Elem(), fields)
+	// nothing is handed over when binding fails
+	defer func() {
+		if err != nil {
+			for _, bound := range files {
+				bound.release()
+			}
+		}
+	}()
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := b.decodeInto(v, func(v interface{}) error {
+		return bindQuery(escapeFormValues(values), v, b.conflicts)
+	}); err != nil {
+		return err
+	}
+	if err := bindHeaders(r.Header, v); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v).Elem()
+	for _, bound := range files {
+		if err := bound.verify(rv); err != nil {
+			return err
+		}
+		bound.set(rv.FieldByIndex(bound.index))
+	}
+
+	presence := queryPresence(values)
+	return checkStruct(v, b.checkOptions(r, &presence), "")
+}
+
+// formEscaper keeps the unescaping bindQuery does for string fields from
+// mangling a + or % in a text part, which isn't url encoded
+var formEscaper = strings.NewReplacer("%", "%25", "+", "%2B")
+
+// escapeFormValues escapes the text parts for bindQuery
+func escapeFormValues(values url.Values) url.Values {
+	escaped := make(url.Values, len(values))
+	for name, list := range values {
+		for _, value := range list {
+			escaped.Add(name, formEscaper.Replace(value))
+		}
+	}
+	return escaped
+}
+
+// fileField is a file tagged field
+type fileField struct {
+	index []int
+	// temp saves the content to a temp file
+	temp bool
+	// stream hands the part over as an io.ReadCloser
+	stream bool
+	// checksum is the field's checksum-field, if it has one
+	checksum *uploadChecksum
+}
+
+// boundFile is a file waiting to be set on the field at index
+type boundFile struct {
+	index    []int
+	file     *File
+	stream   *fileStream
+	checksum *uploadChecksum
+}
+
+// verify checks the file against its checksum-field now that it's bound,
+// streams check once they're read to the end
+func (bound boundFile) verify(rv reflect.Value) error {
+	if bound.checksum == nil {
+		return nil
+	}
+	expected := rv.FieldByIndex(bound.checksum.index).String()
+	if bound.stream != nil {
+		bound.stream.expected = expected
+		return nil
+	}
+	return bound.checksum.check(bound.file.sha256, expected)
+}
+
+// set puts the file in a File, *File, []*File, string or io.ReadCloser field
+func (bound boundFile) set(field reflect.Value) {
+	switch {
+	case bound.stream != nil:
+		field.Set(reflect.ValueOf(bound.stream))
+	case field.Type() == fileType:
+		field.Set(reflect.ValueOf(*bound.file))
+	case field.Type() == filePtrType:
+		field.Set(reflect.ValueOf(bound.file))
+	case field.Kind() == reflect.String:
+		field.SetString(bound.file.Path)
+	default:
+		field.Set(reflect.Append(field, reflect.ValueOf(bound.file)))
+	}
+}
+
+// release removes the temp file or closes the stream
+func (bound boundFile) release() {
+	if bound.stream != nil {
+		bound.stream.Close()
+		return
+	}
+	bound.file.Remove()
+}
+
+// readParts reads the text parts into values and the file parts for the
+// fields of t into files. It stops at a streamed part. A file whose checksum
+// was sent before it is checked straight away.
+func (b *Binder) readParts(r *http.Request, reader *multipart.Reader, t reflect.Type, fields map[string]fileField) (url.Values, []boundFile, error) {
+	values := url.Values{}
+	var files []boundFile
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return values, files, nil
+		}
+		if err != nil {
+			return nil, files, err
+		}
+		name := part.FormName()
+		if part.FileName() == "" {
+			value, err := io.ReadAll(part)
+			if err != nil {
+				return nil, files, err
+			}
+			values.Add(name, string(value))
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+		f := t.FieldByIndex(field.index)
+		if field.stream {
+			stream, err := b.streamFile(r.Context(), f, part, field.checksum)
+			if err != nil {
+				return nil, files, err
+			}
+			return values, append(files, boundFile{index: field.index, stream: stream, checksum: field.checksum}), nil
+		}
+		file, err := b.readFile(r.Context(), f, part, field.temp, field.checksum != nil)
+		if err != nil {
+			return nil, files, err
+		}
+		files = append(files, boundFile{index: field.index, file: file, checksum: field.checksum})
+		if field.checksum != nil {
+			if err := field.checksum.check(file.sha256, field.checksum.sent(r.Header, values)); err != nil {
+				return nil, files, err
+			}
+		}
+	}
+}
+
+// fileFields maps the names of the file tagged fields of t to the fields,
+// including the ones of embedded structs
+func fileFields(t reflect.Type, parent []int, fields map[string]fileField) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" {
+			if err := fileFields(f.Type, index, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := f.Tag.Get("file")
+		if tag == "" || isIgnored(f) {
+			continue
+		}
+		name, option, _ := strings.Cut(tag, ",")
+		checksum, err := checksumSibling(t, parent, f)
+		if err != nil {
+			return err
+		}
+		field := fileField{index: index, temp: option == "temp", checksum: checksum}
+		switch {
+		case option != "" && option != "temp":
+			return fieldErrorf(CodeInvalidTag, f.Name, "has unknown file option %s", option)
+		case f.Type == readCloserType:
+			if f.Tag.Get("max-width") != "" || f.Tag.Get("max-height") != "" {
+				return fieldErrorf(CodeInvalidTag, f.Name, "can not check the size of a streamed image")
+			}
+			field.stream = true
+		case f.Type.Kind() == reflect.String:
+			field.temp = true
+		case f.Type != fileType && f.Type != filePtrType && f.Type != reflect.SliceOf(filePtrType):
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid file type %s", f.Type)
+		}
+		fields[name] = field
+	}
+	return nil
+}
+
+// readFile reads a part into a File, or a temp file when temp is set, and
+// runs the field's upload checks. Reading stops as soon as a file is over
+// its max-file-size. With digest the sha256 is worked out while reading, and
+// the binder's FileScanner scans the part as it's read.
+func (b *Binder) readFile(ctx context.Context, f reflect.StructField, part *multipart.Part, temp bool, digest bool) (_ *File, err error) {
+	maxSize, err := maxFileSize(f)
+	if err != nil {
+		return nil, err
+	}
+	var content io.Reader = part
+	if maxSize > 0 {
+		content = io.LimitReader(part, maxSize+1)
+	}
+	hash := sha256.New()
+	if digest {
+		content = io.TeeReader(content, hash)
+	}
+	file := &File{Filename: part.FileName(), Header: part.Header}
+	scan := b.startScan(ctx, &File{Filename: file.Filename, Header: file.Header})
+	if scan != nil {
+		content = io.TeeReader(content, scan)
+	}
+	defer func() {
+		if err != nil {
+			file.Remove()
+		}
+	}()
+	if temp {
+		err = b.saveTemp(file, content)
+	} else {
+		file.data, err = io.ReadAll(content)
+		file.Size = int64(len(file.data))
+	}
+	if scan != nil {
+		if scanErr := scan.finish(f, err); err == nil {
+			err = scanErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if maxSize > 0 && file.Size > maxSize {
+		return nil, fieldErrorf(CodeInvalidFile, f.Name, "is invalid: file is larger than %s", f.Tag.Get("max-file-size"))
+	}
+	if digest {
+		file.sha256 = hash.Sum(nil)
+	}
+	if err := sniffFile(file); err != nil {
+		return nil, err
+	}
+	if err := checkFile(f, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// saveTemp copies content to a new temp file in the binder's upload dir
+func (b *Binder) saveTemp(file *File, content io.Reader) error {
+	temp, err := os.CreateTemp(b.uploadDir, "reqbind-upload-*")
+	if err != nil {
+		return err
+	}
+	defer temp.Close()
+	file.Path = temp.Name()
+	if file.Size, err = io.Copy(temp, content); err != nil {
+		return err
+	}
+	return temp.Close()
+}
+
+// sniffFile sets the content type from the first 512 bytes of the file
+func sniffFile(file *File) error {
+	content, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	file.ContentType = http.DetectContentType(head[:n])
+	return nil
+}
+
+// streamFile checks a part from its first 512 bytes and returns a reader
+// that enforces max-file-size, the checksum and the scanner's verdict as the
+// handler reads it
+func (b *Binder) streamFile(ctx context.Context, f reflect.StructField, part *multipart.Part, checksum *uploadChecksum) (*fileStream, error) {
+	maxSize, err := maxFileSize(f)
+	if err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReaderSize(part, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	file := &File{Filename: part.FileName(), Header: part.Header, ContentType: http.DetectContentType(head)}
+	if err := checkFile(f, file); err != nil {
+		return nil, err
+	}
+	stream := &fileStream{Reader: buffered, part: part, field: f, max: maxSize, checksum: checksum}
+	if checksum != nil {
+		stream.hash = sha256.New()
+	}
+	stream.scan = b.startScan(ctx, &File{Filename: file.Filename, Header: file.Header})
+	return stream, nil
+}
+
+// fileStream is a streamed part that fails once it's over max-file-size
+type fileStream struct {
+	*bufio.Reader
+	part  *multipart.Part
+	field reflect.StructField
+	// max is the field's max-file-size, 0 when there's none
+	max  int64
+	read int64
+	// checksum is checked against hash at the end of the stream
+	checksum *uploadChecksum
+	hash     hash.Hash
+	expected string
+	// scan is the scanner's, nil once it has given its verdict
+	scan *fileScan
+}
+
+func (s *fileStream) Read(p []byte) (int, error) {
+	n, err := s.Reader.Read(p)
+	s.read += int64(n)
+	if s.max > 0 && s.read > s.max {
+		over := s.read - s.max
+		if over > int64(n) {
+			over = int64(n)
+		}
+		err = fieldErrorf(CodeInvalidFile, s.field.Name, "is invalid: file is larger than %s", s.field.Tag.Get("max-file-size"))
+		s.finishScan(err)
+		return n - int(over), err
+	}
+	if s.scan != nil {
+		s.scan.Write(p[:n])
+		if err == io.EOF {
+			if scanErr := s.finishScan(nil); scanErr != nil {
+				return n, scanErr
+			}
+		} else if err != nil {
+			s.finishScan(err)
+		}
+	}
+	if s.hash != nil {
+		s.hash.Write(p[:n])
+		if err == io.EOF {
+			if checkErr := s.checksum.check(s.hash.Sum(nil), s.expected); checkErr != nil {
+				return n, checkErr
+			}
+		}
+	}
+	return n, err
+}
+
+// finishScan gets the scanner's verdict once the stream ends
+func (s *fileStream) finishScan(readErr error) error {
+	if s.scan == nil {
+		return nil
+	}
+	err := s.scan.finish(s.field, readErr)
+	s.scan = nil
+	return err
+}
+
+func (s *fileStream) Close() error {
+	s.finishScan(errors.New("stream closed"))
+	return s.part.Close()
+}
+
+// Cleanup removes the temp files of v's file fields and closes its streamed
+// ones. Handler calls it when the handler returns, call it yourself after
+// UnmarshalMultipart, e.g. with defer.
+func Cleanup(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	fields := make(map[string]fileField)
+	if err := fileFields(rv.Elem().Type(), nil, fields); err != nil {
+		return err
+	}
+	var errs []error
+	for _, field := range fields {
+		value := rv.Elem().FieldByIndex(field.index)
+		switch {
+		case field.stream:
+			if !value.IsNil() {
+				errs = append(errs, value.Interface().(io.ReadCloser).Close())
+			}
+		case value.Kind() == reflect.String:
+			errs = append(errs, (&File{Path: value.String()}).Remove())
+		case value.Type() == fileType:
+			file := value.Interface().(File)
+			errs = append(errs, file.Remove())
+		case value.Type() == filePtrType:
+			if !value.IsNil() {
+				errs = append(errs, value.Interface().(*File).Remove())
+			}
+		default:
+			for _, file := range value.Interface().([]*File) {
+				errs = append(errs, file.Remove())
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,245 @@
+package reqbind
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testPart is a part of a multipart test request, a file when it has a
+// filename
+type testPart struct {
+	name     string
+	filename string
+	content  string
+}
+
+func multipartRequest(t *testing.T, parts ...testPart) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, part := range parts {
+		var w io.Writer
+		var err error
+		if part.filename != "" {
+			w, err = writer.CreateFormFile(part.name, part.filename)
+		} else {
+			w, err = writer.CreateFormField(part.name)
+		}
+		require.NoError(t, err)
+		_, err = io.WriteString(w, part.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	request := httptest.NewRequest("POST", "/", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+type profileUpload struct {
+	Name        string  `json:"name" required:"true" max-length:"10"`
+	Age         int     `json:"age"`
+	Avatar      *File   `file:"avatar" required:"true"`
+	Attachments []*File `file:"attachments"`
+}
+
+func TestUnmarshalMultipart(t *testing.T) {
+	request := multipartRequest(t,
+		testPart{name: "name", content: "jane"},
+		testPart{name: "age", content: "42"},
+		testPart{name: "avatar", filename: "../me.png", content: pngHeader + "pixels"},
+		testPart{name: "attachments", filename: "a.txt", content: "hello"},
+		testPart{name: "attachments", filename: "b.txt", content: "world"},
+		testPart{name: "unknown", filename: "c.txt", content: "skipped"},
+	)
+	p := &profileUpload{}
+	require.NoError(t, UnmarshalMultipart(request, p))
+	require.Equal(t, "jane", p.Name)
+	require.Equal(t, 42, p.Age)
+	require.Equal(t, "me.png", p.Avatar.Filename)
+	require.Equal(t, "image/png", p.Avatar.ContentType)
+	require.Equal(t, int64(len(pngHeader)+6), p.Avatar.Size)
+	require.Len(t, p.Attachments, 2)
+
+	content, err := p.Attachments[1].Open()
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, "world", string(data))
+}
+
+func TestUnmarshalMultipartErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func(t *testing.T) *http.Request
+		binder   *Binder
+		expected string
+	}{
+		{
+			name: "missing file",
+			request: func(t *testing.T) *http.Request {
+				return multipartRequest(t, testPart{name: "name", content: "jane"})
+			},
+			expected: "field Avatar is required",
+		},
+		{
+			name: "field checks",
+			request: func(t *testing.T) *http.Request {
+				return multipartRequest(t, testPart{name: "name", content: "jane the first"}, testPart{name: "avatar", filename: "me.png", content: pngHeader})
+			},
+			expected: "field Name is too long",
+		},
+		{
+			name: "not multipart",
+			request: func(t *testing.T) *http.Request {
+				return httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"jane"}`))
+			},
+			expected: "request is not multipart/form-data",
+		},
+		{
+			name: "too large",
+			request: func(t *testing.T) *http.Request {
+				return multipartRequest(t, testPart{name: "avatar", filename: "me.png", content: pngHeader + string(make([]byte, 1024))})
+			},
+			binder:   New(WithMaxBodyBytes(512)),
+			expected: "request body is too large: more than 512 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binder := test.binder
+			if binder == nil {
+				binder = New()
+			}
+			require.EqualError(t, binder.UnmarshalMultipart(test.request(t), &profileUpload{}), test.expected)
+		})
+	}
+}
+
+func TestUnmarshalMultipartInvalidFileType(t *testing.T) {
+	request := multipartRequest(t, testPart{name: "avatar", filename: "me.png", content: pngHeader})
+	v := &struct {
+		Avatar []byte `file:"avatar"`
+	}{}
+	require.EqualError(t, UnmarshalMultipart(request, v), "field Avatar has invalid file type []uint8")
+}
+
+func TestUnmarshalMultipartTemp(t *testing.T) {
+	dir := t.TempDir()
+	v := &struct {
+		Video *File  `file:"video,temp" max-file-size:"1KB" sniff:"image/png"`
+		Raw   string `file:"raw"`
+	}{}
+	request := multipartRequest(t,
+		testPart{name: "video", filename: "v.png", content: pngHeader + "frames"},
+		testPart{name: "raw", filename: "raw.bin", content: "raw bytes"},
+	)
+	require.NoError(t, New(WithUploadDir(dir)).UnmarshalMultipart(request, v))
+	require.Equal(t, dir, filepath.Dir(v.Video.Path))
+	require.Equal(t, "image/png", v.Video.ContentType)
+	require.Equal(t, int64(len(pngHeader)+6), v.Video.Size)
+	content, err := v.Video.Open()
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	require.Equal(t, pngHeader+"frames", string(data))
+	data, err = os.ReadFile(v.Raw)
+	require.NoError(t, err)
+	require.Equal(t, "raw bytes", string(data))
+
+	require.NoError(t, Cleanup(v))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestUnmarshalMultipartTempFailure(t *testing.T) {
+	dir := t.TempDir()
+	v := &struct {
+		Video *File `file:"video,temp"`
+		Doc   *File `file:"doc,temp" max-file-size:"4"`
+	}{}
+	request := multipartRequest(t,
+		testPart{name: "video", filename: "v.mp4", content: "frames"},
+		testPart{name: "doc", filename: "d.txt", content: "too long"},
+	)
+	require.EqualError(t, New(WithUploadDir(dir)).UnmarshalMultipart(request, v), "field Doc is invalid: file is larger than 4")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "temp files are removed when binding fails")
+}
+
+func TestUnmarshalMultipartStream(t *testing.T) {
+	type upload struct {
+		Name string        `json:"name" required:"true"`
+		Data io.ReadCloser `file:"data" required:"true" max-file-size:"16" sniff:"text/*"`
+	}
+	v := &upload{}
+	request := multipartRequest(t,
+		testPart{name: "name", content: "log"},
+		testPart{name: "data", filename: "log.txt", content: "line one\nline 2\n"},
+		testPart{name: "after", content: "never read"},
+	)
+	require.NoError(t, UnmarshalMultipart(request, v))
+	require.Equal(t, "log", v.Name)
+	data, err := io.ReadAll(v.Data)
+	require.NoError(t, err)
+	require.Equal(t, "line one\nline 2\n", string(data))
+	require.NoError(t, Cleanup(v))
+
+	v = &upload{}
+	request = multipartRequest(t,
+		testPart{name: "name", content: "log"},
+		testPart{name: "data", filename: "log.txt", content: "line one\nline two\n"},
+	)
+	require.NoError(t, UnmarshalMultipart(request, v))
+	data, err = io.ReadAll(v.Data)
+	require.EqualError(t, err, "field Data is invalid: file is larger than 16")
+	require.Equal(t, "line one\nline tw", string(data))
+
+	request = multipartRequest(t, testPart{name: "name", content: "log"}, testPart{name: "data", filename: "log.png", content: pngHeader})
+	require.EqualError(t, UnmarshalMultipart(request, &upload{}), "field Data is invalid: content type image/png is not allowed")
+
+	request = multipartRequest(t, testPart{name: "data", filename: "log.txt", content: "hello"}, testPart{name: "name", content: "log"})
+	require.EqualError(t, UnmarshalMultipart(request, &upload{}), "field Name is required", "parts after the stream aren't read")
+}
+
+func TestHandlerMultipart(t *testing.T) {
+	dir := t.TempDir()
+	type upload struct {
+		Name  string `json:"name" required:"true"`
+		Video *File  `file:"video,temp" required:"true"`
+	}
+	var path string
+	handler := HandlerWith(New(WithUploadDir(dir)), func(w http.ResponseWriter, r *http.Request, v *upload) error {
+		path = v.Video.Path
+		_, err := os.Stat(path)
+		return err
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, multipartRequest(t, testPart{name: "name", content: "clip"}, testPart{name: "video", filename: "clip.mp4", content: "frames"}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotEmpty(t, path)
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err), "the temp file is removed once the handler returns")
+}
+
+func TestUnmarshalMultipartTextNotUnescaped(t *testing.T) {
+	v := &struct {
+		Note string `json:"note"`
+	}{}
+	request := multipartRequest(t, testPart{name: "note", content: "1+1 is 100%"})
+	require.NoError(t, UnmarshalMultipart(request, v))
+	require.Equal(t, "1+1 is 100%", v.Note)
+}
@@ -0,0 +1,47 @@
+package reqbind
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// BindOneOf binds a polymorphic body into one of variants, picked by the
+// discriminator field, and returns the bound variant. A discriminator sent
+// twice picks by the last one, the value the variant is bound with. Each
+// variant is a pointer to a struct and is checked with its own tags:
+//
+//	v, err := reqbind.BindOneOf(r, map[string]interface{}{"card": &CardPayment{}, "bank": &BankPayment{}}, "method")
+//	switch payment := v.(type) {
+//	case *CardPayment:
+func BindOneOf(r *http.Request, variants map[string]interface{}, discriminator string) (interface{}, error) {
+	return defaultBinder.BindOneOf(r, variants, discriminator)
+}
+
+// BindOneOf binds like the package level BindOneOf
+func (b *Binder) BindOneOf(r *http.Request, variants map[string]interface{}, discriminator string) (_ interface{}, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	kind, err := b.Peek(r, discriminator)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		return nil, fieldErrorf(CodeRequired, discriminator, "is required")
+	}
+	v, ok := variants[kind]
+	if !ok {
+		names := make([]string, 0, len(variants))
+		for name := range variants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fieldErrorf(CodeNotOneOf, discriminator, "must be one of %s", strings.Join(names, ", "))
+	}
+	if err := b.Bind(r, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
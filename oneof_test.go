@@ -0,0 +1,66 @@
+package reqbind
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindOneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected interface{}
+		error    string
+	}{
+		{name: "card", body: `{"type":"card","number":"4242"}`, expected: &cardPayment{Type: "card", Number: "4242"}},
+		{name: "bank", body: `{"iban":"GB82","type":"bank"}`, expected: &bankPayment{Type: "bank", IBAN: "GB82"}},
+		{name: "variant rules", body: `{"type":"bank","number":"4242"}`, error: "field IBAN is required"},
+		{name: "missing", body: `{"number":"4242"}`, error: "field type is required"},
+		{name: "unknown", body: `{"type":"cash"}`, error: "field type must be one of bank, card"},
+		{name: "not an object", body: `"card"`, error: "body is not a json object"},
+		{name: "duplicated discriminator", body: `{"type":"card","iban":"GB82","type":"bank"}`, expected: &bankPayment{Type: "bank", IBAN: "GB82"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			v, err := BindOneOf(request, map[string]interface{}{"card": &cardPayment{}, "bank": &bankPayment{}}, "type")
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestBindOneOfBinderLimits(t *testing.T) {
+	variants := func() map[string]interface{} {
+		return map[string]interface{}{"card": &struct {
+			Type string `json:"type"`
+		}{}}
+	}
+	body := `{"padding":"` + strings.Repeat("x", 4096) + `","type":"card"}`
+	_, err := New(WithMaxBodyBytes(1024)).BindOneOf(httptest.NewRequest("POST", "/", io.MultiReader(strings.NewReader(body))), variants(), "type")
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+
+	// the client sends the start of the body and then stalls
+	stalled, writer := io.Pipe()
+	defer writer.Close()
+	go func() {
+		_, _ = writer.Write([]byte(`{"padding":`))
+	}()
+	start := time.Now()
+	_, err = New(WithBindTimeout(20*time.Millisecond)).BindOneOf(httptest.NewRequest("POST", "/", stalled), variants(), "type")
+	require.True(t, errors.Is(err, ErrBindTimeout))
+	require.Less(t, time.Since(start), time.Second)
+}
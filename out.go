@@ -0,0 +1,101 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// ValidateOut checks a response before it's written, so a server catches
+// its own contract violations before clients do. Fields tagged
+// required-on-output:"true" must not be zero and strings must fit their
+// max-length. Nested structs and the structs in lists and maps are checked
+// too. Every failure is returned, as Errors when there's more than one.
+func ValidateOut(v interface{}) (err error) {
+	defer recoverPanic(&err)
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	errs := &collector{all: true}
+	checkOutValue(rv, errs, 0)
+	return errs.err()
+}
+
+// WriteJSON checks v with ValidateOut and writes it as json with status. A
+// response that fails is a bug on the server, it's written with WriteError
+// as a 500 and the error is returned for logging.
+func WriteJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) error {
+	if err := ValidateOut(v); err != nil {
+		err = fmt.Errorf("response is invalid: %w", err)
+		WriteError(w, r, err)
+		return err
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		WriteError(w, r, err)
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(body, '\n'))
+	return err
+}
+
+// checkOutValue checks the structs in rv, depth stops values that refer
+// back to themselves
+func checkOutValue(rv reflect.Value, errs *collector, depth int) {
+	if depth > DefaultMaxDepth {
+		return
+	}
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		if rv.Type() != reflect.TypeOf(time.Time{}) {
+			checkOutStruct(rv, errs, depth)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			checkOutValue(rv.Index(i), errs, depth+1)
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			checkOutValue(iter.Value(), errs, depth+1)
+		}
+	}
+}
+
+func checkOutStruct(rv reflect.Value, errs *collector, depth int) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		value := rv.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" && indirectType(f.Type).Kind() == reflect.Struct {
+			checkOutValue(value, errs, depth)
+			continue
+		}
+		if isIgnored(f) {
+			continue
+		}
+		if f.Tag.Get("required-on-output") == "true" && value.IsZero() {
+			errs.add(fieldErrorf(CodeRequired, f.Name, "is required on output"))
+			continue
+		}
+		str := reflect.Indirect(value)
+		if str.Kind() == reflect.String {
+			errs.add(checkMaxLength(f, str))
+		}
+		checkOutValue(value, errs, depth+1)
+	}
+}
@@ -0,0 +1,63 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type outLine struct {
+	SKU string `json:"sku" required-on-output:"true" max-length:"8"`
+}
+
+type outOrder struct {
+	ID    string             `json:"id" required-on-output:"true"`
+	Note  *string            `json:"note" max-length:"4"`
+	Lines []outLine          `json:"lines"`
+	ByID  map[string]outLine `json:"by_id"`
+}
+
+func TestValidateOut(t *testing.T) {
+	long := "too long"
+	tests := []struct {
+		name     string
+		order    interface{}
+		expected string
+	}{
+		{name: "valid", order: outOrder{ID: "1", Lines: []outLine{{SKU: "a"}}}},
+		{name: "pointer", order: &outOrder{ID: "1"}},
+		{name: "nil", order: (*outOrder)(nil)},
+		{name: "required", order: outOrder{}, expected: "field ID is required on output"},
+		{name: "max length", order: outOrder{ID: "1", Note: &long}, expected: "field Note is too long"},
+		{name: "nested", order: outOrder{ID: "1", Lines: []outLine{{SKU: "a"}, {}}, ByID: map[string]outLine{"x": {SKU: "abcdefghi"}}}, expected: "field SKU is required on output; field SKU is too long"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOut(test.order)
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set(RequestIDHeader, "req-1")
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, WriteJSON(recorder, request, http.StatusOK, outOrder{ID: "1"}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"id":"1","note":null,"lines":null,"by_id":null}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	err := WriteJSON(recorder, request, http.StatusOK, outOrder{})
+	require.EqualError(t, err, "response is invalid: field ID is required on output")
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.JSONEq(t, `{"status":500,"message":"Internal Server Error","request_id":"req-1"}`, recorder.Body.String())
+}
@@ -0,0 +1,113 @@
+package reqbind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPerPage = 20
+	defaultMaxPage = 100
+)
+
+// Pagination is a ready made set of paging parameters that can be embedded in
+// a request struct. Defaults, the per page cap and the allowed sort fields are
+// configured with a pagination tag on the embedded field, e.g.
+//
+//	reqbind.Pagination `pagination:"default=25,max=50,sort=name|createdAt"`
+//
+// Limit is accepted as an alias of PerPage, after binding both hold the same
+// value. Sort is a single field name, prefixed with - for descending order.
+type Pagination struct {
+	Page    int    `json:"page" optional:"true"`
+	PerPage int    `json:"perPage" optional:"true"`
+	Limit   int    `json:"limit" optional:"true"`
+	Cursor  string `json:"cursor" optional:"true"`
+	Sort    string `json:"sort" optional:"true"`
+}
+
+// Offset is the number of records to skip for the current page
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// SortField returns the sort field without its direction prefix
+func (p Pagination) SortField() string {
+	return strings.TrimPrefix(p.Sort, "-")
+}
+
+// SortDesc reports whether the sort field was prefixed with -
+func (p Pagination) SortDesc() bool {
+	return strings.HasPrefix(p.Sort, "-")
+}
+
+type paginationOptions struct {
+	perPage int
+	max     int
+	sort    []string
+}
+
+func parsePaginationTag(tag string) (paginationOptions, error) {
+	opts := paginationOptions{perPage: defaultPerPage, max: defaultMaxPage}
+	if tag == "" {
+		return opts, nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "default":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("invalid default")
+			}
+			opts.perPage = n
+		case "max":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("invalid max")
+			}
+			opts.max = n
+		case "sort":
+			opts.sort = strings.Split(value, "|")
+		default:
+			return opts, fmt.Errorf("unknown option %s", key)
+		}
+	}
+	return opts, nil
+}
+
+// normalize applies the defaults and caps and checks the sort field against
+// the allowlist. Without an allowlist no sort is accepted.
+func (p *Pagination) normalize(opts paginationOptions) error {
+	if p.Page < 0 {
+		return fmt.Errorf("page must be positive")
+	}
+	if p.Page == 0 {
+		p.Page = 1
+	}
+
+	if p.PerPage == 0 {
+		p.PerPage = p.Limit
+	}
+	if p.PerPage < 0 {
+		return fmt.Errorf("perPage must be positive")
+	}
+	if p.PerPage == 0 {
+		p.PerPage = opts.perPage
+	}
+	if p.PerPage > opts.max {
+		p.PerPage = opts.max
+	}
+	p.Limit = p.PerPage
+
+	if p.Sort == "" {
+		return nil
+	}
+	for _, allowed := range opts.sort {
+		if p.SortField() == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot sort by %s", p.SortField())
+}
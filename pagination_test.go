@@ -0,0 +1,73 @@
+package reqbind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationDefaults(t *testing.T) {
+	k := &struct {
+		Pagination
+	}{}
+
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, 1, k.Page)
+	require.Equal(t, 20, k.PerPage)
+	require.Equal(t, 20, k.Limit)
+	require.Equal(t, 0, k.Offset())
+}
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		query      string
+		expected   Pagination
+		shouldPass bool
+	}{
+		{query: "page=3&perPage=10", expected: Pagination{Page: 3, PerPage: 10, Limit: 10}, shouldPass: true},
+		{query: "limit=30", expected: Pagination{Page: 1, PerPage: 30, Limit: 30}, shouldPass: true},
+		{query: "perPage=500", expected: Pagination{Page: 1, PerPage: 50, Limit: 50}, shouldPass: true},
+		{query: "sort=-createdAt", expected: Pagination{Page: 1, PerPage: 25, Limit: 25, Sort: "-createdAt"}, shouldPass: true},
+		{query: "cursor=abc", expected: Pagination{Page: 1, PerPage: 25, Limit: 25, Cursor: "abc"}, shouldPass: true},
+		{query: "sort=password", shouldPass: false},
+		{query: "page=-1", shouldPass: false},
+		{query: "perPage=-1", shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			k := &struct {
+				Pagination `pagination:"default=25,max=50,sort=name|createdAt"`
+			}{}
+
+			request, err := http.NewRequest("GET", "/?"+test.query, nil)
+			require.NoError(t, err)
+			if !test.shouldPass {
+				require.Error(t, UnmarshalQuery(request, k))
+				return
+			}
+			require.NoError(t, UnmarshalQuery(request, k))
+			require.Equal(t, test.expected, k.Pagination)
+		})
+	}
+}
+
+func TestPaginationSortDirection(t *testing.T) {
+	p := Pagination{Sort: "-name"}
+	require.Equal(t, "name", p.SortField())
+	require.True(t, p.SortDesc())
+	require.Equal(t, 20, Pagination{Page: 3, PerPage: 10}.Offset())
+}
+
+func TestPaginationInvalidTag(t *testing.T) {
+	k := &struct {
+		Pagination `pagination:"max=lots"`
+	}{}
+
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, k))
+}
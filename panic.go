@@ -0,0 +1,15 @@
+package reqbind
+
+import "fmt"
+
+// recoverPanic turns a panic from the reflection code into an error, so a
+// struct the binder can't handle or hostile input fails the request instead
+// of the server. Use it with a named error result:
+//
+//	func (b *Binder) UnmarshalBody(r *http.Request, v interface{}) (err error) {
+//		defer recoverPanic(&err)
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("could not bind request: %v", r)
+	}
+}
@@ -0,0 +1,114 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "not a pointer", v: struct{ Name string }{}},
+		{name: "string tag on an int", v: &struct {
+			Size int `json:"size" trimlower:"true"`
+		}{}},
+		{name: "max-length on a slice", v: &struct {
+			Tags []string `json:"tags" truncate:"1"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?size=1", strings.NewReader(`{"size":1,"tags":["a"]}`))
+			require.NoError(t, err)
+			require.NotPanics(t, func() {
+				require.Error(t, Bind(request, test.v))
+			})
+		})
+	}
+}
+
+// fuzzRequest covers most of the tags the binder understands
+type fuzzRequest struct {
+	ID         string            `json:"id" validate:"uuid"`
+	Email      *string           `json:"email" trimlower:"true" validate:"email" truncate:"50"`
+	Phone      string            `json:"phone" validate:"phone"`
+	Name       string            `json:"name" required:"true" max-length:"20"`
+	Count      int               `json:"count" required:"nonzero"`
+	Active     *bool             `json:"active"`
+	Tags       []string          `json:"tags" single:"true"`
+	Labels     map[string]string `json:"labels"`
+	Payload    []byte            `json:"payload" raw:"true"`
+	Pagination `pagination:"default=10,max=20,sort=name"`
+	Sort       []SortField `sort:"name,count"`
+	Filter     []Filter    `filter:"name:eq|contains"`
+	Address    *struct {
+		Zip   string `json:"zip" required:"true"`
+		Lines []struct {
+			Text string `json:"text"`
+		} `json:"lines"`
+	} `json:"address"`
+}
+
+func FuzzUnmarshalBody(f *testing.F) {
+	f.Add(`{"name":"a","count":1,"address":{"zip":"1","lines":[{"text":"x"}]}}`)
+	f.Add(`{"email":" A@b.com","tags":["a",1],"labels":{"a":null},"payload":{"a":[1,2]}}`)
+	f.Add(`{"address":null,"page":-1,"perPage":1000}`)
+	f.Add(`[{"name":1}]`)
+	f.Fuzz(func(t *testing.T, body string) {
+		request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+		require.NoError(t, err)
+		requireNoPanic(t, UnmarshalBody(request, &fuzzRequest{}))
+	})
+}
+
+func FuzzUnmarshalQuery(f *testing.F) {
+	f.Add("name=a&count=1&address.zip=1&address.lines[0][text]=x")
+	f.Add("tags[]=a&tags[5]=b&labels[a]=b&sort=-name&filter=name:eq:a")
+	f.Add("address[lines][][text]=a&address..zip=&[]=1&page=99999999999999999999")
+	f.Fuzz(func(t *testing.T, query string) {
+		request, err := http.NewRequest("GET", "/", nil)
+		require.NoError(t, err)
+		request.URL.RawQuery = query
+		requireNoPanic(t, UnmarshalQuery(request, &fuzzRequest{}))
+		requireNoPanic(t, New(WithStrictQuery(), WithConflictPolicy(ConflictError)).Bind(request, &fuzzRequest{}))
+	})
+}
+
+// requireNoPanic fails on errors that came from a recovered panic, the
+// binder should reject bad input without ever panicking
+func requireNoPanic(t *testing.T, err error) {
+	if err != nil {
+		require.NotContains(t, err.Error(), "could not bind request")
+	}
+}
+
+// FuzzTags builds a struct at runtime so the tags themselves are fuzzed
+func FuzzTags(f *testing.F) {
+	f.Add(`json:"value" required:"true"`, `{"value":"a"}`)
+	f.Add(`json:"value" truncate:"-1" max-length:"x"`, `{"value":"aoeu"}`)
+	f.Add(`json:"value" validate:"resource-url,pattern=/a/{b}"`, `{"value":"/a/1"}`)
+	f.Add(`json:"value" pagination:"max=-1" max-span:"1"`, `{"value":1}`)
+	f.Fuzz(func(t *testing.T, tag string, body string) {
+		types := []reflect.Type{
+			reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(false),
+			reflect.TypeOf([]string{}), reflect.TypeOf(&struct{ A string }{}),
+		}
+		for _, typ := range types {
+			fields := []reflect.StructField{{Name: "Value", Type: typ, Tag: reflect.StructTag(tag)}}
+			v := reflect.New(reflect.StructOf(fields)).Interface()
+			request, err := http.NewRequest("POST", "/?"+url.Values{"value": {body}}.Encode(), strings.NewReader(body))
+			require.NoError(t, err)
+			// tags that don't fit the type are allowed to end up in
+			// recoverPanic, but they must not take the test down
+			_ = Bind(request, v)
+		}
+	})
+}
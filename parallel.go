@@ -0,0 +1,158 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelItems is when and how widely struct items are checked at once
+type parallelItems struct {
+	threshold int
+	workers   int
+}
+
+// WithParallelItems checks the struct items of slices with at least
+// threshold items on up to workers goroutines, e.g. for bulk imports.
+// Errors come back in item order whichever finishes first, so the result
+// is the same as checking them one by one. Flag evaluators and policy
+// providers may be called concurrently.
+func WithParallelItems(threshold int, workers int) Option {
+	return func(b *Binder) {
+		b.parallel = parallelItems{threshold: threshold, workers: workers}
+	}
+}
+
+// checkElements checks the struct items of a slice or array field. Item
+// errors say which item failed, e.g. "field Items item 2: field SKU is
+// required".
+func checkElements(f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil
+	}
+	elem := value.Type().Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct || value.Len() == 0 {
+		return nil
+	}
+
+	check := func(i int, opts checkOptions) (err error) {
+		defer recoverPanic(&err)
+		item := value.Index(i)
+		for item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				return nil
+			}
+			item = item.Elem()
+		}
+		if err := checkStruct(item.Addr().Interface(), opts, path+"."+strconv.Itoa(i)+"."); err != nil {
+			return itemError(f, i, err)
+		}
+		return nil
+	}
+
+	n := value.Len()
+	if opts.parallel.workers < 2 || n < opts.parallel.threshold {
+		errs := &collector{all: opts.aggregate}
+		for i := 0; i < n; i++ {
+			if errs.add(check(i, opts)) {
+				break
+			}
+		}
+		return errs.err()
+	}
+
+	// each item gets its own slot for errors and warnings so the results
+	// can be put back in order
+	results := make([]error, n)
+	var warnings []Warnings
+	if opts.warnings != nil {
+		warnings = make([]Warnings, n)
+	}
+	var next atomic.Int64
+	// without aggregating only the first failed item matters, so items after
+	// it are skipped
+	var firstFailed atomic.Int64
+	firstFailed.Store(int64(n))
+
+	workers := opts.parallel.workers
+	if workers > n {
+		workers = n
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if !opts.aggregate && int64(i) > firstFailed.Load() {
+					continue
+				}
+				itemOpts := opts
+				if warnings != nil {
+					itemOpts.warnings = &warnings[i]
+				}
+				if results[i] = check(i, itemOpts); results[i] != nil {
+					storeMin(&firstFailed, int64(i))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, itemWarnings := range warnings {
+		*opts.warnings = append(*opts.warnings, itemWarnings...)
+	}
+	errs := &collector{all: opts.aggregate}
+	for _, err := range results {
+		if errs.add(err) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+// storeMin lowers a to v when v is smaller
+func storeMin(a *atomic.Int64, v int64) {
+	for {
+		current := a.Load()
+		if v >= current || a.CompareAndSwap(current, v) {
+			return
+		}
+	}
+}
+
+// itemError says which item of f an error came from
+func itemError(f reflect.StructField, i int, err error) error {
+	if errs, ok := err.(Errors); ok {
+		wrapped := make(Errors, len(errs))
+		for j, err := range errs {
+			wrapped[j] = itemError(f, i, err)
+		}
+		return wrapped
+	}
+	return &elementError{field: f.Name, index: i, err: err}
+}
+
+// elementError is an error from an item of a list of structs
+type elementError struct {
+	field string
+	index int
+	err   error
+}
+
+func (e *elementError) Error() string {
+	return fmt.Sprintf("field %s item %d: %s", e.field, e.index, e.err)
+}
+
+func (e *elementError) Unwrap() error {
+	return e.err
+}
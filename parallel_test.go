@@ -0,0 +1,119 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type importLine struct {
+	SKU      string `json:"sku" required:"true"`
+	Quantity int    `json:"quantity" required:"true"`
+	Note     string `json:"note" max-length:"5" warn:"max-length=3"`
+}
+
+type importRequest struct {
+	Lines []importLine `json:"lines" required:"true"`
+}
+
+func importBody(n int, broken ...int) string {
+	lines := make([]map[string]interface{}, n)
+	for i := range lines {
+		lines[i] = map[string]interface{}{"sku": fmt.Sprintf("sku-%d", i), "quantity": 0}
+	}
+	for _, i := range broken {
+		delete(lines[i], "sku")
+	}
+	body, _ := json.Marshal(map[string]interface{}{"lines": lines})
+	return string(body)
+}
+
+func TestCheckItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		error string
+	}{
+		{name: "valid", body: `{"lines":[{"sku":"a","quantity":0},{"sku":"b","quantity":2}]}`},
+		{name: "missing field", body: `{"lines":[{"sku":"a","quantity":1},{"quantity":2}]}`, error: "field Lines item 1: field SKU is required"},
+		{name: "sent zero counts", body: `{"lines":[{"sku":"a","quantity":0}]}`},
+		{name: "item tags", body: `{"lines":[{"sku":"a","quantity":1,"note":"too long"}]}`, error: "field Lines item 0: field Note is too long"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = Bind(request, &importRequest{})
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// pointer items are checked, nil ones skipped
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"lines":[null,{"quantity":1}]}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &struct {
+		Lines []*importLine `json:"lines"`
+	}{}), "field Lines item 1: field SKU is required")
+}
+
+func TestParallelItems(t *testing.T) {
+	parallel := New(WithParallelItems(10, 4))
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(importBody(1000)))
+	require.NoError(t, err)
+	k := &importRequest{}
+	require.NoError(t, parallel.Bind(request, k))
+	require.Len(t, k.Lines, 1000)
+
+	// the first broken item is reported whichever worker finds it
+	for i := 0; i < 5; i++ {
+		request, err = http.NewRequest("POST", "/", strings.NewReader(importBody(1000, 999, 517, 518)))
+		require.NoError(t, err)
+		require.EqualError(t, parallel.Bind(request, &importRequest{}), "field Lines item 517: field SKU is required")
+	}
+
+	// and in aggregate mode every broken item in order
+	request, err = http.NewRequest("POST", "/", strings.NewReader(importBody(1000, 999, 3, 518)))
+	require.NoError(t, err)
+	err = New(WithParallelItems(10, 4), WithAggregateErrors()).Bind(request, &importRequest{})
+	require.EqualError(t, err, "field Lines item 3: field SKU is required; field Lines item 518: field SKU is required; field Lines item 999: field SKU is required")
+}
+
+func TestParallelItemsWarnings(t *testing.T) {
+	body := `{"lines":[{"sku":"a","quantity":1,"note":"four"},{"sku":"b","quantity":1},{"sku":"c","quantity":1,"note":"fives"}]}`
+	request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	warnings, err := New(WithParallelItems(2, 3)).BindWithWarnings(request, &importRequest{})
+	require.NoError(t, err)
+	require.Equal(t, Warnings{
+		{Path: "lines.0.note", Message: "field Note is too long"},
+		{Path: "lines.2.note", Message: "field Note is too long"},
+	}, warnings)
+}
+
+func BenchmarkParallelItems(b *testing.B) {
+	body := importBody(10000)
+	for _, workers := range []int{1, 4} {
+		binder := New(WithParallelItems(100, workers))
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := binder.Bind(request, &importRequest{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package reqbind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Peek returns one top level field of a json body without binding it, e.g.
+// the "type" of a polymorphic payload, and the body can still be bound
+// afterwards. It reads to the end of the object so a field sent twice gives
+// the last value, the one encoding/json binds. Strings come back unquoted,
+// other values as their json text, and a missing field as "".
+func Peek(r *http.Request, key string) (string, error) {
+	return defaultBinder.Peek(r, key)
+}
+
+// Peek peeks like the package level Peek, reading no more of the body than
+// the binder's WithMaxBodyBytes allows and no longer than its bind timeout
+func (b *Binder) Peek(r *http.Request, key string) (_ string, err error) {
+	defer recoverPanic(&err)
+	// the body is replaced on r itself, the timeout only needs the context
+	timed, done := b.withBindTimeout(r)
+	defer done(&err)
+	budget, ok := timed.Context().Value(bindBudgetKey{}).(time.Duration)
+	if !ok {
+		return peek(r, key, b.maxBodyBytes)
+	}
+
+	type result struct {
+		value string
+		err   error
+	}
+	peeked := make(chan result, 1)
+	go func() {
+		value, err := peek(r, key, b.maxBodyBytes)
+		peeked <- result{value: value, err: err}
+	}()
+	select {
+	case res := <-peeked:
+		return res.value, res.err
+	case <-timed.Context().Done():
+		if errors.Is(timed.Context().Err(), context.DeadlineExceeded) {
+			return "", &TimeoutError{Body: true, Budget: budget}
+		}
+		return "", timed.Context().Err()
+	}
+}
+
+// peek is Peek without the timeout, maxBytes caps what's read when it's
+// over 0
+func peek(r *http.Request, key string, maxBytes int64) (_ string, err error) {
+	if r.Body == nil {
+		return "", nil
+	}
+	if maxBytes > 0 && r.ContentLength > maxBytes {
+		return "", fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+	}
+	read := getBuffer()
+	defer func() {
+		// put back what was read in front of what wasn't
+		replay := make([]byte, read.Len())
+		copy(replay, read.Bytes())
+		putBuffer(read)
+		r.Body = peekedBody{io.MultiReader(bytes.NewReader(replay), r.Body), r.Body}
+	}()
+
+	body := io.Reader(r.Body)
+	if maxBytes > 0 {
+		// one more byte than allowed tells a body at the limit from one over
+		body = io.LimitReader(r.Body, maxBytes+1)
+	}
+	defer func() {
+		if err != nil && maxBytes > 0 && int64(read.Len()) > maxBytes {
+			err = fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+		}
+	}()
+	decoder := json.NewDecoder(io.TeeReader(body, read))
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if token != json.Delim('{') {
+		return "", fmt.Errorf("body is not a json object")
+	}
+	var found json.RawMessage
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return "", err
+		}
+		if name, _ := token.(string); strings.EqualFold(name, key) {
+			found = value
+		}
+	}
+	if maxBytes > 0 && int64(read.Len()) > maxBytes {
+		return "", fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, maxBytes)
+	}
+	if found == nil {
+		return "", nil
+	}
+	var s string
+	if json.Unmarshal(found, &s) == nil {
+		return s, nil
+	}
+	return string(found), nil
+}
+
+// peekedBody replays what Peek read before the rest of the body
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// BindAs binds the request into a new T with Bind, usually once Peek has
+// said which T the body is
+func BindAs[T any](r *http.Request) (*T, error) {
+	v := new(T)
+	if err := Bind(r, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,128 @@
+package reqbind
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cardPayment struct {
+	Type   string `json:"type"`
+	Number string `json:"number" required:"true"`
+}
+
+type bankPayment struct {
+	Type string `json:"type"`
+	IBAN string `json:"iban" required:"true"`
+}
+
+func TestPeek(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		key      string
+		expected string
+		error    string
+	}{
+		{name: "string", body: `{"amount":{"value":10,"tags":["a"]},"type":"card","number":"4242"}`, key: "type", expected: "card"},
+		{name: "case insensitive", body: `{"Type":"bank"}`, key: "type", expected: "bank"},
+		{name: "number", body: `{"version":2}`, key: "version", expected: "2"},
+		{name: "object", body: `{"meta":{"a":1}}`, key: "meta", expected: `{"a":1}`},
+		{name: "missing", body: `{"number":"4242"}`, key: "type"},
+		{name: "empty", body: ``, key: "type"},
+		{name: "not an object", body: `["card"]`, key: "type", error: "body is not a json object"},
+		{name: "broken", body: `{"type"`, key: "type", error: "unexpected EOF"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			value, err := Peek(request, test.key)
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, value)
+			}
+
+			// the whole body is still there
+			body, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			require.Equal(t, test.body, string(body))
+		})
+	}
+}
+
+// stopReader fails if it's read past the end of its data
+type stopReader struct {
+	data string
+}
+
+func (s *stopReader) Read(p []byte) (int, error) {
+	if s.data == "" {
+		return 0, errors.New("read past the peeked field")
+	}
+	n := copy(p, s.data)
+	s.data = s.data[n:]
+	return n, nil
+}
+
+func TestPeekReadsOnlyTheObject(t *testing.T) {
+	rest := &stopReader{data: `{"number":"4242"}`}
+	request, err := http.NewRequest("POST", "/", io.MultiReader(strings.NewReader(`{"type":"card","type":"bank"}`), rest))
+	require.NoError(t, err)
+	value, err := Peek(request, "type")
+	require.NoError(t, err)
+	require.Equal(t, "bank", value, "the last key wins like encoding/json")
+	require.NotEmpty(t, rest.data, "nothing after the object has been read")
+}
+
+// countingReader counts how much of a big body was read
+type countingReader struct {
+	io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestPeekMaxBodyBytes(t *testing.T) {
+	// the field comes after 20MB, a chunked body so there's no Content-Length
+	big := &countingReader{Reader: io.MultiReader(strings.NewReader(`{"padding":"`), strings.NewReader(strings.Repeat("x", 20<<20)), strings.NewReader(`","type":"card"}`))}
+	request, err := http.NewRequest("POST", "/", big)
+	require.NoError(t, err)
+	_, err = New(WithMaxBodyBytes(1024)).Peek(request, "type")
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	require.Less(t, big.read, 64<<10)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"type":"card"}`))
+	require.NoError(t, err)
+	value, err := New(WithMaxBodyBytes(15)).Peek(request, "type")
+	require.NoError(t, err)
+	require.Equal(t, "card", value)
+}
+
+func TestBindAs(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"type":"card","number":"4242"}`))
+	require.NoError(t, err)
+
+	kind, err := Peek(request, "type")
+	require.NoError(t, err)
+	require.Equal(t, "card", kind)
+	card, err := BindAs[cardPayment](request)
+	require.NoError(t, err)
+	require.Equal(t, &cardPayment{Type: "card", Number: "4242"}, card)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"type":"bank"}`))
+	require.NoError(t, err)
+	_, err = BindAs[bankPayment](request)
+	require.EqualError(t, err, "field IBAN is required")
+}
@@ -0,0 +1,145 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// MaxPhoneExtension is the most digits a phone extension can have
+const MaxPhoneExtension = 7
+
+// phoneExtensionRegex finds where the extension starts, written the way
+// english, french, german, spanish, portuguese, russian and japanese
+// speakers do
+var phoneExtensionRegex = regexp.MustCompile(`(?i)(?:ext(?:ension)?|x|#|poste|durchwahl|dw|interno|int|anexo|ramal|доб|内線)\.?\s*:?\s*`)
+
+// phoneNumber is a phone number split from its extension. number keeps a
+// leading + when the country calling code was given.
+type phoneNumber struct {
+	number       string
+	extension    string
+	hasExtension bool
+}
+
+// parsePhone cleans value up into a phone number and an extension without
+// checking either
+func parsePhone(value string) phoneNumber {
+	var p phoneNumber
+	if loc := phoneExtensionRegex.FindStringIndex(value); loc != nil && strings.ContainsAny(value[:loc[0]], "0123456789") {
+		p.hasExtension = true
+		p.extension = strings.TrimSpace(value[loc[1]:])
+		value = value[:loc[0]]
+	}
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "00") {
+		value = "+" + value[2:]
+	}
+	p.number = strings.Map(func(r rune) rune {
+		if r == '+' || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, value)
+	return p
+}
+
+// validatePhone normalizes value to its digits with an x before the
+// extension, e.g. +11234567890x12
+func validatePhone(value string) (string, error) {
+	p := parsePhone(value)
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	if p.extension != "" {
+		return p.number + "x" + p.extension, nil
+	}
+	return p.number, nil
+}
+
+func (p phoneNumber) validate() error {
+	if len(p.number) < 10 || strings.LastIndex(p.number, "+") > 0 {
+		return fmt.Errorf("invalid phone number")
+	}
+	if !p.hasExtension {
+		return nil
+	}
+	if p.extension == "" || len(p.extension) > MaxPhoneExtension || strings.Trim(p.extension, "0123456789") != "" {
+		return fmt.Errorf("invalid phone extension")
+	}
+	return nil
+}
+
+// formatPhone writes value in the format of the phone-format tag, e164
+// (+15555550100;ext=12) or national ((555) 555-0100 ext. 12). A country
+// option, e.g. phone-format:"e164,country=44", is the calling code of
+// numbers sent without one, their leading trunk 0 is dropped.
+func formatPhone(f reflect.StructField, value string) (string, error) {
+	format, options := parseValidateTag(f.Tag.Get("phone-format"))
+	if format != "e164" && format != "national" {
+		return "", fieldErrorf(CodeInvalidTag, f.Name, "has invalid phone-format")
+	}
+	country := options["country"]
+	if strings.Trim(country, "0123456789") != "" || len(country) > 3 {
+		return "", fieldErrorf(CodeInvalidTag, f.Name, "has invalid phone-format country")
+	}
+
+	p := parsePhone(value)
+	if err := p.validate(); err != nil {
+		return "", fieldErrorf(CodeInvalidPhone, f.Name, "is invalid: %s", err)
+	}
+	national := p.number
+	if strings.HasPrefix(p.number, "+") {
+		country = callingCode(p.number[1:])
+		national = p.number[1+len(country):]
+	} else if country != "" {
+		national = strings.TrimPrefix(national, "0")
+	}
+
+	if format == "e164" {
+		if country == "" {
+			return "", fieldErrorf(CodeInvalidPhone, f.Name, "is invalid: phone number has no country code")
+		}
+		if len(country)+len(national) > 15 {
+			return "", fieldErrorf(CodeInvalidPhone, f.Name, "is invalid: phone number is too long")
+		}
+		formatted := "+" + country + national
+		if p.extension != "" {
+			formatted += ";ext=" + p.extension
+		}
+		return formatted, nil
+	}
+
+	formatted := national
+	if country == "1" && len(national) == 10 {
+		formatted = fmt.Sprintf("(%s) %s-%s", national[:3], national[3:6], national[6:])
+	}
+	if p.extension != "" {
+		formatted += " ext. " + p.extension
+	}
+	return formatted, nil
+}
+
+// callingCode is the country calling code digits start with. Codes are one,
+// two or three digits long and never a prefix of each other.
+func callingCode(digits string) string {
+	switch {
+	case len(digits) < 3:
+		return ""
+	case digits[0] == '1' || digits[0] == '7':
+		return digits[:1]
+	case twoDigitCallingCodes[digits[:2]]:
+		return digits[:2]
+	}
+	return digits[:3]
+}
+
+var twoDigitCallingCodes = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49
+		51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98`) {
+		twoDigitCallingCodes[code] = true
+	}
+}
@@ -0,0 +1,103 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneExtension(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      string
+	}{
+		{value: "123-456-7890 extension 12", expected: "1234567890x12"},
+		{value: "123-456-7890 ext. 12", expected: "1234567890x12"},
+		{value: "123-456-7890 #12", expected: "1234567890x12"},
+		{value: "01 23 45 67 89 poste 12", expected: "0123456789x12"},
+		{value: "030 1234567 Durchwahl 12", expected: "0301234567x12"},
+		{value: "011 4567 8901 int. 12", expected: "01145678901x12"},
+		{value: "011 4567 8901 ramal 12", expected: "01145678901x12"},
+		{value: "495 123 45 67 доб. 12", expected: "4951234567x12"},
+		{value: "03-1234-5678 内線 12", expected: "0312345678x12"},
+		{value: "0044 20 7946 0958", expected: "+442079460958"},
+		{value: "123-456-7890 x12a", err: "field Value is invalid: invalid phone extension"},
+		{value: "123-456-7890 x12345678", err: "field Value is invalid: invalid phone extension"},
+		{value: "123-456-7890 x", err: "field Value is invalid: invalid phone extension"},
+		{value: "123+4567890", err: "field Value is invalid: invalid phone number"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			body, err := json.Marshal(map[string]string{"Value": test.value})
+			require.NoError(t, err)
+			request := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+			k := &struct {
+				Value string `validate:"phone"`
+			}{}
+			err = UnmarshalBody(request, k)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, k.Value)
+		})
+	}
+}
+
+func TestPhoneFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		value    string
+		expected string
+		err      string
+	}{
+		{format: "e164", value: "+1 (555) 555-0100", expected: "+15555550100"},
+		{format: "e164", value: "+1 555 555 0100 ext 12", expected: "+15555550100;ext=12"},
+		{format: "e164", value: "555-555-0100", err: "field Value is invalid: phone number has no country code"},
+		{format: "e164,country=1", value: "555-555-0100", expected: "+15555550100"},
+		{format: "e164,country=44", value: "020 7946 0958", expected: "+442079460958"},
+		{format: "e164", value: "+44 20 7946 0958 12345", err: "field Value is invalid: phone number is too long"},
+		{format: "national", value: "+1 555 555 0100", expected: "(555) 555-0100"},
+		{format: "national", value: "+1 555 555 0100 x12", expected: "(555) 555-0100 ext. 12"},
+		{format: "national,country=1", value: "5555550100", expected: "(555) 555-0100"},
+		{format: "national", value: "+44 20 7946 0958", expected: "2079460958"},
+		{format: "national", value: "+353 1 234 5678", expected: "12345678"},
+		{format: "national", value: "020 7946 0958", expected: "02079460958"},
+		{format: "international", value: "+1 555 555 0100", err: "field Value has invalid phone-format"},
+		{format: "e164,country=x", value: "+1 555 555 0100", err: "field Value has invalid phone-format country"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format+" "+test.value, func(t *testing.T) {
+			f := reflect.StructField{Name: "Value", Tag: reflect.StructTag(`phone-format:"` + test.format + `"`)}
+			value, err := formatPhone(f, test.value)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestPhoneFormatBind(t *testing.T) {
+	body := `{"phone":"+1 555 555 0100 ext 12"}`
+	k := &struct {
+		Phone string `json:"phone" validate:"phone" phone-format:"national"`
+	}{}
+	require.NoError(t, UnmarshalBody(httptest.NewRequest("POST", "/", bytes.NewBufferString(body)), k))
+	require.Equal(t, "(555) 555-0100 ext. 12", k.Phone)
+
+	m := map[string]interface{}{}
+	request := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+	require.NoError(t, UnmarshalBodyMap(request, m, Rules{"phone": "phone,phone-format=e164"}))
+	require.Equal(t, "+15555550100;ext=12", m["phone"])
+}
@@ -0,0 +1,221 @@
+package reqbind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Step is one of the operations on a string field. They run in pipeline
+// order after the required check.
+type Step string
+
+const (
+	// StepModifier runs the modifier tag
+	StepModifier Step = "modifier"
+	// StepTrimLower runs the trimlower tag
+	StepTrimLower Step = "trimlower"
+	// StepTruncate runs the truncate tag
+	StepTruncate Step = "truncate"
+	// StepMaxLength runs the max-length tag
+	StepMaxLength Step = "max-length"
+	// StepValidate runs the validate tag
+	StepValidate Step = "validate"
+	// StepRegexp runs the regexp tag
+	StepRegexp Step = "regexp"
+)
+
+// DefaultPipeline is the order steps run in unless the binder or the field
+// says otherwise. Values are cleaned up first, so " ABC " is trimmed before
+// it's truncated, then cut to size and then checked.
+var DefaultPipeline = []Step{StepModifier, StepTrimLower, StepTruncate, StepMaxLength, StepValidate, StepRegexp}
+
+// WithPipeline changes the order of the steps for every field. Steps that
+// aren't listed run afterwards in their DefaultPipeline order. A field can
+// set its own order with a pipeline tag, e.g. pipeline:"truncate,trimlower".
+func WithPipeline(steps ...Step) Option {
+	return func(b *Binder) {
+		b.pipeline = steps
+	}
+}
+
+// pipelineFor is the order of the steps for f, the field's pipeline tag
+// beats the binder's order
+func pipelineFor(f reflect.StructField, opts checkOptions) ([]Step, error) {
+	order := opts.pipeline
+	if tag := f.Tag.Get("pipeline"); tag != "" {
+		order = nil
+		for _, step := range strings.Split(tag, ",") {
+			order = append(order, Step(strings.TrimSpace(step)))
+		}
+	}
+	if len(order) == 0 {
+		return DefaultPipeline, nil
+	}
+
+	steps := make([]Step, 0, len(DefaultPipeline))
+	seen := make(map[Step]bool)
+	for _, step := range order {
+		if !containsStep(DefaultPipeline, step) {
+			return nil, fieldErrorf(CodeInvalidTag, f.Name, "has unknown pipeline step %s", step)
+		}
+		if !seen[step] {
+			seen[step] = true
+			steps = append(steps, step)
+		}
+	}
+	for _, step := range DefaultPipeline {
+		if !seen[step] {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
+
+func containsStep(steps []Step, step Step) bool {
+	for _, s := range steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// runPipeline runs the string steps of f against value in order
+func runPipeline(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions) error {
+	steps, err := pipelineFor(f, opts)
+	if err != nil {
+		return err
+	}
+	errs := &collector{all: !stopOnFirst(f, opts)}
+	for _, step := range steps {
+		var err error
+		switch step {
+		case StepModifier:
+			err = applyModifiers(f, value)
+		case StepTrimLower:
+			err = applyTrimLower(f, value)
+		case StepTruncate:
+			err = applyTruncate(f, value)
+		case StepMaxLength:
+			err = checkMaxLength(f, value)
+		case StepValidate:
+			err = checkValidate(parent, f, value, opts)
+		case StepRegexp:
+			err = checkRegexp(f, value)
+		}
+		if errs.add(err) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+// applyTrimLower trims and lowercases fields tagged trimlower:"true"
+func applyTrimLower(f reflect.StructField, value reflect.Value) error {
+	if f.Tag.Get("trimlower") == "true" {
+		value.SetString(strings.TrimSpace(strings.ToLower(value.String())))
+	}
+	return nil
+}
+
+// applyTruncate cuts the value down to the truncate tag
+func applyTruncate(f reflect.StructField, value reflect.Value) error {
+	if f.Tag.Get("truncate") == "" {
+		return nil
+	}
+	n, runes, err := parseLengthTag(f.Tag.Get("truncate"))
+	if err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid truncate")
+	}
+	value.SetString(truncateString(value.String(), n, runes))
+	return nil
+}
+
+// checkMaxLength checks the value against the max-length tag
+func checkMaxLength(f reflect.StructField, value reflect.Value) error {
+	if f.Tag.Get("max-length") == "" {
+		return nil
+	}
+	n, runes, err := parseLengthTag(f.Tag.Get("max-length"))
+	if err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid max-length")
+	}
+	if stringLength(value.String(), runes) > n {
+		return fieldErrorf(CodeTooLong, f.Name, "is too long")
+	}
+	return nil
+}
+
+// checkValidate runs the validate tag, the validation type (email, phone)
+// and its options
+func checkValidate(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions) error {
+	if f.Tag.Get("validate") == "" {
+		return nil
+	}
+	vType, options := parseValidateTag(f.Tag.Get("validate"))
+
+	// validate the value
+	if vType == "email" {
+		if err := validateEmail(value.String(), vType); err != nil {
+			return fieldErrorf(CodeInvalidEmail, f.Name, "is invalid: %s", err)
+		}
+		if err := opts.policy.checkEmailDomain(value.String()); err != nil {
+			return fieldErrorf(CodeInvalidEmail, f.Name, "is invalid: %s", err)
+		}
+	} else if vType == "phone" {
+		newValue, err := validatePhone(value.String())
+		if err != nil {
+			return fieldErrorf(CodeInvalidPhone, f.Name, "is invalid: %s", err)
+		}
+		if f.Tag.Get("phone-format") != "" {
+			if newValue, err = formatPhone(f, value.String()); err != nil {
+				return err
+			}
+		}
+		value.SetString(newValue)
+	} else if vType == "uuid" {
+		// leave empty values to the required check
+		if value.String() == "" {
+			return nil
+		}
+		if err := validateUUID(value.String()); err != nil {
+			return fieldErrorf(CodeInvalidUUID, f.Name, "is invalid: %s", err)
+		}
+	} else if vType == "country" {
+		if value.String() == "" {
+			return nil
+		}
+		if err := validateCountry(value.String()); err != nil {
+			return fieldErrorf(CodeInvalidCountry, f.Name, "is invalid: %s", err)
+		}
+	} else if vType == "password" {
+		if value.String() == "" {
+			return nil
+		}
+		if err := validatePassword(value.String(), opts.policy.passwordPolicy()); err != nil {
+			return fieldErrorf(CodeWeakPassword, f.Name, "is invalid: %s", err)
+		}
+	} else if vType == "slug" {
+		if value.String() == "" {
+			return nil
+		}
+		if err := validateSlug(value.String()); err != nil {
+			return fieldErrorf(CodeInvalidSlug, f.Name, "is invalid: %s", err)
+		}
+	} else if vType == "geohash" {
+		if err := checkGeohash(f, value, options); err != nil {
+			return err
+		}
+	} else if vType == "address" {
+		if err := checkAddress(parent, f, value, options, opts); err != nil {
+			return err
+		}
+	} else if vType == "resource-url" && parent.IsValid() {
+		if err := validateResourceURL(parent, value.String(), options); err != nil {
+			return fieldErrorf(CodeInvalidURL, f.Name, "is invalid: %s", err)
+		}
+	} else {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid validation type")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineDefaultOrder(t *testing.T) {
+	type code struct {
+		Code string `json:"code" trimlower:"true" truncate:"3"`
+	}
+
+	// padding is trimmed before the value is cut to size
+	k := &code{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"code":"  ABCDEF  "}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "abc", k.Code)
+}
+
+func TestPipelineTag(t *testing.T) {
+	type code struct {
+		Code string `json:"code" trimlower:"true" truncate:"3" pipeline:"truncate,trimlower"`
+	}
+
+	k := &code{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"code":"  ABCDEF  "}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "a", k.Code)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"code":"a"}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &struct {
+		Code string `json:"code" pipeline:"truncate,shout"`
+	}{}), "field Code has unknown pipeline step shout")
+}
+
+func TestWithPipeline(t *testing.T) {
+	type email struct {
+		Email string `json:"email" validate:"email" truncate:"8"`
+	}
+
+	// validating before truncating sees the whole address
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"bob@example.com"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, &email{}))
+
+	k := &email{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"email":"bob@example.com"}`))
+	require.NoError(t, err)
+	require.NoError(t, New(WithPipeline(StepValidate, StepTruncate)).UnmarshalBody(request, k))
+	require.Equal(t, "bob@exam", k.Email)
+}
@@ -0,0 +1,136 @@
+package reqbind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Policy is the validation settings of one tenant
+type Policy struct {
+	// Rules tighten the tags of fields, keyed by dotted json path like
+	// Rules.ValidateStruct, e.g. {"bio": "max-length=200"}
+	Rules Rules
+	// EmailDomains, when set, are the only domains validate:"email" accepts
+	EmailDomains []string
+	// Password is what validate:"password" fields need, the zero value is
+	// DefaultPasswordPolicy
+	Password PasswordPolicy
+}
+
+// PasswordPolicy is what a validate:"password" field needs
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy applies when there's no policy provider, or the
+// policy doesn't set one
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8}
+
+// PolicyProvider looks up the policy for a request, usually from the tenant
+// in its context
+type PolicyProvider interface {
+	Policy(ctx context.Context) Policy
+}
+
+// PolicyProviderFunc adapts a function to PolicyProvider
+type PolicyProviderFunc func(ctx context.Context) Policy
+
+// Policy calls f
+func (f PolicyProviderFunc) Policy(ctx context.Context) Policy {
+	return f(ctx)
+}
+
+// WithPolicyProvider consults provider with the request context on every
+// bind, so validation can vary per tenant
+func WithPolicyProvider(provider PolicyProvider) Option {
+	return func(b *Binder) {
+		b.policy = provider
+	}
+}
+
+// passwordPolicy is the policy's password settings, or the default
+func (p *Policy) passwordPolicy() PasswordPolicy {
+	if p == nil || p.Password == (PasswordPolicy{}) {
+		return DefaultPasswordPolicy
+	}
+	return p.Password
+}
+
+// checkEmailDomain rejects addresses outside the policy's email domains
+func (p *Policy) checkEmailDomain(email string) error {
+	if p == nil || len(p.EmailDomains) == 0 {
+		return nil
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if !containsFold(p.EmailDomains, domain) {
+		return fmt.Errorf("email domain %s is not allowed", domain)
+	}
+	return nil
+}
+
+// validatePassword checks value against the password policy
+func validatePassword(value string, policy PasswordPolicy) error {
+	if len([]rune(value)) < policy.MinLength {
+		return fmt.Errorf("password must be at least %d characters", policy.MinLength)
+	}
+	var upper, lower, digit, symbol bool
+	for _, r := range value {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	switch {
+	case policy.RequireUpper && !upper:
+		return fmt.Errorf("password needs an uppercase letter")
+	case policy.RequireLower && !lower:
+		return fmt.Errorf("password needs a lowercase letter")
+	case policy.RequireDigit && !digit:
+		return fmt.Errorf("password needs a digit")
+	case policy.RequireSymbol && !symbol:
+		return fmt.Errorf("password needs a symbol")
+	}
+	return nil
+}
+
+// checkPolicyRules runs the policy's rule for the field at path, if it has
+// one, after the field's own tags
+func checkPolicyRules(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	if opts.policy == nil {
+		return nil
+	}
+	list, ok := opts.policy.Rules[path]
+	if !ok {
+		return nil
+	}
+	tag, stringOnly, err := compileRules(path, list)
+	if err != nil {
+		return err
+	}
+	if stringOnly && indirectType(f.Type).Kind() != reflect.String {
+		return fieldErrorf(CodeInvalidType, f.Name, "must be a string")
+	}
+	rule := reflect.StructField{Name: f.Name, Type: f.Type, Tag: tag}
+	if indirectType(f.Type).Kind() == reflect.Struct {
+		// the struct's fields have already been checked
+		if isRequired(rule, checkOptions{}) && missingRequired(rule, value, opts, path) {
+			return fieldErrorf(CodeRequired, f.Name, "is required")
+		}
+		return nil
+	}
+	opts.requiredByDefault = false
+	return checkField(parent, rule, value, opts, path)
+}
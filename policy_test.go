@@ -0,0 +1,98 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+type tenantSignup struct {
+	Email    string `json:"email" validate:"email"`
+	Password string `json:"password" validate:"password"`
+	Bio      string `json:"bio" max-length:"100"`
+}
+
+func TestPolicyProvider(t *testing.T) {
+	policies := map[string]Policy{
+		"acme": {
+			Rules:        Rules{"bio": "max-length=5"},
+			EmailDomains: []string{"acme.com"},
+			Password:     PasswordPolicy{MinLength: 12, RequireDigit: true, RequireSymbol: true},
+		},
+	}
+	binder := New(WithPolicyProvider(PolicyProviderFunc(func(ctx context.Context) Policy {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return policies[tenant]
+	})))
+
+	tests := []struct {
+		name   string
+		tenant string
+		body   string
+		error  string
+	}{
+		{name: "default tenant", body: `{"email":"a@b.com","password":"password","bio":"a long bio"}`},
+		{name: "default password", body: `{"email":"a@b.com","password":"short"}`, error: "field Password is invalid: password must be at least 8 characters"},
+		{name: "acme", tenant: "acme", body: `{"email":"a@ACME.com","password":"correct-horse-1","bio":"hi"}`},
+		{name: "acme email domain", tenant: "acme", body: `{"email":"a@b.com","password":"correct-horse-1"}`, error: "field Email is invalid: email domain b.com is not allowed"},
+		{name: "acme password length", tenant: "acme", body: `{"email":"a@acme.com","password":"password"}`, error: "field Password is invalid: password must be at least 12 characters"},
+		{name: "acme password digit", tenant: "acme", body: `{"email":"a@acme.com","password":"correct-horse"}`, error: "field Password is invalid: password needs a digit"},
+		{name: "acme rules", tenant: "acme", body: `{"email":"a@acme.com","password":"correct-horse-1","bio":"a long bio"}`, error: "field Bio is too long"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			request = request.WithContext(context.WithValue(request.Context(), tenantKey{}, test.tenant))
+			err = binder.UnmarshalBody(request, &tenantSignup{})
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 4, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+	tests := []struct {
+		password string
+		error    string
+	}{
+		{password: "Ab1!"},
+		{password: "ab1!", error: "password needs an uppercase letter"},
+		{password: "AB1!", error: "password needs a lowercase letter"},
+		{password: "Abc!", error: "password needs a digit"},
+		{password: "Abc1", error: "password needs a symbol"},
+		{password: "A1!", error: "password must be at least 4 characters"},
+	}
+	for _, test := range tests {
+		err := validatePassword(test.password, strict)
+		if test.error != "" {
+			require.EqualError(t, err, test.error, test.password)
+			continue
+		}
+		require.NoError(t, err, test.password)
+	}
+}
+
+func TestOptionalPassword(t *testing.T) {
+	type account struct {
+		Name     string `json:"name"`
+		Password string `json:"password" validate:"password"`
+	}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, &account{}))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"password":"short"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, &account{}))
+}
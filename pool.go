@@ -0,0 +1,80 @@
+package reqbind
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBuffer is the largest buffer put back in the pool, so one huge
+// request doesn't pin its memory for the life of the process
+const maxPooledBuffer = 1 << 20
+
+// PoolStats counts how the pools of intermediate buffers and maps are used,
+// for tuning under sustained load
+type PoolStats struct {
+	// Gets is how many buffers and maps were taken from the pools
+	Gets uint64
+	// Misses is how many of those had to be allocated
+	Misses uint64
+	// Discards is how many were too big to put back
+	Discards uint64
+}
+
+var poolStats struct {
+	gets, misses, discards atomic.Uint64
+}
+
+// ReadPoolStats returns the pool counters since the process started
+func ReadPoolStats() PoolStats {
+	return PoolStats{
+		Gets:     poolStats.gets.Load(),
+		Misses:   poolStats.misses.Load(),
+		Discards: poolStats.discards.Load(),
+	}
+}
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		poolStats.misses.Add(1)
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	poolStats.gets.Add(1)
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		poolStats.discards.Add(1)
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+var treePool = sync.Pool{
+	New: func() interface{} {
+		poolStats.misses.Add(1)
+		return make(map[string]interface{})
+	},
+}
+
+func getTree() map[string]interface{} {
+	poolStats.gets.Add(1)
+	return treePool.Get().(map[string]interface{})
+}
+
+// putTree empties tree and puts it back, nothing may hold on to it
+func putTree(tree map[string]interface{}) {
+	if len(tree) > 1024 {
+		poolStats.discards.Add(1)
+		return
+	}
+	for key := range tree {
+		delete(tree, key)
+	}
+	treePool.Put(tree)
+}
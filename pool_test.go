@@ -0,0 +1,81 @@
+package reqbind
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPooledBodyBytes(t *testing.T) {
+	// the bytes returned must not share the pooled buffer
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"first"}`))
+	require.NoError(t, err)
+	first, err := getBodyBytes(request, 0)
+	require.NoError(t, err)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"second"}`))
+	require.NoError(t, err)
+	second, err := getBodyBytes(request, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, `{"name":"first"}`, string(first))
+	require.Equal(t, `{"name":"second"}`, string(second))
+}
+
+func TestPoolStats(t *testing.T) {
+	before := ReadPoolStats()
+	request, err := http.NewRequest("GET", "/?status=open", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, &struct {
+		Status string `json:"status"`
+	}{}))
+	after := ReadPoolStats()
+	require.GreaterOrEqual(t, after.Gets-before.Gets, uint64(2), "the tree and the buffer")
+
+	// huge buffers aren't kept
+	buf := getBuffer()
+	buf.Grow(maxPooledBuffer + 1)
+	putBuffer(buf)
+	require.Equal(t, before.Discards+1, ReadPoolStats().Discards)
+}
+
+func BenchmarkUnmarshalQuery(b *testing.B) {
+	type query struct {
+		Status string `json:"status"`
+		Page   int    `json:"page"`
+		Tags   []string
+	}
+	request, err := http.NewRequest("GET", "/?status=open&page=2&tags[]=a&tags[]=b", nil)
+	require.NoError(b, err)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := UnmarshalQuery(request, &query{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalBody(b *testing.B) {
+	body := []byte(`{"name":"a","tags":["a","b"],"address":{"city":"Portland"}}`)
+	type address struct {
+		City string `json:"city"`
+	}
+	type request struct {
+		Name    string   `json:"name" required:"true"`
+		Tags    []string `json:"tags"`
+		Address address  `json:"address"`
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := UnmarshalBody(r, &request{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
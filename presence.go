@@ -0,0 +1,224 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Presence records which fields the client actually sent, as dotted json
+// paths. Lookups ignore case the same way encoding/json does, so a key that
+// decoded into a field is always found under the field's name.
+type Presence struct {
+	// paths maps the folded paths to the paths lower cased
+	paths map[string]string
+	// aliases are the names fields were sent under, when that was one of
+	// their aliases rather than their own name
+	aliases map[string]string
+}
+
+// Has reports whether the path was sent. Parents of a sent path count as
+// sent, so address.zip implies address.
+func (p Presence) Has(path string) bool {
+	_, ok := p.paths[foldKey(path)]
+	return ok
+}
+
+// Paths returns every sent path in sorted order
+func (p Presence) Paths() []string {
+	paths := make([]string, 0, len(p.paths))
+	for _, path := range p.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// alias returns the alias a field was sent under, if it was
+func (p *Presence) alias(path string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	alias, ok := p.aliases[strings.ToLower(path)]
+	return alias, ok
+}
+
+func (p *Presence) add(path string) {
+	if p.paths == nil {
+		p.paths = make(map[string]string)
+	}
+	p.paths[foldKey(path)] = strings.ToLower(path)
+}
+
+func (p *Presence) remove(path string) {
+	delete(p.paths, foldKey(path))
+}
+
+// foldKey folds a key the way encoding/json matches keys to field names,
+// every rune becomes the smallest of its case folding set, so ſ is s and
+// the Kelvin sign is k
+func foldKey(key string) string {
+	var sb strings.Builder
+	sb.Grow(len(key))
+	for _, r := range key {
+		for {
+			folded := unicode.SimpleFold(r)
+			if folded <= r {
+				r = folded
+				break
+			}
+			r = folded
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// BindWithPresence binds the query and then the body into v, checks the
+// metadata once both are bound, and reports which fields were sent
+func BindWithPresence(r *http.Request, v interface{}) (Presence, error) {
+	return defaultBinder.BindWithPresence(r, v)
+}
+
+// BindWithPresence binds like the package level BindWithPresence
+func (b *Binder) BindWithPresence(r *http.Request, v interface{}) (_ Presence, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	aliases := map[string]string{}
+	r = aliasQuery(r, v, aliases)
+	if err := b.checkUTF8(r, v); err != nil {
+		return Presence{}, err
+	}
+	if err := b.checkQueryKeys(r.URL.Query(), v); err != nil {
+		return Presence{}, err
+	}
+	bodyBytes, err := b.readBody(r)
+	if err != nil {
+		return Presence{}, err
+	}
+	if bodyBytes, err = aliasBody(bodyBytes, v, aliases); err != nil {
+		return Presence{}, err
+	}
+	presence, err := b.bindQueryAndBody(r, bodyBytes, v)
+	if err != nil {
+		return presence, err
+	}
+	presence.aliases = aliases
+	return presence, checkStruct(v, b.checkOptions(r, &presence), "")
+}
+
+// queryPresence records every query key, items[0][sku] is recorded as
+// items, items.0 and items.0.sku
+func queryPresence(query url.Values) Presence {
+	presence := Presence{}
+	for key := range query {
+		segments := parseQueryKey(key)
+		for i := range segments {
+			if segments[i] != "" {
+				presence.add(strings.Join(segments[:i+1], "."))
+			}
+		}
+	}
+	return presence
+}
+
+// bodyPresence records every key path in a json body, objects in arrays
+// are recorded by index, e.g. items.0.sku
+func bodyPresence(bodyBytes []byte) Presence {
+	presence := Presence{}
+	addBodyPaths(bodyBytes, "", &presence)
+	return presence
+}
+
+func addBodyPaths(data []byte, prefix string, presence *Presence) {
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return
+	}
+	for key, value := range object {
+		path := prefix + key
+		presence.add(path)
+		addBodyPaths(value, path+".", presence)
+
+		var items []json.RawMessage
+		if json.Unmarshal(value, &items) != nil {
+			continue
+		}
+		for i, item := range items {
+			itemPath := path + "." + strconv.Itoa(i)
+			presence.add(itemPath)
+			addBodyPaths(item, itemPath+".", presence)
+		}
+	}
+}
+
+// bindQueryAndBody decodes the query and the already read body into v
+// without checking the metadata. Keys sent in both are resolved with the
+// conflict policy, the query counts as coming first.
+func (b *Binder) bindQueryAndBody(r *http.Request, bodyBytes []byte, v interface{}) (Presence, error) {
+	policy := b.conflicts
+	presence := queryPresence(r.URL.Query())
+	body := bodyPresence(bodyBytes)
+	if err := b.checkSingle(r.URL.Query(), &body, v); err != nil {
+		return presence, err
+	}
+
+	if policy == ConflictError {
+		for key := range r.URL.Query() {
+			if body.Has(key) {
+				return presence, paramErrorf(CodeConflict, key, "was sent in both the query and the body")
+			}
+		}
+	}
+
+	err := b.decodeInto(v, func(v interface{}) error {
+		bindBody := func() error {
+			if len(bodyBytes) == 0 {
+				return nil
+			}
+			return b.decodeBody(bodyBytes, v)
+		}
+		// whichever source should win is decoded last
+		first, second := bindBody, func() error { return bindQuery(r.URL.Query(), v, policy) }
+		if policy == ConflictLastWins {
+			first, second = second, first
+		}
+		if err := first(); err != nil {
+			return err
+		}
+		return second()
+	})
+	if err != nil {
+		return presence, err
+	}
+
+	for path := range body.paths {
+		presence.add(path)
+	}
+	return presence, nil
+}
+
+// walkJSONKeys calls fn with the dotted path of every key in a json object,
+// leaf is false for keys holding a non empty object
+func walkJSONKeys(data []byte, prefix string, fn func(path string, leaf bool)) {
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return
+	}
+	for key, value := range object {
+		path := prefix + key
+		child := map[string]json.RawMessage{}
+		if json.Unmarshal(value, &child) == nil && len(child) > 0 {
+			fn(path, false)
+			walkJSONKeys(value, path+".", fn)
+			continue
+		}
+		fn(path, true)
+	}
+}
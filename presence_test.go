@@ -0,0 +1,79 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindWithPresence(t *testing.T) {
+	k := &struct {
+		DryRun  bool
+		Name    string
+		Size    int
+		Address struct {
+			City string `json:"city"`
+			Zip  string `json:"zip"`
+		} `json:"address"`
+	}{}
+
+	request, err := http.NewRequest("PATCH", "/?dryRun=true", strings.NewReader(`{"size":0,"address":{"zip":"97201"}}`))
+	require.NoError(t, err)
+	presence, err := BindWithPresence(request, k)
+	require.NoError(t, err)
+
+	require.True(t, k.DryRun)
+	require.Equal(t, "97201", k.Address.Zip)
+	require.True(t, presence.Has("size"))
+	require.True(t, presence.Has("Size"))
+	require.True(t, presence.Has("address"))
+	require.True(t, presence.Has("address.zip"))
+	require.True(t, presence.Has("dryRun"))
+	require.False(t, presence.Has("name"))
+	require.False(t, presence.Has("address.city"))
+	require.Equal(t, []string{"address", "address.zip", "dryrun", "size"}, presence.Paths())
+}
+
+func TestBindWithPresenceChecksOnce(t *testing.T) {
+	// Name comes from the query and Size from the body, neither binder on its
+	// own would pass the required check
+	k := &struct {
+		Name string `required:"true"`
+		Size int    `required:"true"`
+	}{}
+
+	request, err := http.NewRequest("POST", "/?name=a", strings.NewReader(`{"size":3}`))
+	require.NoError(t, err)
+	_, err = BindWithPresence(request, k)
+	require.NoError(t, err)
+
+	k.Size = 0
+	request, err = http.NewRequest("POST", "/?name=a", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	_, err = BindWithPresence(request, k)
+	require.Error(t, err)
+}
+
+func TestPresenceUnicodeFolding(t *testing.T) {
+	// encoding/json matches ſ to s and the Kelvin sign to k, presence has to
+	// find those keys under the field's name too
+	k := &struct {
+		IsVerified bool   `json:"isVerified" readonly:"true"`
+		Kind       string `json:"kind" readonly:"true"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader("{\"i\u017fVerified\":true}"))
+	require.NoError(t, err)
+	presence, err := New(WithReadOnly(ReadOnlyZero)).BindWithPresence(request, k)
+	require.NoError(t, err)
+	require.True(t, presence.Has("isverified"))
+	require.False(t, k.IsVerified)
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader("{\"\u212aind\":\"admin\"}"))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, k))
+
+	require.Equal(t, foldKey("isverified"), foldKey("I\u017fVERIFIED"))
+	require.Equal(t, foldKey("kind"), foldKey("\u212aIND"))
+}
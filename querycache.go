@@ -0,0 +1,143 @@
+package reqbind
+
+import (
+	"container/list"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// uncachedQueryTags are the tags whose binding depends on more than the
+// query string
+var uncachedQueryTags = []string{"allow-roles", "flag", "versions", "clientip", "useragent", "geo", "header", "derive"}
+
+// cacheableQueryTypes caches whether a type is free of uncachedQueryTags
+var cacheableQueryTypes sync.Map
+
+// WithQueryCache caches up to size UnmarshalQuery results keyed on the
+// struct type and the raw query, so hot repeated queries skip parsing and
+// checking. A hit copies the cached struct into v, which shares any slices
+// or maps with the cache, so only use it for query structs that are
+// treated as immutable. Targets that aren't zero before binding are never
+// cached, and neither is anything when the binder has flags, a policy or
+// its own roles, or when the struct has fields filled from the request or
+// checked against the caller, e.g. header or allow-roles.
+func WithQueryCache(size int) Option {
+	return func(b *Binder) {
+		b.queryCache = nil
+		if size > 0 {
+			b.queryCache = newQueryCache(size)
+		}
+	}
+}
+
+type queryCacheKey struct {
+	t     reflect.Type
+	query string
+}
+
+type queryCacheEntry struct {
+	key   queryCacheKey
+	value reflect.Value
+}
+
+// queryCache is a least recently used cache of bound query structs
+type queryCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[queryCacheKey]*list.Element
+}
+
+func newQueryCache(size int) *queryCache {
+	return &queryCache{size: size, order: list.New(), items: make(map[queryCacheKey]*list.Element)}
+}
+
+func (c *queryCache) get(key queryCacheKey) (reflect.Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*queryCacheEntry).value, true
+}
+
+func (c *queryCache) put(key queryCacheKey, value reflect.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.items[key]; ok {
+		element.Value.(*queryCacheEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[key] = c.order.PushFront(&queryCacheEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*queryCacheEntry).key)
+	}
+}
+
+// bind binds the query into v from the cache, or with bind and caches the
+// result when it succeeds
+func (c *queryCache) bind(r *http.Request, v interface{}, bind func() error) error {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct || !rv.IsZero() {
+		return bind()
+	}
+	key := queryCacheKey{t: rv.Type(), query: r.URL.RawQuery}
+	if cached, ok := c.get(key); ok {
+		rv.Set(cached)
+		return nil
+	}
+	if err := bind(); err != nil {
+		return err
+	}
+	value := reflect.New(rv.Type()).Elem()
+	value.Set(rv)
+	c.put(key, value)
+	return nil
+}
+
+// cachesQuery reports whether binding the query into v depends on nothing
+// but the query, so the result can come from the cache
+func (b *Binder) cachesQuery(v interface{}) bool {
+	if b.flags != nil || b.policy != nil || b.customRoles {
+		return false
+	}
+	t := reflect.TypeOf(v)
+	if cached, ok := cacheableQueryTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	cacheable := !typeHasUncachedTags(t, make(map[reflect.Type]bool))
+	cacheableQueryTypes.Store(t, cacheable)
+	return cacheable
+}
+
+func typeHasUncachedTags(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHasUncachedTags(t.Elem(), visiting)
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		for _, tag := range uncachedQueryTags {
+			if f.Tag.Get(tag) != "" {
+				return true
+			}
+		}
+		if typeHasUncachedTags(f.Type, visiting) {
+			return true
+		}
+	}
+	return false
+}
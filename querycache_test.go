@@ -0,0 +1,90 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cachedSearch struct {
+	Status string `json:"status" required:"true"`
+	Limit  int    `json:"limit"`
+}
+
+func TestQueryCache(t *testing.T) {
+	binder := New(WithQueryCache(2))
+	cache := binder.queryCache
+
+	request, err := http.NewRequest("GET", "/?status=open&limit=5", nil)
+	require.NoError(t, err)
+	k := &cachedSearch{}
+	require.NoError(t, binder.UnmarshalQuery(request, k))
+	require.Equal(t, &cachedSearch{Status: "open", Limit: 5}, k)
+	require.Equal(t, 1, cache.order.Len())
+
+	// a hit comes straight from the cache without parsing
+	key := queryCacheKey{t: reflect.TypeOf(cachedSearch{}), query: "status=open&limit=5"}
+	cache.put(key, reflect.ValueOf(cachedSearch{Status: "cached"}))
+	k = &cachedSearch{}
+	require.NoError(t, binder.UnmarshalQuery(request, k))
+	require.Equal(t, &cachedSearch{Status: "cached"}, k)
+
+	// targets with values of their own aren't cached
+	k = &cachedSearch{Limit: 10}
+	require.NoError(t, binder.UnmarshalQuery(request, k))
+	require.Equal(t, &cachedSearch{Status: "open", Limit: 5}, k)
+
+	// errors aren't cached
+	request, err = http.NewRequest("GET", "/?limit=5", nil)
+	require.NoError(t, err)
+	require.Error(t, binder.UnmarshalQuery(request, &cachedSearch{}))
+	require.Equal(t, 1, cache.order.Len())
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	binder := New(WithQueryCache(2))
+	for _, query := range []string{"status=a", "status=b", "status=a", "status=c"} {
+		request, err := http.NewRequest("GET", "/?"+query, nil)
+		require.NoError(t, err)
+		require.NoError(t, binder.UnmarshalQuery(request, &cachedSearch{}))
+	}
+
+	// b was used least recently
+	typ := reflect.TypeOf(cachedSearch{})
+	_, ok := binder.queryCache.get(queryCacheKey{t: typ, query: "status=b"})
+	require.False(t, ok)
+	_, ok = binder.queryCache.get(queryCacheKey{t: typ, query: "status=a"})
+	require.True(t, ok)
+	_, ok = binder.queryCache.get(queryCacheKey{t: typ, query: "status=c"})
+	require.True(t, ok)
+}
+
+func TestQueryCacheSkipsCallerDependentBinding(t *testing.T) {
+	type adminSearch struct {
+		Status string `json:"status"`
+		All    bool   `json:"all" allow-roles:"admin"`
+	}
+	binder := New(WithQueryCache(2))
+	request, err := http.NewRequest("GET", "/?status=open&all=true", nil)
+	require.NoError(t, err)
+	admin := request.WithContext(ContextWithRoles(request.Context(), "admin"))
+	require.NoError(t, binder.UnmarshalQuery(admin, &adminSearch{}))
+	require.Error(t, binder.UnmarshalQuery(request, &adminSearch{}))
+	require.Equal(t, 0, binder.queryCache.order.Len())
+
+	type search struct {
+		Status string `json:"status"`
+	}
+	roles := func(ctx context.Context) []string { return nil }
+	for _, binder := range []*Binder{
+		New(WithQueryCache(2), WithRoles(roles)),
+		New(WithQueryCache(2), WithFlags(FlagEvaluatorFunc(func(ctx context.Context, flag string) bool { return true }), FlagReject)),
+	} {
+		require.NoError(t, binder.UnmarshalQuery(request, &search{}))
+		require.Equal(t, 0, binder.queryCache.order.Len())
+	}
+	require.NoError(t, New(WithQueryCache(2)).UnmarshalQuery(request, &search{}))
+}
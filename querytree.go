@@ -0,0 +1,194 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseQueryKey splits a key such as items[0][sku] or address.city into its
+// path segments, items[] ends with an empty segment meaning "append". A
+// malformed key is returned whole.
+func parseQueryKey(key string) []string {
+	var segments []string
+	current := strings.Builder{}
+	inBracket := false
+	for _, r := range key {
+		switch {
+		case r == '[' && !inBracket:
+			if current.Len() > 0 || len(segments) == 0 {
+				segments = append(segments, current.String())
+			}
+			current.Reset()
+			inBracket = true
+		case r == ']' && inBracket:
+			segments = append(segments, current.String())
+			current.Reset()
+			inBracket = false
+		case r == '.' && !inBracket:
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+			}
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inBracket {
+		return []string{key}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return []string{key}
+	}
+	return segments
+}
+
+// querySegments is parseQueryKey, except a key that names a field outright,
+// e.g. json:"file.name", is kept whole
+func querySegments(t reflect.Type, key string) []string {
+	if _, ok := fieldByJSONNameFold(t, key); ok {
+		return []string{key}
+	}
+	return parseQueryKey(key)
+}
+
+// queryTree turns the flat query into the nested document encoding/json
+// expects, so items[0][sku]=a binds into a slice of structs. Values are
+// coerced to suit the field at their path in t.
+func queryTree(query url.Values, t reflect.Type, skip map[string]bool, policy ConflictPolicy) (map[string]interface{}, error) {
+	tree := getTree()
+	for k, values := range query {
+		segments := querySegments(t, strings.ToLower(k))
+		if skip[segments[0]] {
+			continue
+		}
+
+		target := typeAtPath(t, segments)
+		var value interface{}
+		if segments[len(segments)-1] == "" {
+			// items[]=a&items[]=b keeps every value
+			segments = segments[:len(segments)-1]
+			if len(segments) == 0 {
+				// a bare []=a has no field to go to
+				continue
+			}
+			list := make([]interface{}, 0, len(values))
+			for _, v := range values {
+				list = append(list, coerceQueryValue(v, target))
+			}
+			value = list
+		} else {
+			picked, err := pickValue(k, values, policy)
+			if err != nil {
+				return nil, err
+			}
+			if picked == "" {
+				continue
+			}
+			value = coerceQueryValue(picked, target)
+		}
+
+		if err := setTreeValue(tree, segments, value); err != nil {
+			return nil, paramErrorf(CodeConflict, k, "%s", err)
+		}
+	}
+	// the root is always an object, even for ?0=a
+	for key, child := range tree {
+		tree[key] = listify(child)
+	}
+	return tree, nil
+}
+
+func setTreeValue(tree map[string]interface{}, segments []string, value interface{}) error {
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := tree[segment]
+		if !ok {
+			child = make(map[string]interface{})
+			tree[segment] = child
+		}
+		childTree, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("conflicts with another parameter")
+		}
+		tree = childTree
+	}
+	last := segments[len(segments)-1]
+	if _, exists := tree[last]; exists {
+		return fmt.Errorf("conflicts with another parameter")
+	}
+	tree[last] = value
+	return nil
+}
+
+// listify converts maps whose keys are all indexes into slices ordered by
+// index. Gaps are closed up rather than filled so ?items[1000000]=a can't
+// allocate a huge slice.
+func listify(node interface{}) interface{} {
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+	indexes := make([]int, 0, len(m))
+	for key, child := range m {
+		m[key] = listify(child)
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(m) == 0 || len(indexes) != len(m) {
+		return m
+	}
+	sort.Ints(indexes)
+	list := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		list[i] = m[strconv.Itoa(index)]
+	}
+	return list
+}
+
+// typeAtPath finds the type a query path binds into, or nil if it doesn't
+// lead to a known field
+func typeAtPath(t reflect.Type, segments []string) reflect.Type {
+	for _, segment := range segments {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := fieldByJSONNameFold(t, segment)
+			if !ok {
+				return nil
+			}
+			t = f.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return nil
+		}
+	}
+	return t
+}
+
+// coerceQueryValue coerces like coerceToType, except that fields which want
+// a string (or parse text themselves) get one even if it looks like a number
+func coerceQueryValue(value string, t reflect.Type) interface{} {
+	if t == nil {
+		return coerceToType(value)
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			return unescaped
+		}
+		return value
+	}
+	return coerceToType(value)
+}
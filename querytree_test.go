@@ -0,0 +1,115 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type queryTreeOrder struct {
+	Items []struct {
+		SKU string `json:"sku" required:"true"`
+		Qty int    `json:"qty"`
+	} `json:"items"`
+	Tags []string `json:"tags"`
+	Code string   `json:"code"`
+}
+
+func TestBracketedQueryKeys(t *testing.T) {
+	query := url.Values{
+		"items[0][sku]": {"a"},
+		"items[0][qty]": {"2"},
+		"items[1][sku]": {"123"},
+		"tags[]":        {"x", "y"},
+		"code":          {"007"},
+	}
+	request, err := http.NewRequest("GET", "/?"+query.Encode(), nil)
+	require.NoError(t, err)
+
+	k := &queryTreeOrder{}
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Len(t, k.Items, 2)
+	require.Equal(t, "a", k.Items[0].SKU)
+	require.Equal(t, 2, k.Items[0].Qty)
+	require.Equal(t, "123", k.Items[1].SKU)
+	require.Equal(t, []string{"x", "y"}, k.Tags)
+	require.Equal(t, "007", k.Code)
+
+	presence, err := BindWithPresence(request, &queryTreeOrder{})
+	require.NoError(t, err)
+	require.True(t, presence.Has("items.1.sku"))
+
+	_, err = New(WithStrictQuery()).BindWithPresence(request, &queryTreeOrder{})
+	require.NoError(t, err)
+}
+
+func TestBracketedQueryKeysSparse(t *testing.T) {
+	request, err := http.NewRequest("GET", "/?"+url.Values{"items[1000000][sku]": {"a"}}.Encode(), nil)
+	require.NoError(t, err)
+
+	k := &queryTreeOrder{}
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Len(t, k.Items, 1)
+}
+
+func TestBracketedQueryKeysInvalid(t *testing.T) {
+	tests := []url.Values{
+		{"items[0][qty]": {"two"}},
+		{"code": {"a"}, "code[0]": {"b"}},
+	}
+
+	for _, query := range tests {
+		t.Run(query.Encode(), func(t *testing.T) {
+			request, err := http.NewRequest("GET", "/?"+query.Encode(), nil)
+			require.NoError(t, err)
+			require.Error(t, UnmarshalQuery(request, &queryTreeOrder{}))
+		})
+	}
+}
+
+func TestDotQueryKeys(t *testing.T) {
+	type query struct {
+		Address struct {
+			City string `json:"city"`
+			Zip  string `json:"zip" required:"true"`
+		} `json:"address"`
+		Items []struct {
+			SKU string `json:"sku"`
+		} `json:"items"`
+		FileName string `json:"file.name"`
+	}
+
+	request, err := http.NewRequest("GET", "/?address.city=Portland&address.zip=97201&items[0].sku=a&file.name=x", nil)
+	require.NoError(t, err)
+	k := &query{}
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, "Portland", k.Address.City)
+	require.Equal(t, "97201", k.Address.Zip)
+	require.Equal(t, "a", k.Items[0].SKU)
+	require.Equal(t, "x", k.FileName)
+
+	// errors read the same as they do for the body
+	request, err = http.NewRequest("GET", "/?address.city=Portland", nil)
+	require.NoError(t, err)
+	queryErr := UnmarshalQuery(request, &query{})
+	require.Error(t, queryErr)
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"address":{"city":"Portland"}}`))
+	require.NoError(t, err)
+	require.Equal(t, UnmarshalBody(request, &query{}).Error(), queryErr.Error())
+
+	request, err = http.NewRequest("GET", "/?address.zip[0]=a", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, &query{}))
+}
+
+func TestParseQueryKey(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, parseQueryKey("a.b.c"))
+	require.Equal(t, []string{"items", "0", "sku"}, parseQueryKey("items[0][sku]"))
+	require.Equal(t, []string{"items", "0", "sku"}, parseQueryKey("items[0].sku"))
+	require.Equal(t, []string{"tags", ""}, parseQueryKey("tags[]"))
+	require.Equal(t, []string{"a[b"}, parseQueryKey("a[b"))
+	require.Equal(t, []string{"a"}, parseQueryKey(".a"))
+}
@@ -0,0 +1,83 @@
+package reqbind
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteRange is a single range from a Range header, e.g. bytes=0-1023.
+// End is inclusive and is -1 when the range is open ended (bytes=100-).
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// ParseByteRange parses a Range header value. Only a single bytes range is
+// supported, suffix ranges (bytes=-500) and multiple ranges are rejected.
+func ParseByteRange(value string) (ByteRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes=")
+	if !ok {
+		return ByteRange{}, fmt.Errorf("invalid range unit")
+	}
+	if strings.Contains(spec, ",") {
+		return ByteRange{}, fmt.Errorf("multiple ranges are not supported")
+	}
+
+	startStr, endStr, ok := strings.Cut(spec, "-")
+	if !ok || startStr == "" {
+		return ByteRange{}, fmt.Errorf("invalid range")
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return ByteRange{}, fmt.Errorf("invalid range start")
+	}
+	if endStr == "" {
+		return ByteRange{Start: start, End: -1}, nil
+	}
+
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil || end < start {
+		return ByteRange{}, fmt.Errorf("invalid range end")
+	}
+	return ByteRange{Start: start, End: end}, nil
+}
+
+// UnmarshalText lets a ByteRange be bound straight from a header or query value
+func (b *ByteRange) UnmarshalText(text []byte) error {
+	br, err := ParseByteRange(string(text))
+	if err != nil {
+		return err
+	}
+	*b = br
+	return nil
+}
+
+// Span is the number of bytes in the range, or -1 if it's open ended
+func (b ByteRange) Span() int64 {
+	if b.End < 0 {
+		return -1
+	}
+	return b.End - b.Start + 1
+}
+
+func (b ByteRange) String() string {
+	if b.End < 0 {
+		return fmt.Sprintf("bytes=%d-", b.Start)
+	}
+	return fmt.Sprintf("bytes=%d-%d", b.Start, b.End)
+}
+
+// limit rejects ranges wider than maxSpan. Open ended ranges are clamped to
+// maxSpan rather than rejected so bytes=0- still works for streaming clients.
+func (b *ByteRange) limit(maxSpan int64) error {
+	if b.End < 0 {
+		b.End = b.Start + maxSpan - 1
+		return nil
+	}
+	if b.Span() > maxSpan {
+		return fmt.Errorf("range is larger than %d bytes", maxSpan)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package reqbind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		value      string
+		expected   ByteRange
+		shouldPass bool
+	}{
+		{value: "bytes=0-1023", expected: ByteRange{Start: 0, End: 1023}, shouldPass: true},
+		{value: "bytes=100-", expected: ByteRange{Start: 100, End: -1}, shouldPass: true},
+		{value: "bytes=-500", shouldPass: false},
+		{value: "bytes=0-1,5-6", shouldPass: false},
+		{value: "bytes=10-5", shouldPass: false},
+		{value: "items=0-1", shouldPass: false},
+		{value: "bytes=a-b", shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			br, err := ParseByteRange(test.value)
+			if !test.shouldPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, br)
+		})
+	}
+}
+
+func TestRangeHeader(t *testing.T) {
+	k := &struct {
+		Range ByteRange `header:"Range" max-span:"1024"`
+	}{}
+
+	request, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	request.Header.Set("Range", "bytes=0-1023")
+	require.NoError(t, UnmarshalHeaders(request, k))
+	require.Equal(t, int64(1024), k.Range.Span())
+
+	request.Header.Set("Range", "bytes=0-1024")
+	require.Error(t, UnmarshalHeaders(request, k))
+
+	request.Header.Set("Range", "bytes=100-")
+	require.NoError(t, UnmarshalHeaders(request, k))
+	require.Equal(t, ByteRange{Start: 100, End: 1123}, k.Range)
+
+	p := &struct {
+		Range *ByteRange `header:"Range" max-span:"10"`
+	}{}
+	request.Header.Del("Range")
+	require.NoError(t, UnmarshalHeaders(request, p))
+	require.Nil(t, p.Range)
+}
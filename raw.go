@@ -0,0 +1,82 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var (
+	bytesType      = reflect.TypeOf([]byte{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// decodeBody unmarshals a json body into v. Top level []byte or
+// json.RawMessage fields tagged raw:"true" get the exact bytes the client
+// sent for their key rather than being decoded. With useNumber numbers in
+// interface fields are decoded as json.Number instead of float64.
+func decodeBody(bodyBytes []byte, v interface{}, useNumber bool) error {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return unmarshalFields(bodyBytes, v, useNumber)
+	}
+
+	var rawFields []int
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("raw") != "true" || isIgnored(f) {
+			continue
+		}
+		if f.Type != bytesType && f.Type != rawMessageType {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has raw but is not []byte or json.RawMessage")
+		}
+		rawFields = append(rawFields, i)
+	}
+	if len(rawFields) == 0 {
+		return unmarshalFields(bodyBytes, v, useNumber)
+	}
+
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bodyBytes, &object); err != nil {
+		return err
+	}
+	for _, i := range rawFields {
+		f := t.Field(i)
+		for key, value := range object {
+			if !strings.EqualFold(key, jsonName(f)) {
+				continue
+			}
+			raw := make([]byte, len(value))
+			copy(raw, value)
+			rv.Field(i).SetBytes(raw)
+			delete(object, key)
+		}
+	}
+
+	rest, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return unmarshalFields(rest, v, useNumber)
+}
+
+// unmarshalJSON is json.Unmarshal with the option of the decoder's UseNumber
+func unmarshalJSON(data []byte, v interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	// like json.Unmarshal, anything after the value is an error
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after the top level value")
+	}
+	return nil
+}
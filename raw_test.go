@@ -0,0 +1,40 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawCapture(t *testing.T) {
+	k := &struct {
+		Payload   []byte          `json:"payload" raw:"true" required:"true"`
+		Metadata  json.RawMessage `raw:"true"`
+		Signature string          `json:"signature" required:"true"`
+	}{}
+
+	body := `{"payload": {"b":1,  "a":[2, 3]}, "metadata":"x", "signature":"abc"}`
+	request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, `{"b":1,  "a":[2, 3]}`, string(k.Payload))
+	require.Equal(t, `"x"`, string(k.Metadata))
+	require.Equal(t, "abc", k.Signature)
+
+	k.Payload = nil
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"signature":"abc"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, k))
+}
+
+func TestRawInvalidType(t *testing.T) {
+	k := &struct {
+		Payload string `raw:"true"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"payload":{}}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, k))
+}
@@ -1,6 +1,8 @@
 package reqbind
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -17,47 +20,156 @@ import (
 // UnmarshalBody is a custom unmarshaler that will check for required fields
 // and throw an error if the field is missing
 func UnmarshalBody(r *http.Request, v interface{}) error {
-	bodyBytes, err := getBodyBytes(r)
+	return defaultBinder.UnmarshalBody(r, v)
+}
+
+// UnmarshalBody binds the json body like the package level UnmarshalBody
+func (b *Binder) UnmarshalBody(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	aliases := map[string]string{}
+	bodyBytes, err := b.readBody(r)
 	if err != nil {
 		return err
 	}
+	raw := bodyBytes
+	if bodyBytes, err = aliasBody(bodyBytes, v, aliases); err != nil {
+		return err
+	}
 
 	if len(bodyBytes) == 0 {
 		return nil
 	}
 
-	if err := json.Unmarshal(bodyBytes, v); err != nil {
+	if err := b.decodeInto(v, func(v interface{}) error {
+		return b.decodeBody(bodyBytes, v)
+	}); err != nil {
+		return err
+	}
+	if err := bindTrailers(r, raw, v); err != nil {
 		return err
 	}
 
-	return checkMetadata(v)
+	presence := bodyPresence(bodyBytes)
+	presence.aliases = aliases
+	return checkStruct(v, b.checkOptions(r, &presence), "")
 }
 
 func UnmarshalQuery(r *http.Request, v interface{}) error {
-	qMap := make(map[string]interface{})
-	for k, value := range r.URL.Query() {
-		if len(value) == 0 || value[0] == "" {
-			continue
+	return defaultBinder.UnmarshalQuery(r, v)
+}
+
+// UnmarshalQuery binds the query string like the package level UnmarshalQuery
+func (b *Binder) UnmarshalQuery(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	if b.queryCache != nil && b.cachesQuery(v) {
+		return b.queryCache.bind(r, v, func() error {
+			return b.unmarshalQuery(r, v)
+		})
+	}
+	return b.unmarshalQuery(r, v)
+}
+
+// unmarshalQuery is UnmarshalQuery without the cache
+func (b *Binder) unmarshalQuery(r *http.Request, v interface{}) error {
+	aliases := map[string]string{}
+	r = aliasQuery(r, v, aliases)
+	if err := b.checkUTF8(r, v); err != nil {
+		return err
+	}
+	if err := b.checkQueryKeys(r.URL.Query(), v); err != nil {
+		return err
+	}
+	if err := b.checkSingle(r.URL.Query(), nil, v); err != nil {
+		return err
+	}
+	if err := b.decodeInto(v, func(v interface{}) error {
+		return bindQuery(r.URL.Query(), v, b.conflicts)
+	}); err != nil {
+		return err
+	}
+
+	presence := queryPresence(r.URL.Query())
+	presence.aliases = aliases
+	return checkStruct(v, b.checkOptions(r, &presence), "")
+}
+
+// checkQueryKeys rejects unknown query parameters in strict query mode
+func (b *Binder) checkQueryKeys(query url.Values, v interface{}) error {
+	if !b.strictQuery {
+		return nil
+	}
+	t := reflect.TypeOf(v).Elem()
+	for key := range query {
+		if typeAtPath(t, querySegments(t, key)[:1]) == nil {
+			return paramErrorf(CodeNotAllowed, key, "is unknown")
 		}
-		qMap[strings.ToLower(k)] = coerceToType(value[0])
 	}
+	return nil
+}
 
-	b, err := json.Marshal(qMap)
+// bindQuery decodes the query into v without checking the metadata.
+// Repeated parameters are resolved with the conflict policy.
+func bindQuery(query url.Values, v interface{}, policy ConflictPolicy) error {
+	// sort and filter expressions are parsed separately
+	skip := make(map[string]bool)
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if isExpressionField(t.Field(i)) {
+			skip[strings.ToLower(jsonName(t.Field(i)))] = true
+		}
+	}
+
+	qMap, err := queryTree(query, t, skip, policy)
 	if err != nil {
 		return err
 	}
-	if err := json.Unmarshal(b, v); err != nil {
+	defer putTree(qMap)
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := json.NewEncoder(buf).Encode(qMap); err != nil {
 		return err
 	}
-
-	return checkMetadata(v)
+	if err := unmarshalFields(buf.Bytes(), v, false); err != nil {
+		return err
+	}
+	return bindExpressions(query, v)
 }
 
 func UnmarshalURLParams(r *http.Request, v interface{}) error {
+	return defaultBinder.UnmarshalURLParams(r, v)
+}
+
+// UnmarshalURLParams binds chi path parameters like the package level
+// UnmarshalURLParams
+func (b *Binder) UnmarshalURLParams(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
 	rctx := chi.RouteContext(r.Context())
 	if rctx == nil {
 		return fmt.Errorf("no route context")
 	}
+	if err := b.checkUTF8(r, v); err != nil {
+		return err
+	}
+	if err := bindURLParams(rctx, v); err != nil {
+		return err
+	}
+
+	return checkStruct(v, b.checkOptions(r, nil), "")
+}
+
+// bindURLParams decodes the path parameters into v without checking the
+// metadata
+func bindURLParams(rctx *chi.Context, v interface{}) error {
 	queryMap := make(map[string]string)
 
 	for i, key := range rctx.URLParams.Keys {
@@ -69,19 +181,137 @@ func UnmarshalURLParams(r *http.Request, v interface{}) error {
 		return err
 	}
 
-	if err := json.Unmarshal(j, v); err != nil {
+	return unmarshalFields(j, v, false)
+}
+
+// UnmarshalHeaders binds request headers to the fields tagged with
+// header:"Name" and then runs the same metadata checks as the other binders
+func UnmarshalHeaders(r *http.Request, v interface{}) error {
+	return defaultBinder.UnmarshalHeaders(r, v)
+}
+
+// UnmarshalHeaders binds headers like the package level UnmarshalHeaders
+func (b *Binder) UnmarshalHeaders(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	if err := b.checkUTF8(r, v); err != nil {
 		return err
 	}
+	if err := bindHeaders(r.Header, v); err != nil {
+		return err
+	}
+	if err := b.bindClientIP(r, v, nil); err != nil {
+		return err
+	}
+	if err := b.bindUserAgent(r, v, nil); err != nil {
+		return err
+	}
+	if err := bindGeo(r, v, nil); err != nil {
+		return err
+	}
+
+	return checkStruct(v, b.checkOptions(r, nil), "")
+}
 
-	return checkMetadata(v)
+// bindHeaders decodes the header tagged fields without checking the metadata
+func bindHeaders(header http.Header, v interface{}) error {
+	hMap := make(map[string]interface{})
+	headerValues(header, reflect.TypeOf(v).Elem(), hMap)
+
+	j, err := json.Marshal(hMap)
+	if err != nil {
+		return err
+	}
+	return unmarshalFields(j, v, false)
+}
+
+// headerValues adds the header tagged fields of t to hMap, including the
+// ones of embedded structs since encoding/json promotes them
+func headerValues(header http.Header, t reflect.Type, hMap map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" {
+			headerValues(header, f.Type, hMap)
+			continue
+		}
+		name := f.Tag.Get("header")
+		if name == "" || isIgnored(f) {
+			continue
+		}
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		hMap[jsonName(f)] = coerceForField(value, f.Type)
+	}
 }
 
-func getBodyBytes(r *http.Request) ([]byte, error) {
+// jsonName returns the key encoding/json will match against the field
+func jsonName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// coerceForField leaves the value as a string when the field wants a string
+// (or parses text itself), otherwise it falls back to coerceToType
+func coerceForField(value string, t reflect.Type) interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return value
+	}
+	return coerceToType(value)
+}
+
+// newBodyReader lets a body that's already been read be read again
+func newBodyReader(b []byte) io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(b))
+}
+
+var paginationType = reflect.TypeOf(Pagination{})
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// readBody reads the body and runs the request's verifier over it
+func readBody(r *http.Request, maxBytes int64) ([]byte, error) {
+	bodyBytes, err := getBodyBytes(r, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// verify signatures over the raw bytes before anything is decoded
+	if verifier := verifierFrom(r.Context()); verifier != nil {
+		if err := verifier.Verify(r.Header, bodyBytes); err != nil {
+			return nil, err
+		}
+	}
+	return bodyBytes, nil
+}
+
+// getBodyBytes reads the body, maxBytes caps it when it's over 0
+func getBodyBytes(r *http.Request, maxBytes int64) ([]byte, error) {
 	if r.Body == nil {
 		return nil, nil
 	}
+	if budget, ok := r.Context().Value(bindBudgetKey{}).(time.Duration); ok {
+		return readBodyBefore(r, budget, maxBytes)
+	}
 
-	return io.ReadAll(r.Body)
+	// read into a pooled buffer and copy out once, rather than growing a
+	// new slice for every request
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := copyBody(buf, r, maxBytes); err != nil {
+		return nil, err
+	}
+	bodyBytes := make([]byte, buf.Len())
+	copy(bodyBytes, buf.Bytes())
+	return bodyBytes, nil
 }
 
 func coerceToType(value string) interface{} {
@@ -105,137 +335,381 @@ func coerceToType(value string) interface{} {
 	}
 }
 
+// checkOptions carries per call settings down through nested structs
+type checkOptions struct {
+	// scenario limits fields tagged scenario:"a,b" to those operations
+	scenario string
+	// version limits fields tagged versions:"v1,v2" to those api versions
+	version string
+	// presence is set when the caller knows which keys were sent
+	presence *Presence
+	// readOnly decides what happens to sent readonly fields
+	readOnly ReadOnlyMode
+	// roles are the caller's roles for allow-roles fields
+	roles []string
+	// requiredByDefault makes every field required unless it's optional
+	requiredByDefault bool
+	// derivers are the binder's own functions for derive tags
+	derivers map[string]Deriver
+	// pipeline is the binder's order for the string steps
+	pipeline []Step
+	// aggregate keeps checking after a field fails
+	aggregate bool
+	// warnings collects failed warn rules when the caller wants them
+	warnings *Warnings
+	// conflicts collects the fields sent with different values when the
+	// caller wants them
+	conflicts *Conflicts
+	// policy is the tenant's policy when there's a policy provider
+	policy *Policy
+	// flagEnabled reports whether a feature flag is on for the request
+	flagEnabled func(flag string) bool
+	// flagMode decides what happens to sent fields whose flag is off
+	flagMode FlagMode
+	// parallel is when struct items are checked concurrently
+	parallel parallelItems
+	// maxDepth caps how deeply structs nest, 0 is DefaultMaxDepth
+	maxDepth int
+	// hints adds the desc tag to field errors
+	hints bool
+	// values adds the submitted value to field errors
+	values bool
+	// heartbeat is the binder's bounds for the Stream preset, zero for the
+	// defaults
+	heartbeat heartbeatBounds
+	// normalizeAddress is the binder's address normalizer with the request
+	// context, nil when there's none
+	normalizeAddress func(address string) (Address, error)
+	// ancestors are the structs being checked above this one
+	ancestors *ancestor
+}
+
 func checkMetadata(v interface{}) error {
+	return checkStruct(v, checkOptions{}, "")
+}
+
+// checkStruct checks every field of the struct v points to. prefix is the
+// dotted json path of v from the root.
+func checkStruct(v interface{}, opts checkOptions, prefix string) error {
 	// get the type of the object
 	t := reflect.TypeOf(v).Elem()
+	parent := reflect.ValueOf(v).Elem()
+
+	// a nil pointer to a struct has nothing to check
+	if !parent.IsValid() {
+		return nil
+	}
+
+	// stop on cycles and runaway nesting
+	opts, err := opts.enter(parent, prefix)
+	if err != nil {
+		return err
+	}
 
 	// iterate through the fields and check for required
+	errs := &collector{all: opts.aggregate}
+	var derived []int
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
+		errs.field = i
 
-		// if the field is required, check for the zero value
-		if f.Tag.Get("required") == "true" {
-			reflectValue := reflect.ValueOf(v).Elem()
-			// deal with : <invalid reflect.Value>
-			if reflectValue.Kind() == reflect.Invalid {
-				return fmt.Errorf("field %s is required", f.Name)
-			}
+		// unexported fields can't be bound or set, e.g. the internals of
+		// time.Time, and ignored fields are never bound
+		if isIgnored(f) {
+			continue
+		}
 
-			// get the value of the field
-			value := reflect.ValueOf(v).Elem().FieldByName(f.Name)
-			// if the value is the zero value and not a boolean
-			if value.IsZero() && f.Type.Kind() != reflect.Bool {
-				return fmt.Errorf("field %s is required", f.Name)
+		// derived fields wait until their siblings have been checked
+		if f.Tag.Get("derive") != "" {
+			derived = append(derived, i)
+			continue
+		}
+
+		// fields outside the current scenario are skipped, and rejected if sent
+		if scenario := f.Tag.Get("scenario"); opts.scenario != "" && scenario != "" && !contains(strings.Split(scenario, ","), opts.scenario) {
+			if opts.presence != nil && opts.presence.Has(prefix+jsonName(f)) && errs.add(fieldErrorf(CodeNotAllowed, f.Name, "is not allowed")) {
+				return errs.err()
 			}
-			// if it's a pointer and nil then throw an error
-			if f.Type.Kind() == reflect.Ptr && value.IsNil() {
-				return fmt.Errorf("field %s is required", f.Name)
+			continue
+		}
+
+		// fields outside the api version are skipped, and rejected if sent
+		if versions := f.Tag.Get("versions"); opts.version != "" && versions != "" && !contains(strings.Split(versions, ","), opts.version) {
+			if opts.presence != nil && opts.presence.Has(prefix+jsonName(f)) && errs.add(fieldErrorf(CodeNotAllowed, f.Name, "is not allowed in %s", opts.version)) {
+				return errs.err()
 			}
+			continue
 		}
 
-		// if the field has a truncate, check the length
-		if f.Tag.Get("truncate") != "" {
-			// get the value of the field
-			value := reflect.ValueOf(v).Elem().FieldByName(f.Name)
-			// conver the tag truncate to an int
-			if maxLengthInt, err := strconv.Atoi(f.Tag.Get("truncate")); err != nil {
-				return fmt.Errorf("field %s has invalid truncate", f.Name)
-			} else {
-				if len(value.String()) > maxLengthInt {
-					// truncate
-					value.SetString(value.String()[0:maxLengthInt])
+		// fields behind a feature flag that's off are skipped, and rejected
+		// or reset if sent
+		if flag := f.Tag.Get("flag"); flag != "" && (opts.flagEnabled == nil || !opts.flagEnabled(flag)) {
+			if opts.presence != nil && opts.presence.Has(prefix+jsonName(f)) {
+				if opts.flagMode == FlagIgnore {
+					parent.Field(i).Set(reflect.Zero(f.Type))
+				} else if errs.add(fieldErrorf(CodeNotAllowed, f.Name, "is not allowed")) {
+					return errs.err()
 				}
 			}
+			continue
 		}
 
-		// if the field has a truncate, check the length
-		if f.Tag.Get("max-length") != "" {
-			// get the value of the field
-			value := reflect.ValueOf(v).Elem().FieldByName(f.Name)
-			if maxLengthInt, err := strconv.Atoi(f.Tag.Get("max-length")); err != nil {
-				return fmt.Errorf("field %s has invalid max-length", f.Name)
-			} else {
-				if len(value.String()) > maxLengthInt {
-					return fmt.Errorf("field %s is too long", f.Name)
-				}
+		// server managed fields can't be set by the client
+		if f.Tag.Get("readonly") == "true" && opts.presence != nil && opts.presence.Has(prefix+jsonName(f)) {
+			if opts.readOnly == ReadOnlyZero {
+				parent.Field(i).Set(reflect.Zero(f.Type))
+				continue
+			}
+			if errs.add(fieldErrorf(CodeReadOnly, f.Name, "is read only")) {
+				return errs.err()
 			}
+			continue
 		}
 
-		// if the field has a trimlower, trim and lowercase
-		if f.Tag.Get("trimlower") == "true" {
-			// get the value of the field
-			value := reflect.ValueOf(v).Elem().FieldByName(f.Name)
-			// trim and lowercase
-			value.SetString(strings.TrimSpace(strings.ToLower(value.String())))
+		// privileged fields can only be set by callers with one of the roles
+		if allowed := f.Tag.Get("allow-roles"); allowed != "" && opts.presence != nil && opts.presence.Has(prefix+jsonName(f)) && !hasAnyRole(opts.roles, allowed) {
+			if errs.add(&FieldError{Field: f.Name, Code: CodeForbidden, subject: "field", err: fmt.Errorf("%w: field %s can not be set", ErrForbidden, f.Name)}) {
+				return errs.err()
+			}
+			continue
 		}
 
-		// if the field has a validate, get the validation type (email, phone) and validate
-		if f.Tag.Get("validate") != "" {
-			vType := f.Tag.Get("validate")
+		// fields that weren't sent can default to a sibling
+		if err := applyDefaultFrom(parent, f, parent.Field(i), opts, prefix+jsonName(f)); err != nil {
+			if errs.add(err) {
+				return errs.err()
+			}
+			continue
+		}
 
-			// get the value of the field
-			value := reflect.ValueOf(v).Elem().FieldByName(f.Name)
+		if errs.add(withHint(withValue(checkField(parent, f, parent.Field(i), opts, prefix+jsonName(f)), f, parent.Field(i), opts), f, opts)) {
+			return errs.err()
+		}
+		if errs.add(checkPolicyRules(parent, f, parent.Field(i), opts, prefix+jsonName(f))) {
+			return errs.err()
+		}
+		if errs.add(checkDeprecated(f, opts, prefix+jsonName(f))) {
+			return errs.err()
+		}
+		if errs.add(checkWarnings(parent, f, parent.Field(i), opts, prefix+jsonName(f))) {
+			return errs.err()
+		}
+	}
 
-			// validate the value
-			if vType == "email" {
-				if err := validateEmail(value.String(), vType); err != nil {
-					return fmt.Errorf("field %s is invalid: %s", f.Name, err)
-				}
-			} else if vType == "phone" {
-				if newValue, err := validatePhone(value.String()); err != nil {
-					return fmt.Errorf("field %s is invalid: %s", f.Name, err)
-				} else {
-					value.SetString(newValue)
-				}
-			} else {
-				return fmt.Errorf("field %s has invalid validation type", f.Name)
+	for _, i := range derived {
+		f := t.Field(i)
+		errs.field = i
+		if err := deriveField(parent, f, parent.Field(i), opts); err != nil {
+			if errs.add(err) {
+				return errs.err()
 			}
+			continue
+		}
+		if errs.add(withHint(withValue(checkField(parent, f, parent.Field(i), opts, prefix+jsonName(f)), f, parent.Field(i), opts), f, opts)) {
+			return errs.err()
+		}
+	}
+	return errs.err()
+}
+
+// checkField runs the tags of a single field against its value. parent is
+// the struct the field belongs to, value must be settable and path is the
+// field's dotted json path.
+func checkField(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	if isRequired(f, opts) && missingRequired(f, value, opts, path) {
+		return fieldErrorf(CodeRequired, f.Name, "is required")
+	}
 
+	// an unsent pointer has nothing left to check, not even the required
+	// fields of the struct it points to. A sent one is checked like the value
+	// it points to.
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
 		}
+		value = value.Elem()
+	}
 
-		// if this is a nested pointer to a struct, then call checkMetadata on the nested struct
-		if f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct {
-			if err := checkMetadata(reflect.ValueOf(v).Elem().FieldByName(f.Name).Interface()); err != nil {
-				return err
-			}
+	// an interface holds whatever was decoded, see checkInterface
+	if value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
 		}
+		return checkInterface(parent, f, value, opts, path)
+	}
+
+	// a field tagged stop-on-first:"false" reports every failed check in
+	// aggregate mode, otherwise the first one
+	errs := &collector{all: !stopOnFirst(f, opts)}
 
-		// if it's a nested struct then call checkMetadata on the nested struct,
-		if f.Type.Kind() == reflect.Struct {
-			if err := checkMetadata(reflect.ValueOf(v).Elem().FieldByName(f.Name).Addr().Interface()); err != nil {
-				return err
+	// a union such as OneOf2 is checked as whichever variant was bound
+	if value.CanAddr() {
+		if u, ok := value.Addr().Interface().(union); ok {
+			bound := u.variant()
+			if !bound.IsValid() {
+				return nil
+			}
+			// the field's tags are for a string variant, the others are
+			// checked with their own
+			if bound.Kind() != reflect.String {
+				f.Tag = ""
 			}
+			f.Type = bound.Type()
+			return checkField(parent, f, bound, opts, path)
 		}
+	}
+
+	// run the string steps in the field's pipeline order
+	if errs.add(runPipeline(parent, f, value, opts)) {
+		return errs.err()
+	}
 
+	// if the field has max-items, min-items or unique, check the items
+	if errs.add(checkItems(f, value)) || errs.add(checkUnique(f, value)) {
+		return errs.err()
+	}
+
+	// check the struct items of a slice
+	if errs.add(checkElements(f, value, opts, path)) {
+		return errs.err()
+	}
+
+	// if the field has max-keys, keys or values, check the map
+	if errs.add(checkMap(f, value)) {
+		return errs.err()
+	}
+
+	// if the field has a max-span, check the byte range isn't too big
+	if f.Tag.Get("max-span") != "" {
+		maxSpan, err := strconv.ParseInt(f.Tag.Get("max-span"), 10, 64)
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid max-span")
+		}
+		br, ok := value.Addr().Interface().(*ByteRange)
+		if !ok {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has max-span but is not a ByteRange")
+		}
+		if err := br.limit(maxSpan); err != nil && errs.add(fieldErrorf(CodeOutOfRange, f.Name, "is invalid: %s", err)) {
+			return errs.err()
+		}
+	}
+
+	// if this is the pagination preset, apply its defaults and caps
+	if value.Type() == paginationType {
+		opts, err := parsePaginationTag(f.Tag.Get("pagination"))
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid pagination: %s", err)
+		}
+		if err := value.Addr().Interface().(*Pagination).normalize(opts); err != nil && errs.add(fieldErrorf(CodeOutOfRange, f.Name, "is invalid: %s", err)) {
+			return errs.err()
+		}
+	}
+
+	// if this is the stream preset, parse the heartbeat and clamp it
+	if value.Type() == streamType {
+		bounds, err := parseStreamTag(f.Tag.Get("stream"), opts.heartbeat)
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid stream: %s", err)
+		}
+		if err := value.Addr().Interface().(*Stream).normalize(bounds); err != nil && errs.add(fieldErrorf(CodeInvalidFormat, f.Name, "is invalid: %s", err)) {
+			return errs.err()
+		}
+	}
+
+	// if it's a nested struct, or a pointer to one, then check the nested struct
+	if value.Kind() == reflect.Struct {
+		if errs.add(checkStruct(value.Addr().Interface(), opts, nestedPrefix(f, path))) {
+			return errs.err()
+		}
+	}
+
+	return errs.err()
+}
+
+// isRequired is true for fields tagged required:"true", and in required by
+// default mode for every field not tagged optional:"true" or json:"-"
+func isRequired(f reflect.StructField, opts checkOptions) bool {
+	if required := f.Tag.Get("required"); required == "true" || required == "nonzero" {
+		return true
+	}
+	return opts.requiredByDefault && f.Tag.Get("optional") != "true" && f.Tag.Get("json") != "-"
+}
+
+// checkInterface runs the string tags of an interface field against the
+// string form of its value, e.g. 42 for a json number. Changes such as
+// trimlower are only written back when the field holds a string.
+func checkInterface(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	inner := value.Elem()
+	str := reflect.New(reflect.TypeOf("")).Elem()
+	str.SetString(interfaceString(inner.Interface()))
+	f.Type = str.Type()
+	if err := checkField(parent, f, str, opts, path); err != nil {
+		return err
+	}
+	if inner.Kind() == reflect.String && inner.String() != str.String() {
+		value.Set(str.Convert(inner.Type()))
 	}
 	return nil
 }
 
-func validatePhone(value string) (string, error) {
-	// replace all the spaces with nothing.
-	// replace any alpha characters with nothing except x
-	// if the length is not 10 or greater, return an error
+// interfaceString formats a decoded value the way it would look in a query
+func interfaceString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
 
-	newValue := strings.ReplaceAll(value, " ", "")
-	newValue = strings.ReplaceAll(newValue, "(", "")
-	newValue = strings.ReplaceAll(newValue, ")", "")
-	newValue = strings.ReplaceAll(newValue, "-", "")
-	newValue = strings.Map(func(r rune) rune {
-		if r == 'x' || r == '+' || (r >= '0' && r <= '9') {
-			return r
-		}
-		return -1
-	}, newValue)
+// missingRequired reports whether a required field wasn't sent. When the
+// caller knows which keys were sent, required:"true" only needs the key, so
+// 0 and "" are fine, a value bound from somewhere else counts as well.
+// Without presence, and for required:"nonzero", the value can't be zero.
+// Nil pointers are always missing.
+func missingRequired(f reflect.StructField, value reflect.Value, opts checkOptions, path string) bool {
+	if (f.Type.Kind() == reflect.Ptr || f.Type.Kind() == reflect.Interface) && value.IsNil() {
+		return true
+	}
+	nonZero := f.Tag.Get("required") == "nonzero"
+	if !nonZero && opts.presence != nil && opts.presence.Has(path) {
+		return false
+	}
+	// without presence an unsent bool and false look the same, use *bool
+	// to tell them apart
+	if !nonZero && opts.presence == nil && f.Type.Kind() == reflect.Bool {
+		return false
+	}
+	return value.IsZero()
+}
 
-	if len(newValue) < 10 {
-		return "", fmt.Errorf("invalid phone number")
+// nestedPrefix is the path prefix for the fields of a nested struct.
+// Embedded structs without a json name are flattened by encoding/json so
+// their fields keep the parent's prefix.
+func nestedPrefix(f reflect.StructField, path string) string {
+	if f.Anonymous && f.Tag.Get("json") == "" {
+		return strings.TrimSuffix(path, f.Name)
 	}
+	return path + "."
+}
 
-	return newValue, nil
+// parseValidateTag splits validate:"type,key=value,..." into the validation
+// type and its options
+func parseValidateTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	options := make(map[string]string)
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+		options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(parts[0]), options
 }
 
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
 func validateEmail(value string, validationType string) error {
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	switch validationType {
 	case "email":
 		if !emailRegex.MatchString(value) {
@@ -244,3 +718,23 @@ func validateEmail(value string, validationType string) error {
 	}
 	return nil
 }
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func validateUUID(value string) error {
+	if !uuidRegex.MatchString(value) {
+		return fmt.Errorf("invalid uuid")
+	}
+	return nil
+}
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// validateSlug accepts lowercase letters and digits separated by single
+// hyphens, e.g. my-project-2
+func validateSlug(value string) error {
+	if !slugRegex.MatchString(value) {
+		return fmt.Errorf("invalid slug")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -43,6 +44,206 @@ func TestNestedStruct(t *testing.T) {
 	require.NoError(t, UnmarshalBody(request, b2))
 }
 
+func TestRequiredPresence(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		shouldPass bool
+	}{
+		{name: "zero values sent in the body", body: `{"count":0,"name":""}`, shouldPass: true},
+		{name: "zero values sent in the query", query: "count=0&name=", shouldPass: true},
+		{name: "count missing", body: `{"name":""}`, shouldPass: false},
+		{name: "name missing", query: "count=0", shouldPass: false},
+		{name: "nothing sent", body: `{}`, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?"+test.query, strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &struct {
+				Count int    `json:"count" required:"true"`
+				Name  string `json:"name" required:"true"`
+			}{}
+			err = Bind(request, k)
+			if test.shouldPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRequiredNonZero(t *testing.T) {
+	k := &struct {
+		Count int `json:"count" required:"nonzero"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"count":0}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, k))
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"count":3}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+
+	// without presence, e.g. checking a struct directly, required falls back
+	// to the zero value check
+	require.Error(t, checkMetadata(&struct {
+		Count int `required:"true"`
+	}{}))
+}
+
+func TestRequiredBool(t *testing.T) {
+	tests := []struct {
+		body       string
+		shouldPass bool
+	}{
+		{body: `{"active":false,"notify":false}`, shouldPass: true},
+		{body: `{"active":true,"notify":true}`, shouldPass: true},
+		{body: `{"notify":false}`, shouldPass: false},
+		{body: `{"active":false}`, shouldPass: false},
+		{body: `{"active":false,"notify":null}`, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &struct {
+				Active bool  `json:"active" required:"true"`
+				Notify *bool `json:"notify" required:"true"`
+			}{}
+			err = UnmarshalBody(request, k)
+			if test.shouldPass {
+				require.NoError(t, err)
+				require.NotNil(t, k.Notify)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// the query works the same way
+	request, err := http.NewRequest("GET", "/?active=false", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, &struct {
+		Active bool `json:"active" required:"true"`
+	}{}))
+	request, err = http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, &struct {
+		Active bool `json:"active" required:"true"`
+	}{}))
+}
+
+func TestNestedPointerStruct(t *testing.T) {
+	type address struct {
+		Zip string `json:"zip" required:"true"`
+		Geo *struct {
+			Lat *float64 `json:"lat" required:"true"`
+		} `json:"geo"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		shouldPass bool
+	}{
+		{name: "optional parent missing", body: `{"billing":{"zip":"1"}}`, shouldPass: true},
+		{name: "optional parent null", body: `{"shipping":null,"billing":{"zip":"1"}}`, shouldPass: true},
+		{name: "optional parent sent without its required child", body: `{"shipping":{},"billing":{"zip":"1"}}`, shouldPass: false},
+		{name: "required parent missing", body: `{}`, shouldPass: false},
+		{name: "required parent null", body: `{"billing":null}`, shouldPass: false},
+		{name: "required parent sent without its required child", body: `{"billing":{}}`, shouldPass: false},
+		{name: "grandchild checked when its parent is sent", body: `{"billing":{"zip":"1","geo":{}}}`, shouldPass: false},
+		{name: "grandchild sent", body: `{"billing":{"zip":"1","geo":{"lat":0}}}`, shouldPass: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &struct {
+				Shipping *address `json:"shipping"`
+				Billing  *address `json:"billing" required:"true"`
+			}{}
+			err = UnmarshalBody(request, k)
+			if test.shouldPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPointerTags(t *testing.T) {
+	k := &struct {
+		Email *string `json:"email" trimlower:"true" validate:"email"`
+	}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":" AOEU@aoeu.com"}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "aoeu@aoeu.com", *k.Email)
+
+	k.Email = nil
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Nil(t, k.Email)
+}
+
+func TestInterfaceFields(t *testing.T) {
+	type interfaceRequest struct {
+		Value interface{} `json:"value" required:"true"`
+		Email interface{} `json:"email" trimlower:"true" validate:"email"`
+		Code  interface{} `json:"code" max-length:"3"`
+	}
+
+	// the body keeps the decoded json value
+	k := &interfaceRequest{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"value":{"a":[1,"b"]},"email":" AOEU@aoeu.com","code":123}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, map[string]interface{}{"a": []interface{}{float64(1), "b"}}, k.Value)
+	require.Equal(t, "aoeu@aoeu.com", k.Email)
+	require.Equal(t, float64(123), k.Code)
+
+	// the query keeps the coerced value
+	k = &interfaceRequest{}
+	request, err = http.NewRequest("GET", "/?value=true&code=abc", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, true, k.Value)
+	require.Equal(t, "abc", k.Code)
+
+	tests := []struct {
+		body       string
+		shouldPass bool
+	}{
+		{body: `{"value":0}`, shouldPass: true},
+		{body: `{"value":""}`, shouldPass: true},
+		{body: `{"value":null}`, shouldPass: false},
+		{body: `{}`, shouldPass: false},
+		{body: `{"value":1,"code":1234}`, shouldPass: false},
+		{body: `{"value":1,"email":7}`, shouldPass: false},
+	}
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = UnmarshalBody(request, &interfaceRequest{})
+			if test.shouldPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestBadBody(t *testing.T) {
 	k := &struct {
 		Value string `required:"true"`
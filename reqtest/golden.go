@@ -0,0 +1,86 @@
+package reqtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codeallthethingz/reqbind"
+)
+
+// UpdateEnv is the environment variable that rewrites golden files instead
+// of comparing against them, e.g. REQTEST_UPDATE=1 go test ./...
+const UpdateEnv = "REQTEST_UPDATE"
+
+// snapshot is what a golden file holds for one fixture
+type snapshot struct {
+	Error string      `json:"error,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// Golden binds every *.json fixture in dir as the body of a POST into a new
+// T and compares the bound value and error with the fixture's .golden file
+// next to it. Missing golden files are written, so the first run locks in
+// the current behavior.
+func Golden[T any](t *testing.T, dir string) {
+	t.Helper()
+	GoldenWith[T](t, dir, reqbind.New())
+}
+
+// GoldenWith is Golden binding with binder
+func GoldenWith[T any](t *testing.T, dir string, binder *reqbind.Binder) {
+	t.Helper()
+	fixtures, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("reqtest: listing fixtures: %s", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("reqtest: no fixtures in %s", dir)
+	}
+	for _, fixture := range fixtures {
+		fixture := fixture
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			body, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatalf("reqtest: reading fixture: %s", err)
+			}
+			v := new(T)
+			s := snapshot{Value: v}
+			if err := binder.Bind(JSON(t, "POST", "/", body).Build(), v); err != nil {
+				s.Error = err.Error()
+			}
+			got, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				t.Fatalf("reqtest: encoding snapshot: %s", err)
+			}
+			got = append(got, '\n')
+
+			if err := checkGolden(strings.TrimSuffix(fixture, ".json")+".golden", got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// checkGolden compares got with the golden file, writing it when it's
+// missing or being updated
+func checkGolden(golden string, got []byte) error {
+	want, err := os.ReadFile(golden)
+	if os.IsNotExist(err) || os.Getenv(UpdateEnv) != "" {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			return fmt.Errorf("reqtest: writing golden file: %s", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reqtest: reading golden file: %s", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("reqtest: %s doesn't match, run with %s=1 to update\ngot:\n%s\nwant:\n%s", golden, UpdateEnv, got, want)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package reqtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type signup struct {
+	Name  string `json:"name" required:"true"`
+	Email string `json:"email" validate:"email"`
+	Age   int    `json:"age"`
+}
+
+func TestGolden(t *testing.T) {
+	Golden[signup](t, "testdata/golden")
+
+	golden, err := os.ReadFile("testdata/golden/invalid.golden")
+	require.NoError(t, err)
+	require.Contains(t, string(golden), `"error": "field Name is required"`)
+}
+
+func TestCheckGolden(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "a.golden")
+
+	// a missing golden file is written
+	require.NoError(t, checkGolden(golden, []byte("{}\n")))
+	require.NoError(t, checkGolden(golden, []byte("{}\n")))
+	require.Error(t, checkGolden(golden, []byte("[]\n")))
+
+	t.Setenv(UpdateEnv, "1")
+	require.NoError(t, checkGolden(golden, []byte("[]\n")))
+	written, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, "[]\n", string(written))
+}
@@ -0,0 +1,142 @@
+// Package reqtest builds requests for unit testing handlers that bind with
+// reqbind, without going through a router
+package reqtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Builder fabricates one request, the methods can be chained and Build
+// returns the request
+type Builder struct {
+	t       testing.TB
+	method  string
+	path    string
+	body    []byte
+	query   url.Values
+	keys    []string
+	values  []string
+	header  http.Header
+	ctx     context.Context
+	pattern string
+}
+
+// New starts a request without a body
+func New(t testing.TB, method string, path string) *Builder {
+	return &Builder{t: t, method: method, path: path, query: url.Values{}, header: http.Header{}}
+}
+
+// JSON starts a request with body encoded as json. A string or []byte body
+// is sent as it is, which is handy for malformed json.
+func JSON(t testing.TB, method string, path string, body interface{}) *Builder {
+	t.Helper()
+	b := New(t, method, path)
+	switch body := body.(type) {
+	case string:
+		b.body = []byte(body)
+	case []byte:
+		b.body = body
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("reqtest: encoding body: %s", err)
+		}
+		b.body = encoded
+	}
+	return b.Header("Content-Type", "application/json")
+}
+
+// Form starts a request with values as a url encoded form body
+func Form(t testing.TB, method string, path string, values url.Values) *Builder {
+	b := New(t, method, path)
+	b.body = []byte(values.Encode())
+	return b.Header("Content-Type", "application/x-www-form-urlencoded")
+}
+
+// Query adds a query parameter, on top of any already in the path
+func (b *Builder) Query(key string, value string) *Builder {
+	b.query.Add(key, value)
+	return b
+}
+
+// PathParam sets a path parameter on the chi route context
+func (b *Builder) PathParam(key string, value string) *Builder {
+	for i, k := range b.keys {
+		if k == key {
+			b.values[i] = value
+			return b
+		}
+	}
+	b.keys = append(b.keys, key)
+	b.values = append(b.values, value)
+	return b
+}
+
+// RoutePattern sets the route pattern on the chi route context, e.g.
+// /users/{id}
+func (b *Builder) RoutePattern(pattern string) *Builder {
+	b.pattern = pattern
+	return b
+}
+
+// Header adds a request header
+func (b *Builder) Header(key string, value string) *Builder {
+	b.header.Add(key, value)
+	return b
+}
+
+// Context sets the context the request is built with, e.g. one holding the
+// caller's roles
+func (b *Builder) Context(ctx context.Context) *Builder {
+	b.ctx = ctx
+	return b
+}
+
+// Build returns the request. A chi route context is only added when there
+// are path parameters or a route pattern.
+func (b *Builder) Build() *http.Request {
+	b.t.Helper()
+	u, err := url.Parse(b.path)
+	if err != nil {
+		b.t.Fatalf("reqtest: parsing path: %s", err)
+	}
+	query := u.Query()
+	for key, values := range b.query {
+		query[key] = append(query[key], values...)
+	}
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if b.body != nil {
+		body = bytes.NewReader(b.body)
+	}
+	r := httptest.NewRequest(b.method, u.RequestURI(), body)
+	for key, values := range b.header {
+		r.Header[key] = append([]string(nil), values...)
+	}
+
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = r.Context()
+	}
+	if len(b.keys) > 0 || b.pattern != "" {
+		rctx := chi.NewRouteContext()
+		for i, key := range b.keys {
+			rctx.URLParams.Add(key, b.values[i])
+		}
+		if b.pattern != "" {
+			rctx.RoutePatterns = []string{b.pattern}
+		}
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return r.WithContext(ctx)
+}
@@ -0,0 +1,56 @@
+package reqtest
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type update struct {
+	ID     string `json:"id" required:"true"`
+	Page   int    `json:"page"`
+	Name   string `json:"name" required:"true"`
+	Tenant string `json:"tenant" header:"X-Tenant"`
+}
+
+func TestBuild(t *testing.T) {
+	r := JSON(t, "PUT", "/users/42?page=1", map[string]string{"name": "Ada"}).
+		Query("page", "2").
+		PathParam("id", "7").
+		PathParam("id", "42").
+		Header("X-Tenant", "acme").
+		RoutePattern("/users/{id}").
+		Build()
+
+	require.Equal(t, "PUT", r.Method)
+	require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	require.Equal(t, []string{"1", "2"}, r.URL.Query()["page"])
+	require.Equal(t, "42", chi.URLParam(r, "id"))
+	require.Equal(t, "/users/{id}", chi.RouteContext(r.Context()).RoutePattern())
+
+	k := &update{}
+	require.NoError(t, reqbind.Bind(r, k))
+	require.Equal(t, &update{ID: "42", Page: 1, Name: "Ada", Tenant: "acme"}, k)
+}
+
+func TestBuildBodies(t *testing.T) {
+	r := JSON(t, "POST", "/", `{"name":`).Build()
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":`, string(body))
+
+	r = Form(t, "POST", "/", url.Values{"name": {"Ada"}}).Build()
+	require.NoError(t, r.ParseForm())
+	require.Equal(t, "Ada", r.PostForm.Get("name"))
+
+	// no path parameters means no route context
+	type key struct{}
+	r = New(t, "GET", "/").Context(context.WithValue(context.Background(), key{}, "v")).Build()
+	require.Nil(t, chi.RouteContext(r.Context()))
+	require.Equal(t, "v", r.Context().Value(key{}))
+}
@@ -0,0 +1,55 @@
+package reqbind
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header a request's correlation id is read from and
+// echoed in
+const RequestIDHeader = "X-Request-Id"
+
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID returns the request's correlation id. It's the id Handler gave
+// the request, or else the X-Request-Id header, or else a new random id. A
+// header that's too long or has characters other than visible ASCII is
+// replaced with a new id so it can't inject into logs.
+func RequestID(r *http.Request) string {
+	if id, ok := r.Context().Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	if id := r.Header.Get(RequestIDHeader); validRequestID(id) {
+		return id
+	}
+	return newRequestID()
+}
+
+// withRequestID returns a copy of r whose context carries its id, so the
+// handler, the error hook and WriteError all see the same one
+func withRequestID(r *http.Request) (*http.Request, string) {
+	id := RequestID(r)
+	return r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)), id
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
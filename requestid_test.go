@@ -0,0 +1,61 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		kept   bool
+	}{
+		{name: "sent", header: "abc-123", kept: true},
+		{name: "missing", header: ""},
+		{name: "newline", header: "abc\nforged log line"},
+		{name: "too long", header: strings.Repeat("a", 129)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/", nil)
+			request.Header[RequestIDHeader] = []string{test.header}
+			id := RequestID(request)
+			if test.kept {
+				require.Equal(t, test.header, id)
+				return
+			}
+			require.Len(t, id, 32)
+		})
+	}
+}
+
+func TestRequestIDInHandler(t *testing.T) {
+	var hookID string
+	binder := New(WithErrorHook(func(r *http.Request, err error) {
+		hookID = RequestID(r)
+	}))
+	var handlerID string
+	handler := HandlerWith(binder, func(w http.ResponseWriter, r *http.Request, order *handlerOrder) error {
+		handlerID = RequestID(r)
+		return errors.New("boom")
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/", strings.NewReader(`{"sku":"a"}`)))
+	var doc ErrorDocument
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+
+	// a generated id is the same everywhere
+	require.Len(t, doc.RequestID, 32)
+	require.Equal(t, doc.RequestID, recorder.Header().Get(RequestIDHeader))
+	require.Equal(t, doc.RequestID, handlerID)
+	require.Equal(t, doc.RequestID, hookID)
+}
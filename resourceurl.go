@@ -0,0 +1,133 @@
+package reqbind
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// matchResourceURL checks the path of value against a pattern such as
+// /users/{id} and returns the placeholder values. value may be an absolute
+// http(s) URL or just a path.
+func matchResourceURL(value string, pattern string) (map[string]string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url")
+	}
+	if u.IsAbs() && u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url scheme")
+	}
+
+	pathParts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(pathParts) != len(patternParts) {
+		return nil, fmt.Errorf("url does not match %s", pattern)
+	}
+
+	params := make(map[string]string)
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return nil, fmt.Errorf("url does not match %s", pattern)
+			}
+			params[part[1:len(part)-1]] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, fmt.Errorf("url does not match %s", pattern)
+		}
+	}
+	return params, nil
+}
+
+// validateResourceURL matches the field against the pattern option and copies
+// each path parameter into the sibling field with the same name
+func validateResourceURL(parent reflect.Value, value string, options map[string]string) error {
+	pattern, ok := options["pattern"]
+	if !ok {
+		return fmt.Errorf("resource-url requires a pattern")
+	}
+	// leave empty values to the required check
+	if value == "" {
+		return nil
+	}
+	params, err := matchResourceURL(value, pattern)
+	if err != nil {
+		return err
+	}
+	for name, param := range params {
+		sibling, ok := fieldByNameFold(parent, name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(sibling, param); err != nil {
+			return fmt.Errorf("invalid %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// fieldByNameFold finds a field by its go or json name, ignoring case
+func fieldByNameFold(parent reflect.Value, name string) (reflect.Value, bool) {
+	t := parent.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if strings.EqualFold(f.Name, name) || strings.EqualFold(jsonName(f), name) {
+			return parent.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// setFromString parses s into a string, bool or numeric field, allocating
+// pointers as needed
+func setFromString(field reflect.Value, s string) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	if field.CanAddr() {
+		if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("not a boolean")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not an unsigned integer")
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not a number")
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
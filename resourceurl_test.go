@@ -0,0 +1,54 @@
+package reqbind
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceURL(t *testing.T) {
+	tests := []struct {
+		value      string
+		orgID      string
+		userID     int
+		shouldPass bool
+	}{
+		{value: "https://api.example.com/orgs/acme/users/42", orgID: "acme", userID: 42, shouldPass: true},
+		{value: "/orgs/acme/users/42/", orgID: "acme", userID: 42, shouldPass: true},
+		{value: "/orgs/acme/users/bob", shouldPass: false},
+		{value: "/orgs/acme/groups/42", shouldPass: false},
+		{value: "/orgs/acme/users", shouldPass: false},
+		{value: "ftp://example.com/orgs/acme/users/42", shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			k := &struct {
+				User   string `json:"user" validate:"resource-url,pattern=/orgs/{orgId}/users/{userId}"`
+				OrgID  string `json:"orgId"`
+				UserID int    `json:"userId"`
+			}{}
+			request, err := http.NewRequest("POST", "/", io.NopCloser(bytes.NewReader([]byte(`{"user":"`+test.value+`"}`))))
+			require.NoError(t, err)
+			if !test.shouldPass {
+				require.Error(t, UnmarshalBody(request, k))
+				return
+			}
+			require.NoError(t, UnmarshalBody(request, k))
+			require.Equal(t, test.orgID, k.OrgID)
+			require.Equal(t, test.userID, k.UserID)
+		})
+	}
+}
+
+func TestResourceURLWithoutPattern(t *testing.T) {
+	k := &struct {
+		User string `validate:"resource-url"`
+	}{}
+	request, err := http.NewRequest("GET", "/?user=/users/1", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, k))
+}
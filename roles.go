@@ -0,0 +1,36 @@
+package reqbind
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrForbidden is returned (wrapped) when the caller sets a field their
+// roles don't allow, handlers can map it to a 403
+var ErrForbidden = errors.New("forbidden")
+
+type rolesKey struct{}
+
+// ContextWithRoles stores the caller's roles for RolesFromContext, typically
+// from an auth middleware
+func ContextWithRoles(ctx context.Context, roles ...string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// RolesFromContext returns the roles stored by ContextWithRoles
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// hasAnyRole is true if one of the caller's roles is in the comma separated
+// allowed list
+func hasAnyRole(roles []string, allowed string) bool {
+	for _, role := range strings.Split(allowed, ",") {
+		if contains(roles, strings.TrimSpace(role)) {
+			return true
+		}
+	}
+	return false
+}
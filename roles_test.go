@@ -0,0 +1,53 @@
+package reqbind
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type rolesUser struct {
+	Name       string `json:"name"`
+	IsVerified bool   `json:"isVerified" allow-roles:"admin,support"`
+}
+
+func TestAllowRoles(t *testing.T) {
+	tests := []struct {
+		roles      []string
+		body       string
+		shouldPass bool
+	}{
+		{roles: nil, body: `{"name":"a"}`, shouldPass: true},
+		{roles: nil, body: `{"name":"a","isVerified":true}`, shouldPass: false},
+		{roles: []string{"user"}, body: `{"isVerified":false}`, shouldPass: false},
+		{roles: []string{"user", "support"}, body: `{"isVerified":true}`, shouldPass: true},
+		{roles: []string{"admin"}, body: `{"isVerified":true}`, shouldPass: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("PATCH", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			request = request.WithContext(ContextWithRoles(request.Context(), test.roles...))
+			if !test.shouldPass {
+				require.ErrorIs(t, UnmarshalBody(request, &rolesUser{}), ErrForbidden)
+				return
+			}
+			require.NoError(t, UnmarshalBody(request, &rolesUser{}))
+		})
+	}
+}
+
+func TestWithRoles(t *testing.T) {
+	binder := New(WithRoles(func(ctx context.Context) []string {
+		return []string{"admin"}
+	}))
+	request, err := http.NewRequest("PATCH", "/", strings.NewReader(`{"isVerified":true}`))
+	require.NoError(t, err)
+	k := &rolesUser{}
+	require.NoError(t, binder.UnmarshalBody(request, k))
+	require.True(t, k.IsVerified)
+}
@@ -0,0 +1,199 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Rules validates a map[string]interface{} target the way struct tags
+// validate a struct. Each key maps to a comma separated rule list, e.g.
+//
+//	reqbind.Rules{"email": "required,email,trimlower", "bio": "truncate=500"}
+//
+// The rules are required, nonzero, trimlower, email, phone, uuid, slug,
+// country, password, address, geohash, validate=name, max-length=N, truncate=N and
+// phone-format=e164 or national.
+type Rules map[string]string
+
+// stringRules only make sense on string values
+var stringRules = map[string]bool{
+	"trimlower": true, "email": true, "phone": true, "uuid": true, "slug": true, "country": true, "password": true, "address": true, "geohash": true,
+	"max-length": true, "truncate": true, "phone-format": true,
+}
+
+// tag compiles a rule list into the struct tag checkField understands
+func (rules Rules) tag(key string) (reflect.StructTag, bool, error) {
+	return compileRules(key, rules[key])
+}
+
+// compileRules compiles the comma separated rule list for key
+func compileRules(key string, list string) (reflect.StructTag, bool, error) {
+	var tag []string
+	stringOnly := false
+	for _, rule := range strings.Split(list, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if stringRules[name] {
+			stringOnly = true
+		}
+		switch name {
+		case "":
+		case "required", "trimlower":
+			tag = append(tag, fmt.Sprintf(`%s:"true"`, name))
+		case "nonzero":
+			tag = append(tag, `required:"nonzero"`)
+		case "email", "phone", "uuid", "slug", "country", "password", "address", "geohash":
+			tag = append(tag, fmt.Sprintf(`validate:"%s"`, name))
+		case "validate":
+			stringOnly = true
+			tag = append(tag, fmt.Sprintf(`validate:"%s"`, value))
+		case "max-length", "truncate", "phone-format":
+			tag = append(tag, fmt.Sprintf(`%s:"%s"`, name, value))
+		default:
+			return "", false, fieldErrorf(CodeInvalidTag, key, "has unknown rule %s", name)
+		}
+	}
+	return reflect.StructTag(strings.Join(tag, " ")), stringOnly, nil
+}
+
+// UnmarshalBodyMap binds a json object body into m and validates it with rules
+func UnmarshalBodyMap(r *http.Request, m map[string]interface{}, rules Rules) (err error) {
+	defer defaultBinder.redact(&err)
+	defer recoverPanic(&err)
+	r, done := defaultBinder.withBindTimeout(r)
+	defer done(&err)
+	if m == nil {
+		return fmt.Errorf("map must not be nil")
+	}
+	bodyBytes, err := defaultBinder.readBody(r)
+	if err != nil {
+		return err
+	}
+	if len(bodyBytes) > 0 {
+		if err := unmarshalJSON(bodyBytes, &m, defaultBinder.useNumber); err != nil {
+			return err
+		}
+	}
+	return rules.Validate(m)
+}
+
+// UnmarshalQueryMap binds the query into m, coercing values the same way as
+// UnmarshalQuery, and validates it with rules. Keys with string rules such
+// as phone or max-length are kept as strings, like string fields are.
+func UnmarshalQueryMap(r *http.Request, m map[string]interface{}, rules Rules) (err error) {
+	defer defaultBinder.redact(&err)
+	defer recoverPanic(&err)
+	r, done := defaultBinder.withBindTimeout(r)
+	defer done(&err)
+	if m == nil {
+		return fmt.Errorf("map must not be nil")
+	}
+	for k, value := range r.URL.Query() {
+		if len(value) == 0 || value[0] == "" {
+			continue
+		}
+		if _, stringOnly, _ := rules.tag(k); stringOnly {
+			m[k] = coerceQueryValue(value[0], reflect.TypeOf(""))
+			continue
+		}
+		m[k] = coerceToType(value[0])
+	}
+	return rules.Validate(m)
+}
+
+// Validate checks m against the rules, in key order, applying modifiers such
+// as trimlower to the values in place
+func (rules Rules) Validate(m map[string]interface{}) (err error) {
+	defer recoverPanic(&err)
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tag, stringOnly, err := rules.tag(key)
+		if err != nil {
+			return err
+		}
+
+		raw, ok := m[key]
+		if !ok || raw == nil {
+			if tag.Get("required") != "" {
+				return fieldErrorf(CodeRequired, key, "is required")
+			}
+			continue
+		}
+
+		t := reflect.TypeOf(raw)
+		value := reflect.New(t).Elem()
+		value.Set(reflect.ValueOf(raw))
+		// the key is in the map, so required is already satisfied
+		presence := Presence{}
+		presence.add(key)
+		if err := checkRule(reflect.Value{}, key, tag, stringOnly, value, &presence); err != nil {
+			return err
+		}
+		m[key] = value.Interface()
+	}
+	return nil
+}
+
+// ValidateStruct applies the rules on top of v's own tags. Keys are dotted
+// json paths into v, e.g. "address.zip", so rules loaded at runtime can
+// tighten validation of a struct that's already been bound.
+func (rules Rules) ValidateStruct(v interface{}) (err error) {
+	defer recoverPanic(&err)
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tag, stringOnly, err := rules.tag(key)
+		if err != nil {
+			return err
+		}
+		parent := reflect.ValueOf(v).Elem()
+		parts := strings.Split(key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			f, ok := fieldByJSONName(parent.Type(), part)
+			if !ok || derefType(f.Type).Kind() != reflect.Struct {
+				return fmt.Errorf("rule %s does not match a field", key)
+			}
+			parent = parent.FieldByIndex(f.Index)
+			if parent.Kind() == reflect.Ptr {
+				if parent.IsNil() {
+					parent = reflect.Value{}
+					break
+				}
+				parent = parent.Elem()
+			}
+		}
+		if !parent.IsValid() {
+			if tag.Get("required") != "" {
+				return fieldErrorf(CodeRequired, key, "is required")
+			}
+			continue
+		}
+
+		f, ok := fieldByJSONName(parent.Type(), parts[len(parts)-1])
+		if !ok {
+			return fmt.Errorf("rule %s does not match a field", key)
+		}
+		if err := checkRule(parent, f.Name, tag, stringOnly, parent.FieldByIndex(f.Index), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRule(parent reflect.Value, name string, tag reflect.StructTag, stringOnly bool, value reflect.Value, presence *Presence) error {
+	if stringOnly && value.Kind() != reflect.String {
+		return fieldErrorf(CodeInvalidType, name, "must be a string")
+	}
+	return checkField(parent, reflect.StructField{Name: name, Type: value.Type(), Tag: tag}, value, checkOptions{presence: presence}, name)
+}
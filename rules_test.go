@@ -0,0 +1,75 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalBodyMap(t *testing.T) {
+	rules := Rules{"email": "required,email,trimlower", "bio": "truncate=5", "age": "nonzero"}
+
+	m := map[string]interface{}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":" AOEU@aoeu.com","bio":"aoeuaoeu","age":0,"extra":true}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBodyMap(request, m, rules), "age is zero")
+
+	m = map[string]interface{}{}
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"email":" AOEU@aoeu.com","bio":"aoeuaoeu","age":30,"extra":true}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBodyMap(request, m, rules))
+	require.Equal(t, map[string]interface{}{"email": "aoeu@aoeu.com", "bio": "aoeua", "age": float64(30), "extra": true}, m)
+}
+
+func TestUnmarshalBodyMapRequiredZero(t *testing.T) {
+	// required only needs the key, nonzero needs a value as well
+	m := map[string]interface{}{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"age":0}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBodyMap(request, m, Rules{"age": "required"}))
+	require.Equal(t, float64(0), m["age"])
+}
+
+func TestUnmarshalBodyMapInvalid(t *testing.T) {
+	tests := []struct {
+		body  string
+		rules Rules
+	}{
+		{body: `{}`, rules: Rules{"email": "required"}},
+		{body: `{"email":null}`, rules: Rules{"email": "required"}},
+		{body: `{"email":"aoeu"}`, rules: Rules{"email": "email"}},
+		{body: `{"email":3}`, rules: Rules{"email": "email"}},
+		{body: `{"name":"aoeuaoeu"}`, rules: Rules{"name": "max-length=3"}},
+		{body: `{"name":"a"}`, rules: Rules{"name": "shiny"}},
+		{body: `[]`, rules: Rules{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			require.Error(t, UnmarshalBodyMap(request, map[string]interface{}{}, test.rules))
+		})
+	}
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBodyMap(request, nil, Rules{}))
+}
+
+func TestUnmarshalQueryMap(t *testing.T) {
+	m := map[string]interface{}{}
+	request, err := http.NewRequest("GET", "/?phone=123-456-7890&size=3", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQueryMap(request, m, Rules{"phone": "required,phone", "size": "required"}))
+	require.Equal(t, map[string]interface{}{"phone": "1234567890", "size": 3}, m)
+
+	// numeric looking values stay strings for string rules
+	m = map[string]interface{}{}
+	request, err = http.NewRequest("GET", "/?phone=5035551234&zip=97201&size=3", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQueryMap(request, m, Rules{"phone": "phone", "zip": "max-length=5", "size": "required"}))
+	require.Equal(t, map[string]interface{}{"phone": "5035551234", "zip": "97201", "size": 3}, m)
+}
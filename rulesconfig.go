@@ -0,0 +1,47 @@
+package reqbind
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadRules reads a JSON or YAML rule document. Each key maps to a rule list,
+// either as a comma separated string or as a list:
+//
+//	email: required,email,trimlower
+//	bio: [truncate=500]
+//
+// Every rule is compiled up front so a bad document fails when it's loaded
+// rather than on the first request.
+func LoadRules(data []byte) (Rules, error) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	rules := Rules{}
+	for key, value := range doc {
+		switch value := value.(type) {
+		case string:
+			rules[key] = value
+		case []interface{}:
+			parts := make([]string, 0, len(value))
+			for _, rule := range value {
+				s, ok := rule.(string)
+				if !ok {
+					return nil, fmt.Errorf("rule for %s must be a string", key)
+				}
+				parts = append(parts, s)
+			}
+			rules[key] = strings.Join(parts, ",")
+		default:
+			return nil, fmt.Errorf("rules for %s must be a string or a list", key)
+		}
+		if _, _, err := rules.tag(key); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,68 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules([]byte(`{"email": "required,email", "bio": ["truncate=5"]}`))
+	require.NoError(t, err)
+	require.Equal(t, Rules{"email": "required,email", "bio": "truncate=5"}, rules)
+
+	rules, err = LoadRules([]byte("email: required,email\nbio:\n  - truncate=5\n"))
+	require.NoError(t, err)
+	require.Equal(t, Rules{"email": "required,email", "bio": "truncate=5"}, rules)
+}
+
+func TestLoadRulesInvalid(t *testing.T) {
+	tests := []string{
+		`{"email": "required,shiny"}`,
+		`{"email": 3}`,
+		`{"email": [3]}`,
+		`[`,
+	}
+	for _, doc := range tests {
+		t.Run(doc, func(t *testing.T) {
+			_, err := LoadRules([]byte(doc))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRulesValidateStruct(t *testing.T) {
+	type address struct {
+		Zip string `json:"zip"`
+	}
+	k := &struct {
+		Email   string   `json:"email"`
+		Bio     string   `json:"bio"`
+		Address address  `json:"address"`
+		Billing *address `json:"billing"`
+		Items   []struct {
+			SKU string `json:"sku"`
+		} `json:"items"`
+	}{}
+
+	tenant, err := LoadRules([]byte("email: [required, email, trimlower]\nbio: truncate=5\naddress.zip: required\n"))
+	require.NoError(t, err)
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"email":"AOEU@aoeu.com","bio":"aoeuaoeu","address":{"zip":"97201"}}`))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.NoError(t, tenant.ValidateStruct(k))
+	require.Equal(t, "aoeu@aoeu.com", k.Email)
+	require.Equal(t, "aoeua", k.Bio)
+
+	k.Address.Zip = ""
+	require.Error(t, tenant.ValidateStruct(k))
+
+	require.NoError(t, Rules{"billing.zip": "max-length=3"}.ValidateStruct(k))
+	require.Error(t, Rules{"billing.zip": "required"}.ValidateStruct(k))
+	require.Error(t, Rules{"phone": "required"}.ValidateStruct(k))
+	require.Error(t, Rules{"email.zip": "required"}.ValidateStruct(k))
+	require.EqualError(t, Rules{"items.sku": "required"}.ValidateStruct(k), "rule items.sku does not match a field")
+}
@@ -0,0 +1,78 @@
+package reqbind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ErrInfected is returned (wrapped) by a FileScanner for a file it rejects
+var ErrInfected = errors.New("file is infected")
+
+// FileScanner scans uploaded files, plug in ClamAV or a cloud scanning api
+// with WithFileScanner. Scan is called with the request context as a part
+// starts, content gets the part while it's read, so the file's Filename and
+// Header are set but nothing else yet. Return an error wrapping ErrInfected
+// to reject the file, any other error fails the request as a server error.
+type FileScanner interface {
+	Scan(ctx context.Context, file *File, content io.Reader) error
+}
+
+// FileScannerFunc adapts a function to FileScanner
+type FileScannerFunc func(ctx context.Context, file *File, content io.Reader) error
+
+// Scan calls f
+func (f FileScannerFunc) Scan(ctx context.Context, file *File, content io.Reader) error {
+	return f(ctx, file, content)
+}
+
+// WithFileScanner sets the scanner UnmarshalMultipart runs every uploaded
+// file through. A streamed file is scanned as the handler reads it and its
+// last Read fails when the scanner rejects it.
+func WithFileScanner(scanner FileScanner) Option {
+	return func(b *Binder) {
+		b.scanner = scanner
+	}
+}
+
+// fileScan hands a part to the scanner as it's read
+type fileScan struct {
+	pipe *io.PipeWriter
+	done chan error
+}
+
+// startScan starts scanning file, nil without a scanner
+func (b *Binder) startScan(ctx context.Context, file *File) *fileScan {
+	if b.scanner == nil {
+		return nil
+	}
+	reader, writer := io.Pipe()
+	scan := &fileScan{pipe: writer, done: make(chan error, 1)}
+	go func() {
+		err := b.scanner.Scan(ctx, file, reader)
+		// a scanner can stop reading early, the rest of the part is dropped
+		reader.Close()
+		scan.done <- err
+	}()
+	return scan
+}
+
+// Write passes p on to the scanner, it never fails so a scanner that has
+// already made up its mind doesn't stop the upload
+func (s *fileScan) Write(p []byte) (int, error) {
+	_, _ = s.pipe.Write(p)
+	return len(p), nil
+}
+
+// finish ends the part, with readErr when reading it failed, and returns
+// the scanner's verdict on the file of f
+func (s *fileScan) finish(f reflect.StructField, readErr error) error {
+	s.pipe.CloseWithError(readErr)
+	err := <-s.done
+	if errors.Is(err, ErrInfected) {
+		return &FieldError{Field: f.Name, Code: CodeInvalidFile, subject: "field", err: fmt.Errorf("field %s is invalid: %w", f.Name, err)}
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package reqbind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// eicar is a stand in for the EICAR test signature
+const eicar = "EICAR-TEST"
+
+// testScanner rejects content containing eicar and records what it scanned
+type testScanner struct {
+	scanned []string
+}
+
+func (s *testScanner) Scan(ctx context.Context, file *File, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	s.scanned = append(s.scanned, file.Filename)
+	if bytes.Contains(data, []byte(eicar)) {
+		return fmt.Errorf("%w: Eicar-Test-Signature", ErrInfected)
+	}
+	return nil
+}
+
+func TestUnmarshalMultipartFileScanner(t *testing.T) {
+	type upload struct {
+		Doc  *File  `file:"doc"`
+		Temp string `file:"temp"`
+	}
+	scanner := &testScanner{}
+	binder := New(WithFileScanner(scanner), WithUploadDir(t.TempDir()))
+
+	v := &upload{}
+	request := multipartRequest(t, testPart{name: "doc", filename: "a.txt", content: "clean"}, testPart{name: "temp", filename: "b.txt", content: "also clean"})
+	require.NoError(t, binder.UnmarshalMultipart(request, v))
+	require.Equal(t, []string{"a.txt", "b.txt"}, scanner.scanned)
+	require.Equal(t, int64(5), v.Doc.Size)
+	require.NoError(t, Cleanup(v))
+
+	request = multipartRequest(t, testPart{name: "temp", filename: "b.txt", content: "x" + eicar + "x"})
+	err := binder.UnmarshalMultipart(request, &upload{})
+	require.EqualError(t, err, "field Temp is invalid: file is infected: Eicar-Test-Signature")
+	require.True(t, errors.Is(err, ErrInfected))
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	require.Equal(t, CodeInvalidFile, fieldErr.Code)
+}
+
+func TestUnmarshalMultipartFileScannerFailure(t *testing.T) {
+	unavailable := errors.New("scanner unavailable")
+	binder := New(WithFileScanner(FileScannerFunc(func(ctx context.Context, file *File, content io.Reader) error {
+		// stops without reading, the upload still finishes
+		return unavailable
+	})))
+	request := multipartRequest(t, testPart{name: "doc", filename: "a.txt", content: "clean"})
+	err := binder.UnmarshalMultipart(request, &struct {
+		Doc *File `file:"doc"`
+	}{})
+	require.ErrorIs(t, err, unavailable)
+}
+
+func TestUnmarshalMultipartFileScannerStream(t *testing.T) {
+	type upload struct {
+		Data io.ReadCloser `file:"data"`
+	}
+	binder := New(WithFileScanner(&testScanner{}))
+
+	v := &upload{}
+	require.NoError(t, binder.UnmarshalMultipart(multipartRequest(t, testPart{name: "data", filename: "a.txt", content: "clean"}), v))
+	data, err := io.ReadAll(v.Data)
+	require.NoError(t, err)
+	require.Equal(t, "clean", string(data))
+	require.NoError(t, Cleanup(v))
+
+	v = &upload{}
+	require.NoError(t, binder.UnmarshalMultipart(multipartRequest(t, testPart{name: "data", filename: "a.txt", content: eicar}), v))
+	_, err = io.ReadAll(v.Data)
+	require.EqualError(t, err, "field Data is invalid: file is infected: Eicar-Test-Signature")
+	require.NoError(t, Cleanup(v))
+
+	// closing a stream that wasn't read to the end stops the scan
+	v = &upload{}
+	require.NoError(t, binder.UnmarshalMultipart(multipartRequest(t, testPart{name: "data", filename: "a.txt", content: "clean"}), v))
+	require.NoError(t, Cleanup(v))
+}
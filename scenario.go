@@ -0,0 +1,42 @@
+package reqbind
+
+import "net/http"
+
+// BindScenario binds the query and body like BindWithPresence for one
+// operation. Fields tagged scenario:"create,update" are only bound and
+// checked in those scenarios, in any other scenario sending them is an
+// error. Fields without a scenario tag always apply.
+func BindScenario(r *http.Request, v interface{}, scenario string) error {
+	return defaultBinder.BindScenario(r, v, scenario)
+}
+
+// BindScenario binds like the package level BindScenario
+func (b *Binder) BindScenario(r *http.Request, v interface{}, scenario string) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	aliases := map[string]string{}
+	r = aliasQuery(r, v, aliases)
+	if err := b.checkUTF8(r, v); err != nil {
+		return err
+	}
+	if err := b.checkQueryKeys(r.URL.Query(), v); err != nil {
+		return err
+	}
+	bodyBytes, err := b.readBody(r)
+	if err != nil {
+		return err
+	}
+	if bodyBytes, err = aliasBody(bodyBytes, v, aliases); err != nil {
+		return err
+	}
+	presence, err := b.bindQueryAndBody(r, bodyBytes, v)
+	if err != nil {
+		return err
+	}
+	presence.aliases = aliases
+	opts := b.checkOptions(r, &presence)
+	opts.scenario = scenario
+	return checkStruct(v, opts, "")
+}
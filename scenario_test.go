@@ -0,0 +1,55 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioProject struct {
+	ID      string `json:"id" scenario:"update" required:"true"`
+	Name    string `json:"name" scenario:"create" required:"true"`
+	Size    int    `json:"size"`
+	Address struct {
+		Zip string `json:"zip" scenario:"update" required:"true"`
+	} `json:"address" scenario:"create,update"`
+}
+
+func TestBindScenario(t *testing.T) {
+	tests := []struct {
+		scenario   string
+		body       string
+		shouldPass bool
+	}{
+		{scenario: "create", body: `{"name":"a"}`, shouldPass: true},
+		{scenario: "create", body: `{"name":"a","address":{}}`, shouldPass: true},
+		{scenario: "create", body: `{"size":3}`, shouldPass: false},
+		{scenario: "create", body: `{"id":"1","name":"a"}`, shouldPass: false},
+		{scenario: "create", body: `{"name":"a","address":{"zip":"97201"}}`, shouldPass: false},
+		{scenario: "update", body: `{"id":"1","address":{"zip":"97201"}}`, shouldPass: true},
+		{scenario: "update", body: `{"id":"1"}`, shouldPass: false},
+		{scenario: "update", body: `{"address":{"zip":"97201"}}`, shouldPass: false},
+		{scenario: "update", body: `{"id":"1","name":"a","address":{"zip":"97201"}}`, shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario+test.body, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			if !test.shouldPass {
+				require.Error(t, BindScenario(request, &scenarioProject{}, test.scenario))
+				return
+			}
+			require.NoError(t, BindScenario(request, &scenarioProject{}, test.scenario))
+		})
+	}
+}
+
+func TestScenarioIgnoredByOtherBinders(t *testing.T) {
+	// without a scenario every field applies
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	require.Error(t, UnmarshalBody(request, &scenarioProject{}))
+}
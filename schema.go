@@ -0,0 +1,134 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// Schema is what reqbind knows about a struct type once its tags are
+// parsed. It's built once per type and shared.
+type Schema struct {
+	Type   reflect.Type
+	Fields []FieldSchema
+}
+
+// FieldSchema is one field of a Schema
+type FieldSchema struct {
+	// Name is the Go field name
+	Name string
+	// Path is the dotted json path from the root
+	Path string
+	// Description is the desc tag, e.g. Number of items per page
+	Description string
+	// Validate is the type from the validate tag, e.g. email
+	Validate string
+	// Pattern is the compiled regexp tag, or the expression behind a regex
+	// based validate type such as email or slug
+	Pattern *regexp.Regexp
+	// Fields are the fields of a nested struct, or of the items of a slice
+	// of structs
+	Fields []FieldSchema
+}
+
+var (
+	schemas  sync.Map // reflect.Type to *Schema
+	patterns sync.Map // pattern to *regexp.Regexp
+)
+
+// validatePatterns are the expressions behind regex based validate types
+var validatePatterns = map[string]*regexp.Regexp{
+	"email": emailRegex,
+	"uuid":  uuidRegex,
+	"slug":  slugRegex,
+}
+
+// SchemaOf returns the schema of T, which must be a struct or a pointer to
+// one. The schema is built on first use and cached.
+func SchemaOf[T any]() (*Schema, error) {
+	return schemaOf(indirectType(reflect.TypeOf((*T)(nil))))
+}
+
+func schemaOf(t reflect.Type) (*Schema, error) {
+	if cached, ok := schemas.Load(t); ok {
+		return cached.(*Schema), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+	fields, err := schemaFields(t, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	schema, _ := schemas.LoadOrStore(t, &Schema{Type: t, Fields: fields})
+	return schema.(*Schema), nil
+}
+
+// schemaFields describes the fields of t, seen stops recursive types
+func schemaFields(t reflect.Type, prefix string, seen map[reflect.Type]bool) ([]FieldSchema, error) {
+	if seen[t] {
+		return nil, nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var fields []FieldSchema
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if isIgnored(f) {
+			continue
+		}
+		field := FieldSchema{Name: f.Name, Path: prefix + jsonName(f), Description: f.Tag.Get("desc")}
+		field.Validate, _ = parseValidateTag(f.Tag.Get("validate"))
+		field.Pattern = validatePatterns[field.Validate]
+		if tag := f.Tag.Get("regexp"); tag != "" {
+			pattern, err := compilePattern(tag)
+			if err != nil {
+				return nil, fieldErrorf(CodeInvalidTag, f.Name, "has invalid regexp: %s", err)
+			}
+			field.Pattern = pattern
+		}
+		if nested := indirectType(f.Type); nested.Kind() == reflect.Struct {
+			nestedFields, err := schemaFields(nested, nestedPrefix(f, field.Path), seen)
+			if err != nil {
+				return nil, err
+			}
+			field.Fields = nestedFields
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// compilePattern compiles each unique pattern once
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := patterns.LoadOrStore(pattern, compiled)
+	return cached.(*regexp.Regexp), nil
+}
+
+// checkRegexp matches the value against the regexp tag
+func checkRegexp(f reflect.StructField, value reflect.Value) error {
+	tag := f.Tag.Get("regexp")
+	if tag == "" {
+		return nil
+	}
+	if value.Kind() != reflect.String {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has regexp but is not a string")
+	}
+	pattern, err := compilePattern(tag)
+	if err != nil {
+		return fieldErrorf(CodeInvalidTag, f.Name, "has invalid regexp")
+	}
+	if !pattern.MatchString(value.String()) {
+		return fieldErrorf(CodeInvalidFormat, f.Name, "is invalid: does not match %s", tag)
+	}
+	return nil
+}
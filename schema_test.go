@@ -0,0 +1,80 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type schemaOrder struct {
+	Code     string `json:"code" regexp:"^[A-Z]{3}-[0-9]+$"`
+	Email    string `json:"email" validate:"email"`
+	Internal string `json:"-"`
+	Lines    []struct {
+		SKU string `json:"sku" regexp:"^[A-Z]{3}-[0-9]+$"`
+	} `json:"lines"`
+	Next *schemaOrder `json:"next"`
+}
+
+func TestRegexpTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		error string
+	}{
+		{name: "match", body: `{"code":"ABC-12","email":"a@b.com"}`},
+		{name: "no match", body: `{"code":"abc-12","email":"a@b.com"}`, error: "field Code is invalid: does not match ^[A-Z]{3}-[0-9]+$"},
+		{name: "items", body: `{"code":"ABC-1","email":"a@b.com","lines":[{"sku":"x"}]}`, error: "field Lines item 0: field SKU is invalid: does not match ^[A-Z]{3}-[0-9]+$"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = UnmarshalBody(request, &schemaOrder{})
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"code":"a"}`))
+	require.NoError(t, err)
+	require.EqualError(t, UnmarshalBody(request, &struct {
+		Code string `json:"code" regexp:"["`
+	}{}), "field Code has invalid regexp")
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf[schemaOrder]()
+	require.NoError(t, err)
+	require.Len(t, schema.Fields, 4)
+
+	code, email, lines, next := schema.Fields[0], schema.Fields[1], schema.Fields[2], schema.Fields[3]
+	require.Equal(t, "code", code.Path)
+	require.Equal(t, "^[A-Z]{3}-[0-9]+$", code.Pattern.String())
+	require.Equal(t, "email", email.Validate)
+	require.Same(t, emailRegex, email.Pattern)
+	require.Equal(t, "lines.sku", lines.Fields[0].Path)
+	// each unique pattern is compiled once
+	require.Same(t, code.Pattern, lines.Fields[0].Pattern)
+	// recursive types stop
+	require.Equal(t, "next", next.Path)
+	require.Empty(t, next.Fields)
+
+	// the schema is cached, pointers and values share it
+	again, err := SchemaOf[*schemaOrder]()
+	require.NoError(t, err)
+	require.Same(t, schema, again)
+
+	_, err = SchemaOf[string]()
+	require.EqualError(t, err, "string is not a struct")
+	_, err = SchemaOf[struct {
+		Code string `regexp:"["`
+	}]()
+	require.Error(t, err)
+}
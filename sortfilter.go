@@ -0,0 +1,164 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// SortField is one entry of a sort expression such as ?sort=-created_at,name
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Filter is one filter expression such as ?filter=status:eq:open
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// filterOps are the operators a filter expression can use
+var filterOps = map[string]bool{
+	"eq": true, "ne": true,
+	"gt": true, "gte": true,
+	"lt": true, "lte": true,
+	"in": true, "contains": true,
+}
+
+var (
+	sortFieldsType = reflect.TypeOf([]SortField{})
+	filtersType    = reflect.TypeOf([]Filter{})
+)
+
+// ParseSort parses a comma separated sort expression, checking each field
+// against the allowed list
+func ParseSort(value string, allowed []string) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sf := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !contains(allowed, sf.Field) {
+			return nil, fmt.Errorf("cannot sort by %s", sf.Field)
+		}
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+// ParseFilter parses a field:op:value filter expression. allowed maps each
+// filterable field to the operators it accepts.
+func ParseFilter(value string, allowed map[string][]string) (Filter, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return Filter{}, fmt.Errorf("filter %s must be field:op:value", value)
+	}
+	f := Filter{Field: parts[0], Op: parts[1], Value: parts[2]}
+	if !filterOps[f.Op] {
+		return Filter{}, fmt.Errorf("unknown filter operator %s", f.Op)
+	}
+	ops, ok := allowed[f.Field]
+	if !ok {
+		return Filter{}, fmt.Errorf("cannot filter by %s", f.Field)
+	}
+	if !contains(ops, f.Op) {
+		return Filter{}, fmt.Errorf("cannot filter %s with %s", f.Field, f.Op)
+	}
+	return f, nil
+}
+
+// parseFilterTag reads status:eq|ne,created_at:gt|lt into a field to
+// operators map
+func parseFilterTag(tag string) (map[string][]string, error) {
+	allowed := make(map[string][]string)
+	for _, part := range strings.Split(tag, ",") {
+		field, ops, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || field == "" || ops == "" {
+			return nil, fmt.Errorf("invalid filter rule %s", part)
+		}
+		for _, op := range strings.Split(ops, "|") {
+			if !filterOps[op] {
+				return nil, fmt.Errorf("unknown filter operator %s", op)
+			}
+		}
+		allowed[field] = strings.Split(ops, "|")
+	}
+	return allowed, nil
+}
+
+// isExpressionField is true for the []SortField and []Filter fields that
+// UnmarshalQuery parses itself rather than handing to encoding/json
+func isExpressionField(f reflect.StructField) bool {
+	return f.Type == sortFieldsType || f.Type == filtersType
+}
+
+// bindExpressions parses the sort and filter expressions for the fields
+// tagged with sort:"a,b" or filter:"field:op|op"
+func bindExpressions(query url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !isExpressionField(f) || isIgnored(f) {
+			continue
+		}
+		values := queryValues(query, jsonName(f))
+		if len(values) == 0 {
+			continue
+		}
+
+		if f.Type == sortFieldsType {
+			allowed := strings.Split(f.Tag.Get("sort"), ",")
+			fields, err := ParseSort(strings.Join(values, ","), allowed)
+			if err != nil {
+				return fieldErrorf(CodeInvalid, f.Name, "is invalid: %s", err)
+			}
+			rv.Field(i).Set(reflect.ValueOf(fields))
+			continue
+		}
+
+		allowed, err := parseFilterTag(f.Tag.Get("filter"))
+		if err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid filter: %s", err)
+		}
+		filters := make([]Filter, 0, len(values))
+		for _, value := range values {
+			filter, err := ParseFilter(value, allowed)
+			if err != nil {
+				return fieldErrorf(CodeInvalid, f.Name, "is invalid: %s", err)
+			}
+			filters = append(filters, filter)
+		}
+		rv.Field(i).Set(reflect.ValueOf(filters))
+	}
+	return nil
+}
+
+// queryValues looks a key up case insensitively, matching UnmarshalQuery
+func queryValues(query url.Values, key string) []string {
+	var values []string
+	for k, v := range query {
+		if strings.EqualFold(k, key) {
+			for _, value := range v {
+				if value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package reqbind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortAndFilterQuery(t *testing.T) {
+	k := &struct {
+		Sort   []SortField `sort:"created_at,name"`
+		Filter []Filter    `filter:"status:eq|ne,created_at:gt|lt"`
+		Size   int
+	}{}
+
+	request, err := http.NewRequest("GET", "/?sort=-created_at,name&filter=status:eq:open&filter=created_at:gt:2023-01-01T00:00:00Z&size=3", nil)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalQuery(request, k))
+	require.Equal(t, []SortField{{Field: "created_at", Desc: true}, {Field: "name"}}, k.Sort)
+	require.Equal(t, []Filter{
+		{Field: "status", Op: "eq", Value: "open"},
+		{Field: "created_at", Op: "gt", Value: "2023-01-01T00:00:00Z"},
+	}, k.Filter)
+	require.Equal(t, 3, k.Size)
+}
+
+func TestSortAndFilterInvalid(t *testing.T) {
+	tests := []string{
+		"sort=password",
+		"filter=status",
+		"filter=status:gt:open",
+		"filter=owner:eq:me",
+		"filter=status:like:open",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			k := &struct {
+				Sort   []SortField `sort:"created_at,name"`
+				Filter []Filter    `filter:"status:eq|ne"`
+			}{}
+			request, err := http.NewRequest("GET", "/?"+query, nil)
+			require.NoError(t, err)
+			require.Error(t, UnmarshalQuery(request, k))
+		})
+	}
+}
+
+func TestFilterInvalidTag(t *testing.T) {
+	k := &struct {
+		Filter []Filter `filter:"status:like"`
+	}{}
+	request, err := http.NewRequest("GET", "/?filter=status:like:open", nil)
+	require.NoError(t, err)
+	require.Error(t, UnmarshalQuery(request, k))
+}
+
+func TestParseFilterValueWithColons(t *testing.T) {
+	f, err := ParseFilter("at:gt:10:30", map[string][]string{"at": {"gt"}})
+	require.NoError(t, err)
+	require.Equal(t, "10:30", f.Value)
+}
@@ -0,0 +1,182 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Bind binds every part of the request into v: chi path parameters (when
+// there's a route context), the query, the body and header tagged fields.
+// A path parameter always wins over the same key in the query or body. The
+// metadata is checked once everything is bound.
+//
+// A field tagged source:"header,query,body" is only read from those places,
+// in that order, and takes the first one that has it. Header lookups use the
+// header tag if there is one and the json name otherwise.
+func Bind(r *http.Request, v interface{}) error {
+	return defaultBinder.Bind(r, v)
+}
+
+// Bind binds like the package level Bind
+func (b *Binder) Bind(r *http.Request, v interface{}) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	return b.bind(r, v, nil)
+}
+
+// bind is Bind, setup can change the check options before the checks run
+func (b *Binder) bind(r *http.Request, v interface{}, setup func(opts *checkOptions)) error {
+	aliases := map[string]string{}
+	r = aliasQuery(r, v, aliases)
+	if err := b.checkUTF8(r, v); err != nil {
+		return err
+	}
+	if err := b.checkQueryKeys(r.URL.Query(), v); err != nil {
+		return err
+	}
+	rctx := chi.RouteContext(r.Context())
+
+	bodyBytes, err := b.readBody(r)
+	if err != nil {
+		return err
+	}
+	raw := bodyBytes
+	if bodyBytes, err = aliasBody(bodyBytes, v, aliases); err != nil {
+		return err
+	}
+
+	var presence Presence
+	opts := b.checkOptions(r, &presence)
+	if setup != nil {
+		setup(&opts)
+	}
+	presence, err = b.bindQueryAndBody(r, bodyBytes, v)
+	if err != nil {
+		return err
+	}
+	// path parameters are bound last so the query and body can't replace
+	// the one the route was authorized on
+	if rctx != nil {
+		if err := bindURLParams(rctx, v); err != nil {
+			return err
+		}
+	}
+	if opts.conflicts != nil || b.conflicts == ConflictError {
+		conflicts := b.findConflicts(r, rctx, bodyBytes, v)
+		if b.conflicts == ConflictError {
+			if err := conflictError(conflicts); err != nil {
+				return err
+			}
+		}
+		if opts.conflicts != nil {
+			*opts.conflicts = conflicts
+		}
+	}
+	if err := bindHeaders(r.Header, v); err != nil {
+		return err
+	}
+	if err := bindTrailers(r, raw, v); err != nil {
+		return err
+	}
+	if err := b.bindClientIP(r, v, &presence); err != nil {
+		return err
+	}
+	if err := b.bindUserAgent(r, v, &presence); err != nil {
+		return err
+	}
+	if err := bindGeo(r, v, &presence); err != nil {
+		return err
+	}
+	if err := bindSources(r, rctx, bodyBytes, v, &presence); err != nil {
+		return err
+	}
+
+	presence.aliases = aliases
+	return checkStruct(v, opts, "")
+}
+
+// bindSources resolves the fields tagged with a source chain
+func bindSources(r *http.Request, rctx *chi.Context, bodyBytes []byte, v interface{}, presence *Presence) error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+
+	var body map[string]json.RawMessage
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		chain := f.Tag.Get("source")
+		if chain == "" || isIgnored(f) {
+			continue
+		}
+		if body == nil {
+			body = map[string]json.RawMessage{}
+			_ = json.Unmarshal(bodyBytes, &body)
+		}
+
+		// only the sources in the chain count, whatever else was bound goes
+		rv.Field(i).Set(reflect.Zero(f.Type))
+		name := jsonName(f)
+		presence.remove(name)
+		for _, source := range strings.Split(chain, ",") {
+			raw, ok, err := lookupSource(strings.TrimSpace(source), f, r, rctx, body)
+			if err != nil {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has invalid source: %s", err)
+			}
+			if !ok {
+				continue
+			}
+			doc, err := json.Marshal(map[string]json.RawMessage{name: raw})
+			if err != nil {
+				return err
+			}
+			if err := unmarshalFields(doc, v, false); err != nil {
+				return err
+			}
+			presence.add(name)
+			break
+		}
+	}
+	return nil
+}
+
+// lookupSource returns the json encoded value of a field from one source
+func lookupSource(source string, f reflect.StructField, r *http.Request, rctx *chi.Context, body map[string]json.RawMessage) (json.RawMessage, bool, error) {
+	name := jsonName(f)
+	var value string
+	switch source {
+	case "header":
+		header := f.Tag.Get("header")
+		if header == "" {
+			header = name
+		}
+		value = r.Header.Get(header)
+	case "query":
+		if values := queryValues(r.URL.Query(), name); len(values) > 0 {
+			value = values[0]
+		}
+	case "path":
+		if rctx != nil {
+			value = rctx.URLParam(name)
+		}
+	case "body":
+		for key, raw := range body {
+			if strings.EqualFold(key, name) {
+				return raw, true, nil
+			}
+		}
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unknown source %s", source)
+	}
+	if value == "" {
+		return nil, false, nil
+	}
+	raw, err := json.Marshal(coerceQueryValue(value, f.Type))
+	return raw, true, err
+}
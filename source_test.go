@@ -0,0 +1,121 @@
+package reqbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type sourceRequest struct {
+	APIKey string `json:"apiKey" header:"X-Api-Key" source:"header,query" required:"true"`
+	Locale string `json:"locale" source:"query,body,header"`
+	Name   string `json:"name"`
+}
+
+func TestBindSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		headers  map[string]string
+		expected sourceRequest
+	}{
+		{
+			name:     "header first",
+			query:    "apiKey=fromquery",
+			headers:  map[string]string{"X-Api-Key": "fromheader"},
+			expected: sourceRequest{APIKey: "fromheader"},
+		},
+		{
+			name:     "query fallback",
+			query:    "apiKey=fromquery&locale=fr",
+			body:     `{"locale":"de","name":"a"}`,
+			expected: sourceRequest{APIKey: "fromquery", Locale: "fr", Name: "a"},
+		},
+		{
+			name:     "body fallback",
+			query:    "apiKey=k",
+			body:     `{"locale":"de"}`,
+			headers:  map[string]string{"locale": "en"},
+			expected: sourceRequest{APIKey: "k", Locale: "de"},
+		},
+		{
+			name:     "last in chain",
+			query:    "apiKey=k",
+			headers:  map[string]string{"locale": "en"},
+			expected: sourceRequest{APIKey: "k", Locale: "en"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/?"+test.query, strings.NewReader(test.body))
+			require.NoError(t, err)
+			for k, v := range test.headers {
+				request.Header.Set(k, v)
+			}
+			k := &sourceRequest{}
+			require.NoError(t, Bind(request, k))
+			require.Equal(t, &test.expected, k)
+		})
+	}
+}
+
+func TestBindSourcesNotInChain(t *testing.T) {
+	// the body isn't in the api key's chain so it can't supply it
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"apiKey":"frombody"}`))
+	require.NoError(t, err)
+	require.Error(t, Bind(request, &sourceRequest{}))
+
+	request, err = http.NewRequest("POST", "/?apiKey=k", nil)
+	require.NoError(t, err)
+	require.Error(t, Bind(request, &struct {
+		APIKey string `json:"apiKey" source:"cookie"`
+	}{}))
+}
+
+func TestBindPathQueryBody(t *testing.T) {
+	type request struct {
+		ProjectID string `json:"projectId" required:"true"`
+		DryRun    bool   `json:"dryRun"`
+		Name      string `json:"name" required:"true"`
+		ID        string `json:"id" source:"path"`
+	}
+
+	r := chi.NewRouter()
+	r.Post("/projects/{projectId}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		k := &request{}
+		require.NoError(t, Bind(r, k))
+		require.Equal(t, &request{ProjectID: "p1", DryRun: true, Name: "a", ID: "42"}, k)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest("POST", "/projects/p1/42?dryRun=true", strings.NewReader(`{"name":"a","id":"99"}`)))
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestBindPathParamWins(t *testing.T) {
+	type request struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	r := chi.NewRouter()
+	r.Post("/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		k := &request{}
+		require.NoError(t, Bind(r, k))
+		require.Equal(t, &request{ID: "42", Name: "a"}, k)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, target := range []string{"/accounts/42?id=99", "/accounts/42"} {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest("POST", target, strings.NewReader(`{"name":"a","id":"98"}`)))
+		require.Equal(t, http.StatusNoContent, recorder.Code)
+	}
+}
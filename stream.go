@@ -0,0 +1,111 @@
+package reqbind
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHeartbeat    = 15 * time.Second
+	defaultMinHeartbeat = time.Second
+	defaultMaxHeartbeat = 2 * time.Minute
+)
+
+// Stream is a ready made set of parameters for server-sent events and long
+// poll endpoints that can be embedded in a request struct. LastEventID is the
+// Last-Event-ID header a reconnecting EventSource sends, or the lastEventId
+// query parameter. Heartbeat is how often the client wants a keep alive, a
+// duration such as 30s or a number of seconds. After binding
+// HeartbeatInterval holds it with the default applied and clamped to the
+// bounds, which are configured with WithHeartbeat or a stream tag on the
+// embedded field, e.g.
+//
+//	reqbind.Stream `stream:"default=20s,min=5s,max=1m"`
+type Stream struct {
+	LastEventID       string        `json:"lastEventId" header:"Last-Event-ID" optional:"true"`
+	Heartbeat         string        `json:"heartbeat" optional:"true"`
+	HeartbeatInterval time.Duration `json:"-"`
+}
+
+// Resuming reports whether the client is reconnecting after an event
+func (s Stream) Resuming() bool {
+	return s.LastEventID != ""
+}
+
+var streamType = reflect.TypeOf(Stream{})
+
+type heartbeatBounds struct {
+	interval time.Duration
+	min      time.Duration
+	max      time.Duration
+}
+
+// WithHeartbeat sets the default heartbeat of the Stream preset and the
+// bounds a requested one is clamped to, the defaults are 15s between 1s and
+// 2m. A stream tag overrides them.
+func WithHeartbeat(interval time.Duration, min time.Duration, max time.Duration) Option {
+	return func(b *Binder) {
+		b.heartbeat = heartbeatBounds{interval: interval, min: min, max: max}
+	}
+}
+
+func parseStreamTag(tag string, bounds heartbeatBounds) (heartbeatBounds, error) {
+	if bounds == (heartbeatBounds{}) {
+		bounds = heartbeatBounds{interval: defaultHeartbeat, min: defaultMinHeartbeat, max: defaultMaxHeartbeat}
+	}
+	if tag == "" {
+		return bounds, nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return bounds, fmt.Errorf("invalid %s", key)
+		}
+		switch key {
+		case "default":
+			bounds.interval = d
+		case "min":
+			bounds.min = d
+		case "max":
+			bounds.max = d
+		default:
+			return bounds, fmt.Errorf("unknown option %s", key)
+		}
+	}
+	return bounds, nil
+}
+
+// normalize parses Heartbeat into HeartbeatInterval
+func (s *Stream) normalize(bounds heartbeatBounds) error {
+	s.HeartbeatInterval = bounds.interval
+	if s.Heartbeat != "" {
+		d, err := parseHeartbeat(s.Heartbeat)
+		if err != nil {
+			return err
+		}
+		s.HeartbeatInterval = d
+	}
+	if s.HeartbeatInterval < bounds.min {
+		s.HeartbeatInterval = bounds.min
+	}
+	if s.HeartbeatInterval > bounds.max {
+		s.HeartbeatInterval = bounds.max
+	}
+	return nil
+}
+
+// parseHeartbeat reads a duration, a bare number is seconds
+func parseHeartbeat(value string) (time.Duration, error) {
+	if n, err := strconv.Atoi(value); err == nil && n > 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("heartbeat must be a positive duration")
+	}
+	return d, nil
+}
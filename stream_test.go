@@ -0,0 +1,70 @@
+package reqbind
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type streamEvents struct {
+	Stream
+	Topic string `json:"topic"`
+}
+
+type streamTagged struct {
+	Stream `stream:"default=20s,min=5s,max=1m"`
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		binder    *Binder
+		v         interface{}
+		url       string
+		lastEvent string
+		expected  Stream
+		err       string
+	}{
+		{name: "defaults", binder: New(), v: &streamEvents{}, url: "/events", expected: Stream{HeartbeatInterval: 15 * time.Second}},
+		{name: "header", binder: New(), v: &streamEvents{}, url: "/events?lastEventId=3", lastEvent: "42", expected: Stream{LastEventID: "42", HeartbeatInterval: 15 * time.Second}},
+		{name: "query", binder: New(), v: &streamEvents{}, url: "/events?lastEventId=3", expected: Stream{LastEventID: "3", HeartbeatInterval: 15 * time.Second}},
+		{name: "duration", binder: New(), v: &streamEvents{}, url: "/events?heartbeat=30s", expected: Stream{Heartbeat: "30s", HeartbeatInterval: 30 * time.Second}},
+		{name: "seconds", binder: New(), v: &streamEvents{}, url: "/events?heartbeat=45", expected: Stream{Heartbeat: "45", HeartbeatInterval: 45 * time.Second}},
+		{name: "clamped", binder: New(), v: &streamEvents{}, url: "/events?heartbeat=1h", expected: Stream{Heartbeat: "1h", HeartbeatInterval: 2 * time.Minute}},
+		{name: "binder bounds", binder: New(WithHeartbeat(10*time.Second, 10*time.Second, 30*time.Second)), v: &streamEvents{}, url: "/events?heartbeat=1s", expected: Stream{Heartbeat: "1s", HeartbeatInterval: 10 * time.Second}},
+		{name: "tag", binder: New(), v: &streamTagged{}, url: "/events", expected: Stream{HeartbeatInterval: 20 * time.Second}},
+		{name: "tag bounds", binder: New(), v: &streamTagged{}, url: "/events?heartbeat=2m", expected: Stream{Heartbeat: "2m", HeartbeatInterval: time.Minute}},
+		{name: "invalid", binder: New(), v: &streamEvents{}, url: "/events?heartbeat=soon", err: "field Stream is invalid: heartbeat must be a positive duration"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", test.url, nil)
+			if test.lastEvent != "" {
+				request.Header.Set("Last-Event-ID", test.lastEvent)
+			}
+			err := test.binder.Bind(request, test.v)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			switch v := test.v.(type) {
+			case *streamEvents:
+				require.Equal(t, test.expected, v.Stream)
+			case *streamTagged:
+				require.Equal(t, test.expected, v.Stream)
+			}
+		})
+	}
+}
+
+func TestStreamInvalidTag(t *testing.T) {
+	v := &struct {
+		Stream `stream:"max=soon"`
+	}{}
+	err := Bind(httptest.NewRequest("GET", "/events", nil), v)
+	require.EqualError(t, err, "field Stream has invalid stream: invalid max")
+}
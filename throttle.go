@@ -0,0 +1,48 @@
+package reqbind
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrThrottled is matched by a ThrottledError with errors.Is
+var ErrThrottled = errors.New("throttled")
+
+// ThrottledError is returned by hooks such as a Verifier or an
+// IdempotencyStore when a backend they rely on is rate limiting, so the
+// request should be retried later. WriteError sends it as a 429 with a
+// Retry-After header.
+type ThrottledError struct {
+	// RetryAfter is how long the client should wait, zero when unknown
+	RetryAfter time.Duration
+	// Err is the backend's error, if any
+	Err error
+}
+
+// Throttled returns a ThrottledError asking the client to retry after d
+func Throttled(d time.Duration) error {
+	return &ThrottledError{RetryAfter: d}
+}
+
+func (e *ThrottledError) Error() string {
+	message := "request was throttled"
+	if e.RetryAfter > 0 {
+		message += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	return message
+}
+
+// Is makes errors.Is(err, ErrThrottled) true
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// retryAfterSeconds is d as a Retry-After header value, rounded up
+func retryAfterSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,60 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottled(t *testing.T) {
+	err := Throttled(1500 * time.Millisecond)
+	require.EqualError(t, err, "request was throttled, retry after 1.5s")
+	require.True(t, errors.Is(err, ErrThrottled))
+	require.True(t, errors.Is(&StatusError{Status: http.StatusBadRequest, Err: err}, ErrThrottled))
+
+	backend := errors.New("quota exceeded")
+	wrapped := &ThrottledError{Err: backend}
+	require.EqualError(t, wrapped, "request was throttled")
+	require.True(t, errors.Is(wrapped, backend))
+}
+
+func TestThrottledWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		retryAfter string
+	}{
+		{name: "rounded up", err: Throttled(1500 * time.Millisecond), retryAfter: "2"},
+		{name: "unknown", err: &ThrottledError{}, retryAfter: ""},
+		// a bind error wrapped by Handler is still a 429
+		{name: "in status error", err: &StatusError{Status: http.StatusBadRequest, Err: Throttled(time.Minute)}, retryAfter: "60"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, httptest.NewRequest("GET", "/", nil), test.err)
+			require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+			require.Equal(t, test.retryAfter, recorder.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestThrottledVerifier(t *testing.T) {
+	verifier := VerifierFunc(func(header http.Header, body []byte) error {
+		return Throttled(30 * time.Second)
+	})
+	handler := VerifyMiddleware(verifier)(Handler(func(w http.ResponseWriter, r *http.Request, order *handlerOrder) error {
+		return nil
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/", strings.NewReader(`{"sku":"a"}`)))
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+}
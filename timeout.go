@@ -0,0 +1,100 @@
+package reqbind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrBindTimeout is matched by a TimeoutError with errors.Is
+var ErrBindTimeout = errors.New("bind timed out")
+
+// TimeoutError is returned when binding takes longer than WithBindTimeout
+// allows. WriteError sends it as a 408 when the body was still arriving and
+// a 503 when a hook was too slow.
+type TimeoutError struct {
+	// Body is set when the client was still sending the body
+	Body bool
+	// Budget is the binder's timeout
+	Budget time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Body {
+		return fmt.Sprintf("request body was not received within %s", e.Budget)
+	}
+	return fmt.Sprintf("request was not bound within %s", e.Budget)
+}
+
+// Is makes errors.Is(err, ErrBindTimeout) true
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrBindTimeout
+}
+
+// WithBindTimeout caps how long binding a request can take, reading the
+// body and running context aware hooks such as a PolicyProvider included,
+// so slow or trickled bodies can't stall handlers. The hooks get a request
+// context with the deadline. A body that's still arriving is left to be
+// read in the background, pair this with the server's ReadTimeout so the
+// connection is closed too.
+func WithBindTimeout(d time.Duration) Option {
+	return func(b *Binder) {
+		b.bindTimeout = d
+	}
+}
+
+type bindBudgetKey struct{}
+
+// withBindTimeout gives r the binder's budget, unless it's already being
+// bound by an outer entry point. done cancels the budget and fails a bind
+// that ran past it with a TimeoutError, hooks such as a PolicyProvider
+// can't return errors of their own. Use it with a named error result:
+//
+//	r, done := b.withBindTimeout(r)
+//	defer done(&err)
+func (b *Binder) withBindTimeout(r *http.Request) (*http.Request, func(err *error)) {
+	if b.bindTimeout <= 0 || r.Context().Value(bindBudgetKey{}) != nil {
+		return r, func(err *error) {}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), b.bindTimeout)
+	ctx = context.WithValue(ctx, bindBudgetKey{}, b.bindTimeout)
+	return r.WithContext(ctx), func(err *error) {
+		expired := errors.Is(ctx.Err(), context.DeadlineExceeded)
+		cancel()
+		var timeout *TimeoutError
+		if expired && !errors.As(*err, &timeout) {
+			*err = &TimeoutError{Budget: b.bindTimeout}
+		}
+	}
+}
+
+// readBodyBefore reads the body in the background so the deadline of a bind
+// budget can cut it short. The buffer belongs to the reader until it's done.
+func readBodyBefore(r *http.Request, budget time.Duration, maxBytes int64) ([]byte, error) {
+	type result struct {
+		body []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		buf := getBuffer()
+		defer putBuffer(buf)
+		err := copyBody(buf, r, maxBytes)
+		done <- result{body: bytes.Clone(buf.Bytes()), err: err}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return res.body, nil
+	case <-r.Context().Done():
+		if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+			return nil, &TimeoutError{Body: true, Budget: budget}
+		}
+		return nil, r.Context().Err()
+	}
+}
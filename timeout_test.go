@@ -0,0 +1,55 @@
+package reqbind
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindTimeoutBody(t *testing.T) {
+	body, writer := io.Pipe()
+	defer writer.Close()
+	// the client sends the start of the body and then stalls
+	go func() {
+		_, _ = writer.Write([]byte(`{"sku":`))
+	}()
+	request := httptest.NewRequest("POST", "/", body)
+
+	start := time.Now()
+	err := New(WithBindTimeout(20*time.Millisecond)).Bind(request, &handlerOrder{})
+	require.EqualError(t, err, "request body was not received within 20ms")
+	require.True(t, errors.Is(err, ErrBindTimeout))
+	require.Less(t, time.Since(start), time.Second)
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, request, err)
+	require.Equal(t, http.StatusRequestTimeout, recorder.Code)
+}
+
+func TestBindTimeoutHook(t *testing.T) {
+	slow := PolicyProviderFunc(func(ctx context.Context) Policy {
+		<-ctx.Done()
+		return Policy{}
+	})
+	binder := New(WithBindTimeout(20*time.Millisecond), WithPolicyProvider(slow))
+	err := binder.Bind(httptest.NewRequest("POST", "/", strings.NewReader(`{"sku":"a"}`)), &handlerOrder{})
+	require.EqualError(t, err, "request was not bound within 20ms")
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, httptest.NewRequest("POST", "/", nil), err)
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestBindTimeoutFast(t *testing.T) {
+	order := &handlerOrder{}
+	err := New(WithBindTimeout(time.Second)).Bind(httptest.NewRequest("POST", "/", strings.NewReader(`{"sku":"a"}`)), order)
+	require.NoError(t, err)
+	require.Equal(t, "a", order.SKU)
+}
@@ -0,0 +1,85 @@
+package reqbind
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ErrChecksum is returned (wrapped) when a body doesn't match the checksum
+// sent for it
+var ErrChecksum = errors.New("checksum does not match")
+
+// bindTrailers binds the fields tagged trailer:"Name" from the trailers sent
+// after the body, which are only there once the body has been read. A field
+// tagged trailer:"Name,sha256" is also checked against the sha256 of body,
+// hex or base64 encoded.
+func bindTrailers(r *http.Request, body []byte, v interface{}) error {
+	tMap := make(map[string]interface{})
+	if err := trailerValues(r.Trailer, reflect.TypeOf(v).Elem(), body, tMap); err != nil {
+		return err
+	}
+	if len(tMap) == 0 {
+		return nil
+	}
+	j, err := json.Marshal(tMap)
+	if err != nil {
+		return err
+	}
+	return unmarshalFields(j, v, false)
+}
+
+// trailerValues adds the trailer tagged fields of t to tMap, including the
+// ones of embedded structs
+func trailerValues(trailer http.Header, t reflect.Type, body []byte, tMap map[string]interface{}) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" {
+			if err := trailerValues(trailer, f.Type, body, tMap); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := f.Tag.Get("trailer")
+		if tag == "" || isIgnored(f) {
+			continue
+		}
+		name, hash, _ := strings.Cut(tag, ",")
+		if hash != "" && hash != "sha256" {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has unknown trailer hash %s", hash)
+		}
+		value := trailer.Get(name)
+		if value == "" {
+			continue
+		}
+		if hash != "" && !matchesSHA256(body, value) {
+			return &FieldError{Field: f.Name, Code: CodeInvalid, subject: "field", err: fmt.Errorf("%w: field %s is not the sha256 of the body", ErrChecksum, f.Name)}
+		}
+		tMap[jsonName(f)] = coerceForField(value, f.Type)
+	}
+	return nil
+}
+
+// matchesSHA256 reports whether sum is the hex or base64 sha256 of body
+func matchesSHA256(body []byte, sum string) bool {
+	digest := sha256.Sum256(body)
+	return matchesDigest(digest[:], sum)
+}
+
+// matchesDigest reports whether sum is digest, hex or base64 encoded
+func matchesDigest(digest []byte, sum string) bool {
+	expected, err := hex.DecodeString(sum)
+	if err != nil {
+		if expected, err = base64.StdEncoding.DecodeString(sum); err != nil {
+			return false
+		}
+	}
+	return subtle.ConstantTimeCompare(digest, expected) == 1
+}
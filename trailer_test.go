@@ -0,0 +1,96 @@
+package reqbind
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type trailerUpload struct {
+	Name     string `json:"name" required:"true"`
+	Checksum string `trailer:"X-Checksum,sha256" required:"true"`
+	Parts    int    `trailer:"X-Parts"`
+}
+
+func TestBindTrailers(t *testing.T) {
+	body := `{"name":"report.csv"}`
+	sum := sha256.Sum256([]byte(body))
+	tests := []struct {
+		name     string
+		trailer  http.Header
+		expected string
+		target   error
+	}{
+		{name: "hex", trailer: http.Header{"X-Checksum": {hex.EncodeToString(sum[:])}, "X-Parts": {"3"}}},
+		{name: "base64", trailer: http.Header{"X-Checksum": {base64.StdEncoding.EncodeToString(sum[:])}}},
+		{name: "mismatch", trailer: http.Header{"X-Checksum": {hex.EncodeToString(make([]byte, 32))}}, expected: "checksum does not match: field Checksum is not the sha256 of the body", target: ErrChecksum},
+		{name: "not a checksum", trailer: http.Header{"X-Checksum": {"nope"}}, expected: "checksum does not match: field Checksum is not the sha256 of the body", target: ErrChecksum},
+		{name: "missing", trailer: http.Header{}, expected: "field Checksum is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, bind := range []func(*http.Request, interface{}) error{Bind, UnmarshalBody} {
+				request := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+				request.Trailer = test.trailer
+				upload := &trailerUpload{}
+				err := bind(request, upload)
+				if test.expected == "" {
+					require.NoError(t, err)
+					require.Equal(t, "report.csv", upload.Name)
+					require.Equal(t, test.trailer.Get("X-Checksum"), upload.Checksum)
+					continue
+				}
+				require.EqualError(t, err, test.expected)
+				if test.target != nil {
+					require.True(t, errors.Is(err, test.target))
+				}
+			}
+		})
+	}
+}
+
+func TestBindTrailersUnknownHash(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{}`))
+	request.Trailer = http.Header{"X-Checksum": {"abc"}}
+	upload := &struct {
+		Checksum string `trailer:"X-Checksum,md5"`
+	}{}
+	require.EqualError(t, Bind(request, upload), "field Checksum has unknown trailer hash md5")
+}
+
+func TestBindTrailersChunked(t *testing.T) {
+	var upload trailerUpload
+	server := httptest.NewServer(Handler(func(w http.ResponseWriter, r *http.Request, v *trailerUpload) error {
+		upload = *v
+		return nil
+	}))
+	defer server.Close()
+
+	body := `{"name":"report.csv"}`
+	sum := sha256.Sum256([]byte(body))
+	reader, writer := io.Pipe()
+	request, err := http.NewRequest("POST", server.URL, reader)
+	require.NoError(t, err)
+	request.Trailer = http.Header{"X-Checksum": nil, "X-Parts": nil}
+	go func() {
+		_, _ = writer.Write([]byte(body))
+		request.Trailer.Set("X-Checksum", hex.EncodeToString(sum[:]))
+		request.Trailer.Set("X-Parts", "2")
+		writer.Close()
+	}()
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, 2, upload.Parts)
+	require.Equal(t, hex.EncodeToString(sum[:]), upload.Checksum)
+}
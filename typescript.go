@@ -0,0 +1,275 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypeScript returns a TypeScript interface for the struct type of each of
+// values, and for the named structs they use, so front ends share the
+// server's definitions. Only fields sent in the body are included, fields
+// without required:"true" are optional and pointers can be null.
+func TypeScript(values ...interface{}) (string, error) {
+	types, err := tsTypes(values)
+	if err != nil {
+		return "", err
+	}
+	sb := &strings.Builder{}
+	for i, t := range types {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(sb, "export interface %s {\n", t.Name())
+		tsFields(sb, t, "  ")
+		sb.WriteString("}\n")
+	}
+	return sb.String(), nil
+}
+
+func tsFields(sb *strings.Builder, t reflect.Type, indent string) {
+	for _, f := range tsFieldsOf(t) {
+		optional := ""
+		if f.Tag.Get("required") == "" {
+			optional = "?"
+		}
+		if desc := f.Tag.Get("desc"); desc != "" {
+			fmt.Fprintf(sb, "%s/** %s */\n", indent, strings.ReplaceAll(desc, "*/", "*\\/"))
+		}
+		fmt.Fprintf(sb, "%s%s%s: %s;\n", indent, strconv.Quote(jsonName(f)), optional, tsType(f.Type, indent))
+	}
+}
+
+func tsType(t reflect.Type, indent string) string {
+	if t.Kind() == reflect.Ptr {
+		return tsType(t.Elem(), indent) + " | null"
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return "string"
+	case t == reflect.TypeOf(json.RawMessage{}), reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		return "unknown"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		item := tsType(t.Elem(), indent)
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case reflect.Map:
+		return "Record<string, " + tsType(t.Elem(), indent) + ">"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return t.Name()
+		}
+		sb := &strings.Builder{}
+		sb.WriteString("{\n")
+		tsFields(sb, t, indent+"  ")
+		sb.WriteString(indent + "}")
+		return sb.String()
+	}
+	return "unknown"
+}
+
+// Zod returns a Zod schema and its inferred type for the struct type of
+// each of values, and for the named structs they use. Besides the types the
+// schemas carry required, max-length, min-items, max-items, unique,
+// max-keys, regexp and the validate formats.
+func Zod(values ...interface{}) (string, error) {
+	types, err := tsTypes(values)
+	if err != nil {
+		return "", err
+	}
+	sb := &strings.Builder{}
+	sb.WriteString("import { z } from \"zod\";\n")
+	done := map[reflect.Type]bool{}
+	for _, t := range types {
+		fmt.Fprintf(sb, "\nexport const %sSchema = %s;\n", t.Name(), zodObject(t, "", done))
+		fmt.Fprintf(sb, "export type %s = z.infer<typeof %sSchema>;\n", t.Name(), t.Name())
+		done[t] = true
+	}
+	return sb.String(), nil
+}
+
+// zodObject is the schema of the struct type t, done has the named structs
+// whose schemas are already declared
+func zodObject(t reflect.Type, indent string, done map[reflect.Type]bool) string {
+	sb := &strings.Builder{}
+	sb.WriteString("z.object({\n")
+	for _, f := range tsFieldsOf(t) {
+		schema := zodType(f, f.Type, indent+"  ", done)
+		if f.Tag.Get("required") == "" {
+			schema += ".optional()"
+		}
+		if desc := f.Tag.Get("desc"); desc != "" {
+			schema += ".describe(" + strconv.Quote(desc) + ")"
+		}
+		fmt.Fprintf(sb, "%s  %s: %s,\n", indent, strconv.Quote(jsonName(f)), schema)
+	}
+	sb.WriteString(indent + "})")
+	return sb.String()
+}
+
+func zodType(f reflect.StructField, t reflect.Type, indent string, done map[reflect.Type]bool) string {
+	if t.Kind() == reflect.Ptr {
+		return zodType(f, t.Elem(), indent, done) + ".nullable()"
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return "z.string().datetime({ offset: true })"
+	case t == reflect.TypeOf(json.RawMessage{}), reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		return "z.unknown()"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return zodString(f)
+	case reflect.Bool:
+		return "z.boolean()"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "z.number().int()"
+	case reflect.Float32, reflect.Float64:
+		return "z.number()"
+	case reflect.Slice, reflect.Array:
+		schema := "z.array(" + zodType(reflect.StructField{Name: f.Name}, t.Elem(), indent, done) + ")"
+		if n := f.Tag.Get("min-items"); n != "" {
+			schema += ".min(" + n + ")"
+		}
+		if n := f.Tag.Get("max-items"); n != "" {
+			schema += ".max(" + n + ")"
+		}
+		if f.Tag.Get("unique") == "true" {
+			schema += `.refine((items) => new Set(items.map((item) => JSON.stringify(item))).size === items.length, "items must be unique")`
+		}
+		return schema
+	case reflect.Map:
+		keyTag, _, _ := compileRules(f.Name, f.Tag.Get("keys"))
+		valueTag, _, _ := compileRules(f.Name, f.Tag.Get("values"))
+		key := zodString(reflect.StructField{Tag: keyTag})
+		schema := "z.record(" + key + ", " + zodType(reflect.StructField{Name: f.Name, Tag: valueTag}, t.Elem(), indent, done) + ")"
+		if n := f.Tag.Get("max-keys"); n != "" {
+			schema += ".refine((record) => Object.keys(record).length <= " + n + `, "too many keys")`
+		}
+		return schema
+	case reflect.Struct:
+		switch {
+		case t.Name() == "":
+			return zodObject(t, indent, done)
+		case done[t]:
+			return t.Name() + "Schema"
+		}
+		// a struct that refers back to one still being declared
+		return "z.lazy(() => " + t.Name() + "Schema)"
+	}
+	return "z.unknown()"
+}
+
+// zodPatterns are the expressions behind the validate types without a Zod
+// method of their own
+var zodPatterns = map[string]string{
+	"slug":    slugRegex.String(),
+	"country": `^[A-Z]{2}$`,
+	"phone":   `^(?:[^0-9x+]*[0-9x+]){10}`,
+	"geohash": `^[0-9b-hjkmnp-zB-HJKMNP-Z]{1,12}$`,
+}
+
+func zodString(f reflect.StructField) string {
+	schema := "z.string()"
+	vType, _ := parseValidateTag(f.Tag.Get("validate"))
+	switch vType {
+	case "email":
+		schema += ".email()"
+	case "uuid":
+		schema += ".uuid()"
+	case "password":
+		schema += ".min(" + strconv.Itoa(DefaultPasswordPolicy.MinLength) + ")"
+	case "slug", "country", "phone", "geohash":
+		schema += ".regex(" + jsRegex(zodPatterns[vType]) + ")"
+	}
+	if pattern := f.Tag.Get("regexp"); pattern != "" {
+		schema += ".regex(" + jsRegex(pattern) + ")"
+	}
+	if f.Tag.Get("required") == "nonzero" {
+		schema += ".min(1)"
+	}
+	if n := f.Tag.Get("max-length"); n != "" {
+		schema += ".max(" + n + ")"
+	}
+	if f.Tag.Get("trimlower") == "true" {
+		schema += ".trim().toLowerCase()"
+	}
+	return schema
+}
+
+// jsRegex writes pattern as a JavaScript regex literal
+func jsRegex(pattern string) string {
+	return "/" + strings.ReplaceAll(pattern, "/", `\/`) + "/"
+}
+
+// tsFieldsOf are the body fields of t with embedded structs flattened
+func tsFieldsOf(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Tag.Get("reqbind") != "-" && indirectType(f.Type).Kind() == reflect.Struct {
+			fields = append(fields, tsFieldsOf(indirectType(f.Type))...)
+			continue
+		}
+		if generated(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// tsTypes are the named struct types of values and the ones they use, each
+// after the types it uses
+func tsTypes(values []interface{}) ([]reflect.Type, error) {
+	var types []reflect.Type
+	seen := map[reflect.Type]bool{}
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+			return
+		}
+		if t.Name() != "" {
+			if seen[t] {
+				return
+			}
+			seen[t] = true
+		}
+		for _, f := range tsFieldsOf(t) {
+			visit(f.Type)
+		}
+		if t.Name() != "" {
+			types = append(types, t)
+		}
+	}
+	for _, v := range values {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%T is not a struct", v)
+		}
+		if t.Name() == "" {
+			return nil, fmt.Errorf("%s needs a type name", t)
+		}
+		visit(t)
+	}
+	return types, nil
+}
@@ -0,0 +1,93 @@
+package reqbind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tsAddress struct {
+	Country string `json:"country" required:"true" validate:"country"`
+	Zip     string `json:"zip" regexp:"^[0-9]{5}/?$"`
+}
+
+type tsCategory struct {
+	Name   string      `json:"name" required:"nonzero"`
+	Parent *tsCategory `json:"parent"`
+	Items  []tsAddress `json:"items" max-items:"2"`
+}
+
+type tsSignup struct {
+	Email   string            `json:"email" required:"true" validate:"email" trimlower:"true"`
+	Handle  string            `json:"handle" max-length:"12"`
+	Seats   int               `json:"seats"`
+	Since   *time.Time        `json:"since"`
+	Tags    []string          `json:"tags" min-items:"1" unique:"true"`
+	Labels  map[string]string `json:"labels" max-keys:"2" keys:"validate=slug"`
+	Address tsAddress         `json:"address"`
+	Meta    struct {
+		Source string `json:"source"`
+	} `json:"meta"`
+	Owner  string `json:"owner" readonly:"true"`
+	Tenant string `json:"tenant" header:"X-Tenant"`
+}
+
+func TestTypeScript(t *testing.T) {
+	ts, err := TypeScript(&tsSignup{})
+	require.NoError(t, err)
+	require.Equal(t, `export interface tsAddress {
+  "country": string;
+  "zip"?: string;
+}
+
+export interface tsSignup {
+  "email": string;
+  "handle"?: string;
+  "seats"?: number;
+  "since"?: string | null;
+  "tags"?: string[];
+  "labels"?: Record<string, string>;
+  "address"?: tsAddress;
+  "meta"?: {
+    "source"?: string;
+  };
+}
+`, ts)
+
+	_, err = TypeScript("nope")
+	require.EqualError(t, err, "string is not a struct")
+	_, err = TypeScript(struct{}{})
+	require.EqualError(t, err, "struct {} needs a type name")
+}
+
+func TestZod(t *testing.T) {
+	zod, err := Zod(tsSignup{})
+	require.NoError(t, err)
+	require.Contains(t, zod, `import { z } from "zod";`)
+	require.Contains(t, zod, `export const tsAddressSchema = z.object({
+  "country": z.string().regex(/^[A-Z]{2}$/),
+  "zip": z.string().regex(/^[0-9]{5}\/?$/).optional(),
+});`)
+	require.Contains(t, zod, `  "email": z.string().email().trim().toLowerCase(),
+  "handle": z.string().max(12).optional(),
+  "seats": z.number().int().optional(),
+  "since": z.string().datetime({ offset: true }).nullable().optional(),
+  "tags": z.array(z.string()).min(1).refine(`)
+	require.Contains(t, zod, `  "labels": z.record(z.string().regex(/^[a-z0-9]+(-[a-z0-9]+)*$/), z.string()).refine((record) => Object.keys(record).length <= 2, "too many keys").optional(),
+  "address": tsAddressSchema.optional(),
+  "meta": z.object({
+    "source": z.string().optional(),
+  }).optional(),
+});
+export type tsSignup = z.infer<typeof tsSignupSchema>;`)
+	require.NotContains(t, zod, "owner")
+	require.NotContains(t, zod, "tenant")
+
+	// types that refer back to themselves are lazy
+	zod, err = Zod(tsCategory{})
+	require.NoError(t, err)
+	require.Contains(t, zod, `  "name": z.string().min(1),
+  "parent": z.lazy(() => tsCategorySchema).nullable().optional(),
+  "items": z.array(tsAddressSchema).max(2).optional(),`)
+}
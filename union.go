@@ -0,0 +1,91 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// OneOf2 is a field that takes either of two json shapes, e.g. a string id
+// or an embedded object:
+//
+//	Owner reqbind.OneOf2[string, User] `json:"owner" required:"true"`
+//
+// A is tried first, and objects must match a struct exactly, unknown keys
+// and all, so the two can be told apart. The field's tags are checked when
+// a string variant is bound, struct variants are checked with their own.
+type OneOf2[A, B any] struct {
+	a     A
+	b     B
+	which int
+}
+
+// A returns the first variant and whether it was the one bound
+func (o OneOf2[A, B]) A() (A, bool) {
+	return o.a, o.which == 1
+}
+
+// B returns the second variant and whether it was the one bound
+func (o OneOf2[A, B]) B() (B, bool) {
+	return o.b, o.which == 2
+}
+
+// Which is 1 or 2 for the variant that was bound, 0 when neither was
+func (o OneOf2[A, B]) Which() int {
+	return o.which
+}
+
+// UnmarshalJSON binds data into the first variant it fits
+func (o *OneOf2[A, B]) UnmarshalJSON(data []byte) error {
+	*o = OneOf2[A, B]{}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if decodeStrict(data, &o.a) == nil {
+		o.which = 1
+		return nil
+	}
+	o.a = *new(A)
+	if decodeStrict(data, &o.b) == nil {
+		o.which = 2
+		return nil
+	}
+	o.b = *new(B)
+	return fmt.Errorf("value is neither %s nor %s", reflect.TypeOf(o.a), reflect.TypeOf(o.b))
+}
+
+// MarshalJSON writes the bound variant, or null
+func (o OneOf2[A, B]) MarshalJSON() ([]byte, error) {
+	switch o.which {
+	case 1:
+		return json.Marshal(o.a)
+	case 2:
+		return json.Marshal(o.b)
+	}
+	return []byte("null"), nil
+}
+
+// variant is the bound variant as a settable value, invalid when neither
+// was bound
+func (o *OneOf2[A, B]) variant() reflect.Value {
+	switch o.which {
+	case 1:
+		return reflect.ValueOf(&o.a).Elem()
+	case 2:
+		return reflect.ValueOf(&o.b).Elem()
+	}
+	return reflect.Value{}
+}
+
+// union is a field type holding one of several variants
+type union interface {
+	variant() reflect.Value
+}
+
+// decodeStrict decodes data into v, rejecting keys v doesn't have
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,105 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type unionUser struct {
+	ID   string `json:"id" required:"true"`
+	Name string `json:"name" max-length:"5"`
+}
+
+type unionRequest struct {
+	Owner OneOf2[string, unionUser] `json:"owner" required:"true" max-length:"8"`
+	Size  OneOf2[int, string]       `json:"size"`
+}
+
+func TestOneOf2(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		check func(t *testing.T, k *unionRequest)
+		error string
+	}{
+		{
+			name: "string",
+			body: `{"owner":"u1","size":"large"}`,
+			check: func(t *testing.T, k *unionRequest) {
+				id, ok := k.Owner.A()
+				require.True(t, ok)
+				require.Equal(t, "u1", id)
+				_, ok = k.Owner.B()
+				require.False(t, ok)
+				require.Equal(t, 2, k.Size.Which())
+			},
+		},
+		{
+			name: "object",
+			body: `{"owner":{"id":"u2","name":"bob"},"size":3}`,
+			check: func(t *testing.T, k *unionRequest) {
+				user, ok := k.Owner.B()
+				require.True(t, ok)
+				require.Equal(t, unionUser{ID: "u2", Name: "bob"}, user)
+				size, _ := k.Size.A()
+				require.Equal(t, 3, size)
+			},
+		},
+		{name: "string tags", body: `{"owner":"a-long-user-id"}`, error: "field Owner is too long"},
+		{name: "object tags", body: `{"owner":{"name":"bob"}}`, error: "field ID is required"},
+		{name: "nested object tags", body: `{"owner":{"id":"u3","name":"robert"}}`, error: "field Name is too long"},
+		{name: "neither", body: `{"owner":true}`, error: "value is neither string nor reqbind.unionUser"},
+		{name: "unknown keys", body: `{"owner":{"id":"u4","email":"a@b.com"}}`, error: "value is neither string nor reqbind.unionUser"},
+		{name: "missing", body: `{}`, error: "field Owner is required"},
+		{
+			// the key was sent, use required:"nonzero" to insist on a value
+			name: "null",
+			body: `{"owner":null}`,
+			check: func(t *testing.T, k *unionRequest) {
+				require.Equal(t, 0, k.Owner.Which())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &unionRequest{}
+			err = UnmarshalBody(request, k)
+			if test.error != "" {
+				require.ErrorContains(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, k)
+		})
+	}
+}
+
+func TestOneOf2Marshal(t *testing.T) {
+	k := &unionRequest{}
+	require.NoError(t, json.Unmarshal([]byte(`{"owner":{"id":"u1","name":"bob"},"size":"large"}`), k))
+	out, err := json.Marshal(k)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"owner":{"id":"u1","name":"bob"},"size":"large"}`, string(out))
+
+	out, err = json.Marshal(&unionRequest{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"owner":null,"size":null}`, string(out))
+}
+
+func TestOneOf2Query(t *testing.T) {
+	request, err := http.NewRequest("GET", "/?owner=u1&size=7", nil)
+	require.NoError(t, err)
+	k := &unionRequest{}
+	require.NoError(t, UnmarshalQuery(request, k))
+	id, _ := k.Owner.A()
+	require.Equal(t, "u1", id)
+	size, _ := k.Size.A()
+	require.Equal(t, 7, size)
+}
@@ -0,0 +1,151 @@
+package reqbind
+
+import (
+	"fmt"
+	"image"
+	// the formats image.DecodeConfig knows without extra imports
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"mime"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// checkFile runs the upload tags of f against file. allowed-ext:"jpg,png"
+// lists the extensions the filename can have and sniff:"image/*" the types
+// the content can sniff as. With allowed-ext, content that sniffs as a
+// different type than its extension stands for is rejected too, so a
+// renamed executable can't pass as a png.
+func checkFile(f reflect.StructField, file *File) error {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	sniffed, _, _ := strings.Cut(file.ContentType, ";")
+	if tag := f.Tag.Get("allowed-ext"); tag != "" {
+		if !containsFold(splitList(tag), ext) {
+			if ext == "" {
+				return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: file has no extension")
+			}
+			return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: extension .%s is not allowed", ext)
+		}
+		if expected, _, _ := strings.Cut(mime.TypeByExtension("."+ext), ";"); expected != "" && sniffable(sniffed) && expected != sniffed {
+			return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: content is %s, not .%s", sniffed, ext)
+		}
+	}
+	if tag := f.Tag.Get("sniff"); tag != "" && !matchesMediaType(splitList(tag), sniffed) {
+		return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: content type %s is not allowed", sniffed)
+	}
+	return checkImageSize(f, file)
+}
+
+// maxFileSize is the max-file-size tag of f in bytes, 0 when there's none
+func maxFileSize(f reflect.StructField) (int64, error) {
+	tag := f.Tag.Get("max-file-size")
+	if tag == "" {
+		return 0, nil
+	}
+	n, err := parseByteSize(tag)
+	if err != nil || n <= 0 {
+		return 0, fieldErrorf(CodeInvalidTag, f.Name, "has invalid max-file-size")
+	}
+	return n, nil
+}
+
+// byteUnits are the units of a byte size, in multiples of 1024
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+}
+
+// parseByteSize parses sizes such as 512, 100KB or 5MB
+func parseByteSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	size := int64(1)
+	for _, unit := range byteUnits {
+		if number, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, size = strings.TrimSpace(number), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/size {
+		return 0, fmt.Errorf("invalid size %s", s)
+	}
+	return n * size, nil
+}
+
+// checkImageSize checks an image against the max-width and max-height tags.
+// Only the image's header is decoded, formats other than png, jpeg and gif
+// have to be registered with the image package, e.g. by importing
+// golang.org/x/image/webp.
+func checkImageSize(f reflect.StructField, file *File) error {
+	maxWidth, maxHeight := f.Tag.Get("max-width"), f.Tag.Get("max-height")
+	if maxWidth == "" && maxHeight == "" {
+		return nil
+	}
+	content, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	config, _, err := image.DecodeConfig(content)
+	if err != nil {
+		return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: file is not a supported image")
+	}
+	for _, limit := range []struct {
+		tag   string
+		name  string
+		size  int
+		label string
+	}{
+		{maxWidth, "max-width", config.Width, "wider"},
+		{maxHeight, "max-height", config.Height, "taller"},
+	} {
+		if limit.tag == "" {
+			continue
+		}
+		n, err := strconv.Atoi(limit.tag)
+		if err != nil || n <= 0 {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has invalid %s", limit.name)
+		}
+		if limit.size > n {
+			return fieldErrorf(CodeInvalidFile, f.Name, "is invalid: image is %s than %d pixels", limit.label, n)
+		}
+	}
+	return nil
+}
+
+// sniffable is false for the types http.DetectContentType falls back to,
+// they don't say what the content really is
+func sniffable(contentType string) bool {
+	return contentType != "application/octet-stream" && !strings.HasPrefix(contentType, "text/")
+}
+
+// matchesMediaType reports whether contentType is one of patterns, which
+// can end in /* to match a whole top level type
+func matchesMediaType(patterns []string, contentType string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+		if strings.EqualFold(pattern, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma separated tag into its trimmed, non empty items
+func splitList(tag string) []string {
+	var items []string
+	for _, item := range strings.Split(tag, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
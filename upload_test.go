@@ -0,0 +1,97 @@
+package reqbind
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadTypes(t *testing.T) {
+	type upload struct {
+		Image *File `file:"image" allowed-ext:"jpg,jpeg,png" sniff:"image/*"`
+		Doc   *File `file:"doc" allowed-ext:"pdf,txt"`
+		Any   *File `file:"any" sniff:"image/png,application/pdf"`
+	}
+	tests := []struct {
+		name     string
+		part     testPart
+		expected string
+	}{
+		{name: "png", part: testPart{name: "image", filename: "me.PNG", content: pngHeader}},
+		{name: "jpeg", part: testPart{name: "image", filename: "me.jpg", content: "\xff\xd8\xff\xe0"}},
+		{name: "text", part: testPart{name: "doc", filename: "notes.txt", content: "hello"}},
+		{name: "sniff only", part: testPart{name: "any", filename: "blob", content: "%PDF-1.7"}},
+		{name: "extension not allowed", part: testPart{name: "image", filename: "me.gif", content: "GIF89a"}, expected: "field Image is invalid: extension .gif is not allowed"},
+		{name: "no extension", part: testPart{name: "image", filename: "me", content: pngHeader}, expected: "field Image is invalid: file has no extension"},
+		{name: "renamed", part: testPart{name: "image", filename: "me.png", content: "\xff\xd8\xff\xe0"}, expected: "field Image is invalid: content is image/jpeg, not .png"},
+		{name: "renamed pdf", part: testPart{name: "doc", filename: "report.pdf", content: pngHeader}, expected: "field Doc is invalid: content is image/png, not .pdf"},
+		{name: "not an image", part: testPart{name: "image", filename: "me.png", content: "just text"}, expected: "field Image is invalid: content type text/plain is not allowed"},
+		{name: "sniff mismatch", part: testPart{name: "any", filename: "blob", content: "GIF89a"}, expected: "field Any is invalid: content type image/gif is not allowed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := UnmarshalMultipart(multipartRequest(t, test.part), &upload{})
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+// encodePNG makes a blank png of the given size
+func encodePNG(t *testing.T, width int, height int) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, image.NewGray(image.Rect(0, 0, width, height))))
+	return buf.String()
+}
+
+func TestUploadSize(t *testing.T) {
+	type upload struct {
+		Photo *File `file:"photo" max-file-size:"1KB" max-width:"64" max-height:"32"`
+		Doc   *File `file:"doc" max-file-size:"10"`
+	}
+	tests := []struct {
+		name     string
+		part     testPart
+		expected string
+	}{
+		{name: "fits", part: testPart{name: "photo", filename: "a.png", content: encodePNG(t, 64, 32)}},
+		{name: "exact size", part: testPart{name: "doc", filename: "a.txt", content: "0123456789"}},
+		{name: "too large", part: testPart{name: "doc", filename: "a.txt", content: "0123456789a"}, expected: "field Doc is invalid: file is larger than 10"},
+		{name: "too wide", part: testPart{name: "photo", filename: "a.png", content: encodePNG(t, 65, 32)}, expected: "field Photo is invalid: image is wider than 64 pixels"},
+		{name: "too tall", part: testPart{name: "photo", filename: "a.png", content: encodePNG(t, 10, 33)}, expected: "field Photo is invalid: image is taller than 32 pixels"},
+		{name: "not an image", part: testPart{name: "photo", filename: "a.png", content: "hello"}, expected: "field Photo is invalid: file is not a supported image"},
+		{name: "huge image file", part: testPart{name: "photo", filename: "a.png", content: encodePNG(t, 64, 32) + string(make([]byte, 1024))}, expected: "field Photo is invalid: file is larger than 1KB"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := UnmarshalMultipart(multipartRequest(t, test.part), &upload{})
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{"512": 512, "512B": 512, "100KB": 100 << 10, "5MB": 5 << 20, "5 mb": 5 << 20, "2GB": 2 << 30}
+	for s, expected := range tests {
+		n, err := parseByteSize(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, n, s)
+	}
+	for _, s := range []string{"", "MB", "5TB", "-1KB", "1.5MB", "99999999999GB"} {
+		_, err := parseByteSize(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,141 @@
+package reqbind
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// UserAgent is a parsed User-Agent header
+type UserAgent struct {
+	Browser        string `json:"browser"`
+	BrowserVersion string `json:"browserVersion"`
+	OS             string `json:"os"`
+	OSVersion      string `json:"osVersion"`
+	// Device is desktop, mobile, tablet or bot
+	Device string `json:"device"`
+}
+
+// UserAgentParser turns a User-Agent header into a UserAgent, plug in a
+// full parser such as uap-go with WithUserAgentParser
+type UserAgentParser interface {
+	Parse(userAgent string) UserAgent
+}
+
+// UserAgentParserFunc adapts a function to UserAgentParser
+type UserAgentParserFunc func(userAgent string) UserAgent
+
+// Parse calls f
+func (f UserAgentParserFunc) Parse(userAgent string) UserAgent {
+	return f(userAgent)
+}
+
+// WithUserAgentParser sets the parser for useragent fields, the default is
+// ParseUserAgent
+func WithUserAgentParser(parser UserAgentParser) Option {
+	return func(b *Binder) {
+		b.userAgent = parser
+	}
+}
+
+var (
+	browserPatterns = []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		// order matters, Edge and Opera also claim to be Chrome and Safari
+		{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/([\d.]+)`)},
+		{"Opera", regexp.MustCompile(`OPR/([\d.]+)`)},
+		{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+		{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+		{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	}
+	osPatterns = []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+		{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS ([\d_]+)`)},
+		{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+		{"Android", regexp.MustCompile(`Android ([\d.]+)`)},
+		{"Linux", regexp.MustCompile(`Linux()`)},
+	}
+	botPattern = regexp.MustCompile(`(?i)bot|crawler|spider|curl|wget`)
+)
+
+// ParseUserAgent is a small parser for the common browsers and operating
+// systems. Anything it doesn't recognise is left empty.
+func ParseUserAgent(userAgent string) UserAgent {
+	ua := UserAgent{Device: "desktop"}
+	for _, browser := range browserPatterns {
+		if m := browser.pattern.FindStringSubmatch(userAgent); m != nil {
+			ua.Browser, ua.BrowserVersion = browser.name, m[1]
+			break
+		}
+	}
+	for _, os := range osPatterns {
+		if m := os.pattern.FindStringSubmatch(userAgent); m != nil {
+			ua.OS, ua.OSVersion = os.name, strings.ReplaceAll(m[1], "_", ".")
+			break
+		}
+	}
+	switch {
+	case botPattern.MatchString(userAgent):
+		ua.Device = "bot"
+	case strings.Contains(userAgent, "iPad") || (ua.OS == "Android" && !strings.Contains(userAgent, "Mobile")):
+		ua.Device = "tablet"
+	case strings.Contains(userAgent, "Mobi") || strings.Contains(userAgent, "iPhone"):
+		ua.Device = "mobile"
+	}
+	return ua
+}
+
+// bindUserAgent sets the fields tagged useragent. A UserAgent field takes
+// useragent:"true", string fields name the part they want: browser,
+// browser-version, os, os-version or device.
+func (b *Binder) bindUserAgent(r *http.Request, v interface{}, presence *Presence) error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	var ua *UserAgent
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		part := f.Tag.Get("useragent")
+		if part == "" || isIgnored(f) {
+			continue
+		}
+		if ua == nil {
+			parsed := b.userAgent.Parse(r.UserAgent())
+			ua = &parsed
+		}
+
+		var value string
+		switch part {
+		case "true":
+			if f.Type != reflect.TypeOf(UserAgent{}) {
+				return fieldErrorf(CodeInvalidTag, f.Name, "has useragent:\"true\" but is not a UserAgent")
+			}
+			rv.Field(i).Set(reflect.ValueOf(*ua))
+			continue
+		case "browser":
+			value = ua.Browser
+		case "browser-version":
+			value = ua.BrowserVersion
+		case "os":
+			value = ua.OS
+		case "os-version":
+			value = ua.OSVersion
+		case "device":
+			value = ua.Device
+		default:
+			return fieldErrorf(CodeInvalidTag, f.Name, "has unknown useragent %s", part)
+		}
+		if err := setFromString(rv.Field(i), value); err != nil {
+			return fieldErrorf(CodeInvalidTag, f.Name, "has useragent but %s", err)
+		}
+		if presence != nil && value != "" {
+			presence.add(jsonName(f))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package reqbind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		expected  UserAgent
+	}{
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected:  UserAgent{Browser: "Chrome", BrowserVersion: "120.0.0.0", OS: "Windows", OSVersion: "10.0", Device: "desktop"},
+		},
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			expected:  UserAgent{Browser: "Edge", BrowserVersion: "120.0.2210.91", OS: "Windows", OSVersion: "10.0", Device: "desktop"},
+		},
+		{
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+			expected:  UserAgent{Browser: "Safari", BrowserVersion: "17.2", OS: "iOS", OSVersion: "17.2", Device: "mobile"},
+		},
+		{
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0",
+			expected:  UserAgent{Browser: "Firefox", BrowserVersion: "121.0", OS: "macOS", OSVersion: "10.15", Device: "desktop"},
+		},
+		{
+			userAgent: "Mozilla/5.0 (Linux; Android 14; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected:  UserAgent{Browser: "Chrome", BrowserVersion: "120.0.0.0", OS: "Android", OSVersion: "14", Device: "tablet"},
+		},
+		{
+			userAgent: "Googlebot/2.1 (+http://www.google.com/bot.html)",
+			expected:  UserAgent{Device: "bot"},
+		},
+		{
+			userAgent: "",
+			expected:  UserAgent{Device: "desktop"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.userAgent, func(t *testing.T) {
+			require.Equal(t, test.expected, ParseUserAgent(test.userAgent))
+		})
+	}
+}
+
+func TestBindUserAgent(t *testing.T) {
+	type analytics struct {
+		Browser string    `json:"browser" useragent:"browser" required:"true"`
+		OS      string    `json:"os" useragent:"os"`
+		Agent   UserAgent `json:"agent" useragent:"true"`
+	}
+
+	request, err := http.NewRequest("GET", "/?browser=Netscape", nil)
+	require.NoError(t, err)
+	request.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0")
+	k := &analytics{}
+	require.NoError(t, Bind(request, k))
+	require.Equal(t, "Firefox", k.Browser)
+	require.Equal(t, "Linux", k.OS)
+	require.Equal(t, "121.0", k.Agent.BrowserVersion)
+
+	// a custom parser
+	binder := New(WithUserAgentParser(UserAgentParserFunc(func(string) UserAgent {
+		return UserAgent{Browser: "Custom"}
+	})))
+	k = &analytics{}
+	require.NoError(t, binder.UnmarshalHeaders(request, k))
+	require.Equal(t, "Custom", k.Browser)
+
+	require.Error(t, Bind(request, &struct {
+		Browser string `useragent:"engine"`
+	}{}))
+	require.Error(t, Bind(request, &struct {
+		Agent string `useragent:"true"`
+	}{}))
+}
@@ -0,0 +1,85 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// InvalidUTF8Mode decides what happens to invalid UTF-8 in bound strings
+type InvalidUTF8Mode int
+
+const (
+	// InvalidUTF8Replace replaces every invalid sequence with U+FFFD, the
+	// way encoding/json does
+	InvalidUTF8Replace InvalidUTF8Mode = iota
+	// InvalidUTF8Reject fails the bind when the body, query, path parameters
+	// or bound headers have invalid UTF-8
+	InvalidUTF8Reject
+)
+
+// WithInvalidUTF8 sets how invalid UTF-8 is handled, the default is
+// InvalidUTF8Replace
+func WithInvalidUTF8(mode InvalidUTF8Mode) Option {
+	return func(b *Binder) {
+		b.invalidUTF8 = mode
+	}
+}
+
+// checkUTF8 rejects invalid UTF-8 in the parts of the request v binds from,
+// other than the body, in InvalidUTF8Reject mode
+func (b *Binder) checkUTF8(r *http.Request, v interface{}) error {
+	if b.invalidUTF8 != InvalidUTF8Reject {
+		return nil
+	}
+	for key, values := range r.URL.Query() {
+		if !utf8.ValidString(key) {
+			return fmt.Errorf("query parameter has invalid UTF-8")
+		}
+		for _, value := range values {
+			if !utf8.ValidString(value) {
+				return fmt.Errorf("query parameter %s has invalid UTF-8", key)
+			}
+		}
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		for i, value := range rctx.URLParams.Values {
+			if !utf8.ValidString(value) {
+				return fmt.Errorf("path parameter %s has invalid UTF-8", rctx.URLParams.Keys[i])
+			}
+		}
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("header")
+		if name == "" && strings.Contains(f.Tag.Get("source"), "header") {
+			name = jsonName(f)
+		}
+		if name == "" {
+			continue
+		}
+		for _, value := range r.Header.Values(name) {
+			if !utf8.ValidString(value) {
+				return fmt.Errorf("header %s has invalid UTF-8", name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkUTF8Body rejects a body with invalid UTF-8 in InvalidUTF8Reject mode
+func (b *Binder) checkUTF8Body(bodyBytes []byte) error {
+	if b.invalidUTF8 == InvalidUTF8Reject && !utf8.Valid(bodyBytes) {
+		return fmt.Errorf("body has invalid UTF-8")
+	}
+	return nil
+}
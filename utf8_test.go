@@ -0,0 +1,65 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type utf8Request struct {
+	Name  string `json:"name"`
+	Trace string `json:"trace" header:"X-Trace"`
+}
+
+func TestInvalidUTF8Replace(t *testing.T) {
+	k := &utf8Request{}
+	request, err := http.NewRequest("POST", "/", strings.NewReader("{\"name\":\"a\xffb\"}"))
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalBody(request, k))
+	require.Equal(t, "a�b", k.Name)
+
+	k = &utf8Request{}
+	request, err = http.NewRequest("GET", "/?name=a%FFb", nil)
+	require.NoError(t, err)
+	request.Header.Set("X-Trace", "t\xff")
+	require.NoError(t, Bind(request, k))
+	require.True(t, utf8.ValidString(k.Name))
+	require.True(t, utf8.ValidString(k.Trace))
+}
+
+func TestInvalidUTF8Reject(t *testing.T) {
+	binder := New(WithInvalidUTF8(InvalidUTF8Reject))
+
+	request, err := http.NewRequest("POST", "/", strings.NewReader("{\"name\":\"a\xffb\"}"))
+	require.NoError(t, err)
+	require.EqualError(t, binder.UnmarshalBody(request, &utf8Request{}), "body has invalid UTF-8")
+
+	request, err = http.NewRequest("GET", "/?name=a%FFb", nil)
+	require.NoError(t, err)
+	require.EqualError(t, binder.UnmarshalQuery(request, &utf8Request{}), "query parameter name has invalid UTF-8")
+
+	request, err = http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	request.Header.Set("X-Trace", "t\xff")
+	require.EqualError(t, binder.Bind(request, &utf8Request{}), "header X-Trace has invalid UTF-8")
+
+	// headers that aren't bound aren't checked
+	request.Header.Del("X-Trace")
+	request.Header.Set("X-Other", "t\xff")
+	require.NoError(t, binder.Bind(request, &utf8Request{}))
+
+	called := false
+	router := chi.NewRouter()
+	router.Get("/{name}", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		require.EqualError(t, binder.UnmarshalURLParams(r, &utf8Request{}), "path parameter name has invalid UTF-8")
+	})
+	request, err = http.NewRequest("GET", "/a%FFb", nil)
+	require.NoError(t, err)
+	router.ServeHTTP(nil, request)
+	require.True(t, called)
+}
@@ -0,0 +1,43 @@
+package reqbind
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BindValues binds values that didn't come from a request, e.g. a queue
+// message's attributes or flags, the way UnmarshalQuery binds a query
+// string, and checks v's tags
+func BindValues(values url.Values, v interface{}) error {
+	return defaultBinder.BindValues(values, v)
+}
+
+// BindValues binds values like the package level BindValues
+func (b *Binder) BindValues(values url.Values, v interface{}) error {
+	// the values are already decoded, escaping them keeps a + or % in a
+	// value from being unescaped a second time
+	r := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{RawQuery: escapeFormValues(values).Encode()},
+		Header: http.Header{},
+	}
+	return b.UnmarshalQuery(r, v)
+}
+
+// BindJSON binds json read from body, e.g. a queue message or a test
+// fixture, the way UnmarshalBody binds a request body, and checks v's tags
+func BindJSON(body io.Reader, v interface{}) error {
+	return defaultBinder.BindJSON(body, v)
+}
+
+// BindJSON binds json like the package level BindJSON
+func (b *Binder) BindJSON(body io.Reader, v interface{}) error {
+	r := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{},
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   io.NopCloser(body),
+	}
+	return b.UnmarshalBody(r, v)
+}
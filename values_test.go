@@ -0,0 +1,45 @@
+package reqbind
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type jobMessage struct {
+	ID       string   `json:"id" required:"true" validate:"uuid"`
+	Attempts int      `json:"attempts"`
+	Tags     []string `json:"tags"`
+	Note     string   `json:"note" max-length:"20"`
+}
+
+func TestBindValues(t *testing.T) {
+	v := &jobMessage{}
+	err := BindValues(url.Values{
+		"id":       {"0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11"},
+		"tags[]":   {"a", "b"},
+		"attempts": {"2"},
+		"note":     {"1+1 is 100%"},
+	}, v)
+	require.NoError(t, err)
+	require.Equal(t, jobMessage{ID: "0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11", Attempts: 2, Tags: []string{"a", "b"}, Note: "1+1 is 100%"}, *v)
+
+	require.EqualError(t, BindValues(url.Values{}, &jobMessage{}), "field ID is required")
+	require.EqualError(t, BindValues(url.Values{"id": {"nope"}}, &jobMessage{}), "field ID is invalid: invalid uuid")
+
+	binder := New(WithStrictQuery())
+	require.EqualError(t, binder.BindValues(url.Values{"id": {"0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11"}, "idd": {"x"}}, &jobMessage{}), "parameter idd is unknown")
+}
+
+func TestBindJSON(t *testing.T) {
+	v := &jobMessage{}
+	require.NoError(t, BindJSON(strings.NewReader(`{"id":"0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11","tags":["a"]}`), v))
+	require.Equal(t, jobMessage{ID: "0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11", Tags: []string{"a"}}, *v)
+
+	require.EqualError(t, BindJSON(strings.NewReader(`{"note":"x"}`), &jobMessage{}), "field ID is required")
+
+	binder := New(WithMaxBodyBytes(8))
+	require.ErrorIs(t, binder.BindJSON(strings.NewReader(`{"id":"0b4e8f3a-7a55-4c49-9f0f-3f2d5d8f7b11"}`), &jobMessage{}), ErrBodyTooLarge)
+}
@@ -0,0 +1,186 @@
+package reqbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Verbosity is how much detail bind errors carry
+type Verbosity int
+
+const (
+	// VerbosityStandard keeps the messages as they are, the default
+	VerbosityStandard Verbosity = iota
+	// VerbosityProduction leaves submitted values and Go type details out
+	// of messages so nothing the client sent is reflected back. Field errors
+	// keep their Field and Code with a fixed message for the code, and
+	// errors that aren't about a field become "request is invalid".
+	VerbosityProduction
+	// VerbosityDebug adds the submitted value to field errors, e.g.
+	// `field Email is invalid: invalid email address (got "jane@")`.
+	// Password fields are left out.
+	VerbosityDebug
+)
+
+// WithVerbosity sets how much detail bind errors carry, the default is
+// VerbosityStandard
+func WithVerbosity(v Verbosity) Option {
+	return func(b *Binder) {
+		b.verbosity = v
+	}
+}
+
+// codeMessages are the production messages of the codes
+var codeMessages = map[Code]string{
+	CodeRequired:        "is required",
+	CodeTooLong:         "is too long",
+	CodeTooManyItems:    "has too many items",
+	CodeTooFewItems:     "has too few items",
+	CodeDuplicateItems:  "has duplicate items",
+	CodeTooManyKeys:     "has too many keys",
+	CodeOutOfRange:      "is out of range",
+	CodeInvalidEmail:    "is not a valid email address",
+	CodeInvalidPhone:    "is not a valid phone number",
+	CodeInvalidUUID:     "is not a valid uuid",
+	CodeInvalidCountry:  "is not a valid country code",
+	CodeInvalidAddress:  "is not a valid address",
+	CodeInvalidLocation: "is not a valid location",
+	CodeInvalidFile:     "is not an allowed file",
+	CodeInvalidSlug:     "is not a valid slug",
+	CodeWeakPassword:    "is too weak",
+	CodeInvalidURL:      "is not a valid url",
+	CodeInvalidFormat:   "has an invalid format",
+	CodeInvalidType:     "has the wrong type",
+	CodeInvalid:         "is invalid",
+	CodeNotOneOf:        "is not one of the allowed values",
+	CodeNotAllowed:      "is not allowed",
+	CodeReadOnly:        "is read only",
+	CodeConflict:        "was sent more than once",
+	CodeTooDeep:         "is nested too deeply",
+	CodeInvalidTag:      "can not be bound",
+}
+
+// redact applies the binder's verbosity to the error of an entry point. It's
+// deferred before recoverPanic so panics are redacted too.
+func (b *Binder) redact(err *error) {
+	if *err != nil && b.verbosity == VerbosityProduction {
+		*err = redactError(*err)
+	}
+}
+
+// redactError rewrites err without values or type details
+func redactError(err error) error {
+	switch e := err.(type) {
+	case Errors:
+		redacted := make(Errors, len(e))
+		for i, err := range e {
+			redacted[i] = redactError(err)
+		}
+		return redacted
+	case *hintError:
+		return &hintError{err: redactError(e.err), hint: e.hint}
+	case *elementError:
+		return &elementError{field: e.field, index: e.index, err: redactError(e.err)}
+	case *FieldError:
+		// forbidden errors have no value in them and wrap ErrForbidden
+		if e.Code == CodeForbidden {
+			return e
+		}
+		message, ok := codeMessages[e.Code]
+		if !ok {
+			message = codeMessages[CodeInvalid]
+		}
+		subject := e.subject
+		if e.Field != "" {
+			subject += " " + e.Field
+		}
+		return &FieldError{Field: e.Field, Code: e.Code, subject: e.subject, err: errors.New(subject + " " + message)}
+	}
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return fieldErrorf(CodeInvalidType, typeErr.Field, "has the wrong type")
+	case errors.As(err, &typeErr), errors.As(err, &syntaxErr), err == errNotJSON:
+		return errNotJSON
+	}
+	for _, safe := range safeErrors {
+		if errors.Is(err, safe) {
+			return err
+		}
+	}
+	return errInvalidRequest
+}
+
+// safeErrors are the errors whose messages never have submitted values in
+// them, they're kept in production
+var safeErrors = []error{
+	ErrForbidden,
+	ErrInvalidSignature,
+	ErrNotWebSocket,
+	ErrThrottled,
+	ErrBindTimeout,
+	ErrBodyTooLarge,
+	ErrTruncatedBody,
+	ErrContentLength,
+	ErrChecksum,
+	ErrInfected,
+}
+
+var (
+	errNotJSON        = errors.New("body is not valid json")
+	errInvalidRequest = errors.New("request is invalid")
+)
+
+// withValue adds the submitted value to f's error in debug mode. Only
+// scalar values are added, errors from nested fields have their own.
+func withValue(err error, f reflect.StructField, value reflect.Value, opts checkOptions) error {
+	if err == nil || !opts.values {
+		return err
+	}
+	if vType, _ := parseValidateTag(f.Tag.Get("validate")); vType == "password" {
+		return err
+	}
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return err
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return addValue(err, fmt.Sprintf("%q", value.String()))
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return addValue(err, fmt.Sprint(value.Interface()))
+	}
+	return err
+}
+
+func addValue(err error, value string) error {
+	if list, ok := err.(Errors); ok {
+		valued := make(Errors, len(list))
+		for i, err := range list {
+			valued[i] = addValue(err, value)
+		}
+		return valued
+	}
+	return &valueError{err: err, value: value}
+}
+
+// valueError is a field error with the value that was sent
+type valueError struct {
+	err   error
+	value string
+}
+
+func (e *valueError) Error() string {
+	return e.err.Error() + " (got " + e.value + ")"
+}
+
+func (e *valueError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,60 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type verbositySignup struct {
+	Email    string `json:"email" required:"true" validate:"email"`
+	Password string `json:"password" validate:"password"`
+	Age      int    `json:"age"`
+	Handle   string `json:"handle" regexp:"^[a-z]+$"`
+}
+
+func TestVerbosity(t *testing.T) {
+	tests := []struct {
+		name     string
+		binder   *Binder
+		body     string
+		expected string
+	}{
+		{name: "standard", binder: New(), body: `{"email":"a@b.co","password":"Correct-Horse-9","handle":"Jane"}`, expected: "field Handle is invalid: does not match ^[a-z]+$"},
+		{name: "production field", binder: New(WithVerbosity(VerbosityProduction)), body: `{"email":"a@b.co","password":"Correct-Horse-9","handle":"Jane"}`, expected: "field Handle has an invalid format"},
+		{name: "production type", binder: New(WithVerbosity(VerbosityProduction)), body: `{"email":"a@b.co","age":"old"}`, expected: "field age has the wrong type"},
+		{name: "production syntax", binder: New(WithVerbosity(VerbosityProduction)), body: `{"email":`, expected: "body is not valid json"},
+		{name: "production aggregate", binder: New(WithVerbosity(VerbosityProduction), WithAggregateErrors()), body: `{"email":"jane","password":"Correct-Horse-9","handle":"Jane"}`, expected: "field Email is not a valid email address; field Handle has an invalid format"},
+		{name: "debug", binder: New(WithVerbosity(VerbosityDebug)), body: `{"email":"jane","password":"Correct-Horse-9"}`, expected: `field Email is invalid: invalid email address (got "jane")`},
+		{name: "debug password", binder: New(WithVerbosity(VerbosityDebug)), body: `{"email":"a@b.co","password":"hunter2"}`, expected: "field Password is invalid: password must be at least 8 characters"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			err = test.binder.UnmarshalBody(request, &verbositySignup{})
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestProductionKeepsCodes(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"handle":"Jane"}`))
+	require.NoError(t, err)
+	err = New(WithVerbosity(VerbosityProduction)).UnmarshalBody(request, &verbositySignup{})
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	require.Equal(t, "Email", fieldErr.Field)
+	require.Equal(t, CodeRequired, fieldErr.Code)
+}
+
+func TestProductionPanic(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	err = New(WithVerbosity(VerbosityProduction)).UnmarshalBody(request, nil)
+	require.EqualError(t, err, "request is invalid")
+}
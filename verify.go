@@ -0,0 +1,78 @@
+package reqbind
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidSignature is returned (wrapped) when a verifier rejects a body
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Verifier checks the raw body bytes before UnmarshalBody decodes them
+type Verifier interface {
+	Verify(header http.Header, body []byte) error
+}
+
+// VerifierFunc adapts a function to a Verifier
+type VerifierFunc func(header http.Header, body []byte) error
+
+func (f VerifierFunc) Verify(header http.Header, body []byte) error {
+	return f(header, body)
+}
+
+type verifierKey struct{}
+
+// WithVerifier returns a copy of the request that UnmarshalBody will verify
+// with v before decoding
+func WithVerifier(r *http.Request, v Verifier) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), verifierKey{}, v))
+}
+
+// VerifyMiddleware attaches v to every request so the route's UnmarshalBody
+// calls are verified
+func VerifyMiddleware(v Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, WithVerifier(r, v))
+		})
+	}
+}
+
+func verifierFrom(ctx context.Context) Verifier {
+	v, _ := ctx.Value(verifierKey{}).(Verifier)
+	return v
+}
+
+// HMACSHA256Verifier checks a hex encoded HMAC-SHA256 of the body sent in
+// header, after stripping prefix, e.g. GitHub's X-Hub-Signature-256 with the
+// prefix sha256=
+func HMACSHA256Verifier(header string, prefix string, secret []byte) Verifier {
+	return VerifierFunc(func(h http.Header, body []byte) error {
+		signature, ok := strings.CutPrefix(h.Get(header), prefix)
+		if !ok || signature == "" {
+			return fmt.Errorf("%w: missing %s", ErrInvalidSignature, header)
+		}
+		return VerifyHMACSHA256(secret, body, signature)
+	})
+}
+
+// VerifyHMACSHA256 compares a hex signature against the HMAC of payload in
+// constant time
+func VerifyHMACSHA256(secret []byte, payload []byte, signature string) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package reqbind
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHMACSHA256Verifier(t *testing.T) {
+	body := `{"value":"aoeu"}`
+	verifier := HMACSHA256Verifier("X-Hub-Signature-256", "sha256=", []byte("secret"))
+
+	tests := []struct {
+		signature  string
+		shouldPass bool
+	}{
+		{signature: "sha256=" + sign("secret", body), shouldPass: true},
+		{signature: "sha256=" + sign("other", body), shouldPass: false},
+		{signature: sign("secret", body), shouldPass: false},
+		{signature: "sha256=zz", shouldPass: false},
+		{signature: "", shouldPass: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.signature, func(t *testing.T) {
+			k := &struct {
+				Value string `required:"true"`
+			}{}
+			request, err := http.NewRequest("POST", "/", io.NopCloser(bytes.NewReader([]byte(body))))
+			require.NoError(t, err)
+			request.Header.Set("X-Hub-Signature-256", test.signature)
+			request = WithVerifier(request, verifier)
+			if !test.shouldPass {
+				require.ErrorIs(t, UnmarshalBody(request, k), ErrInvalidSignature)
+				return
+			}
+			require.NoError(t, UnmarshalBody(request, k))
+			require.Equal(t, "aoeu", k.Value)
+		})
+	}
+}
+
+func TestVerifyMiddleware(t *testing.T) {
+	var seen []byte
+	verifier := VerifierFunc(func(header http.Header, body []byte) error {
+		seen = body
+		return nil
+	})
+
+	handler := VerifyMiddleware(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k := &struct {
+			Value string
+		}{}
+		require.NoError(t, UnmarshalBody(r, k))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{"value":"a"}`))))
+	require.Equal(t, `{"value":"a"}`, string(seen))
+}
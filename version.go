@@ -0,0 +1,49 @@
+package reqbind
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// WithVersionRules registers rules for the struct type of v that only apply
+// when binding with BindVersion for that api version, e.g. v2 requiring a
+// field v1 didn't have:
+//
+//	reqbind.WithVersionRules(&Profile{}, "v2", reqbind.Rules{"displayName": "required"})
+func WithVersionRules(v interface{}, version string, rules Rules) Option {
+	t := indirectType(reflect.TypeOf(v))
+	return func(b *Binder) {
+		if b.versions == nil {
+			b.versions = make(map[reflect.Type]map[string]Rules)
+		}
+		if b.versions[t] == nil {
+			b.versions[t] = make(map[string]Rules)
+		}
+		b.versions[t][version] = rules
+	}
+}
+
+// BindVersion binds like Bind for one api version, which usually comes from
+// a header or the path. Fields tagged versions:"v1,v2" are only bound and
+// checked in those versions and rejected in any other, and the rules
+// registered for the version with WithVersionRules are checked last.
+func BindVersion(r *http.Request, v interface{}, version string) error {
+	return defaultBinder.BindVersion(r, v, version)
+}
+
+// BindVersion binds like the package level BindVersion
+func (b *Binder) BindVersion(r *http.Request, v interface{}, version string) (err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	if err := b.bind(r, v, func(opts *checkOptions) {
+		opts.version = version
+	}); err != nil {
+		return err
+	}
+	if rules, ok := b.versions[indirectType(reflect.TypeOf(v))][version]; ok {
+		return rules.ValidateStruct(v)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type versionedProfile struct {
+	Name        string `json:"name" versions:"v1" required:"true"`
+	DisplayName string `json:"displayName" versions:"v2"`
+	Bio         string `json:"bio"`
+}
+
+func TestBindVersion(t *testing.T) {
+	binder := New(WithVersionRules(&versionedProfile{}, "v2", Rules{"displayName": "required", "bio": "max-length=5"}))
+
+	tests := []struct {
+		name     string
+		version  string
+		body     string
+		expected versionedProfile
+		error    string
+	}{
+		{
+			name:     "v1 name",
+			version:  "v1",
+			body:     `{"name":"a","bio":"a long bio"}`,
+			expected: versionedProfile{Name: "a", Bio: "a long bio"},
+		},
+		{
+			name:    "v1 rejects v2 fields",
+			version: "v1",
+			body:    `{"name":"a","displayName":"b"}`,
+			error:   "field DisplayName is not allowed in v1",
+		},
+		{
+			name:     "v2 display name",
+			version:  "v2",
+			body:     `{"displayName":"b"}`,
+			expected: versionedProfile{DisplayName: "b"},
+		},
+		{
+			name:    "v2 requires display name",
+			version: "v2",
+			body:    `{"bio":"hi"}`,
+			error:   "field DisplayName is required",
+		},
+		{
+			name:    "v2 rules",
+			version: "v2",
+			body:    `{"displayName":"b","bio":"a long bio"}`,
+			error:   "field Bio is too long",
+		},
+		{
+			name:    "v2 rejects v1 fields",
+			version: "v2",
+			body:    `{"name":"a","displayName":"b"}`,
+			error:   "field Name is not allowed in v2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			k := &versionedProfile{}
+			err = binder.BindVersion(request, k, test.version)
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &test.expected, k)
+		})
+	}
+}
+
+func TestBindVersionUnregistered(t *testing.T) {
+	// without registered rules only the versions tags apply
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"bio":"a long bio"}`))
+	require.NoError(t, err)
+	require.NoError(t, BindVersion(request, &versionedProfile{}, "v2"))
+
+	// and plain Bind ignores them
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"a","displayName":"b"}`))
+	require.NoError(t, err)
+	require.NoError(t, Bind(request, &versionedProfile{}))
+}
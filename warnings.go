@@ -0,0 +1,106 @@
+package reqbind
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Warning is a failed warn rule. It's reported to the caller but doesn't
+// fail the request.
+type Warning struct {
+	// Path is the dotted json path of the field
+	Path string
+	// Message says what's wrong, e.g. "field Bio is too long"
+	Message string
+	// Deprecated is set when the warning is for a deprecated field
+	Deprecated bool
+	// Sunset is when a deprecated field stops working, if that's known
+	Sunset time.Time
+}
+
+// Warnings are the warnings of one request in the order the fields were
+// checked
+type Warnings []Warning
+
+func (w *Warnings) add(path string, message string) {
+	*w = append(*w, Warning{Path: path, Message: message})
+}
+
+// BindWithWarnings binds like Bind and also returns the failed warn rules.
+// A field tagged warn:"max-length=64,email" is checked against those rules
+// after its other tags, and warn:"deprecated" warns whenever it's sent,
+// which is handy for rolling out a stricter rule or retiring a parameter.
+func BindWithWarnings(r *http.Request, v interface{}) (Warnings, error) {
+	return defaultBinder.BindWithWarnings(r, v)
+}
+
+// BindWithWarnings binds like the package level BindWithWarnings
+func (b *Binder) BindWithWarnings(r *http.Request, v interface{}) (_ Warnings, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	warnings := Warnings{}
+	err = b.bind(r, v, func(opts *checkOptions) {
+		opts.warnings = &warnings
+	})
+	return warnings, err
+}
+
+// checkWarnings runs the warn rules of f, failures are added to the
+// warnings instead of being returned. Only a broken warn tag is an error.
+func checkWarnings(parent reflect.Value, f reflect.StructField, value reflect.Value, opts checkOptions, path string) error {
+	list := f.Tag.Get("warn")
+	warnings := opts.warnings
+	if list == "" || warnings == nil {
+		return nil
+	}
+
+	var rules []string
+	for _, rule := range strings.Split(list, ",") {
+		switch name, _, _ := strings.Cut(strings.TrimSpace(rule), "="); name {
+		case "alias":
+			if alias, ok := opts.presence.alias(path); ok {
+				*warnings = append(*warnings, Warning{Path: path, Message: fmt.Sprintf("field %s was sent as %s", f.Name, alias), Deprecated: true})
+			}
+		case "deprecated":
+			if opts.presence != nil && opts.presence.Has(path) {
+				*warnings = append(*warnings, Warning{Path: path, Message: fmt.Sprintf("field %s is deprecated", f.Name), Deprecated: true})
+			}
+		case "trimlower", "truncate":
+			// warnings only look, they never change the value
+			return fieldErrorf(CodeInvalidTag, f.Name, "has warn rule %s that changes the value", name)
+		default:
+			rules = append(rules, rule)
+		}
+	}
+	tag, stringOnly, err := compileRules(f.Name, strings.Join(rules, ","))
+	if err != nil {
+		return err
+	}
+	if stringOnly && indirectType(f.Type).Kind() != reflect.String {
+		return fieldErrorf(CodeInvalidType, f.Name, "must be a string")
+	}
+
+	// the rules are checked on their own, without the field's other tags
+	// and without aggregating
+	opts.warnings = nil
+	opts.aggregate = false
+	opts.requiredByDefault = false
+	rule := reflect.StructField{Name: f.Name, Type: f.Type, Tag: tag}
+	if indirectType(f.Type).Kind() == reflect.Struct {
+		// only required makes sense for a struct, and its fields have
+		// already been checked
+		if isRequired(rule, opts) && missingRequired(rule, value, opts, path) {
+			warnings.add(path, fmt.Sprintf("field %s is required", f.Name))
+		}
+		return nil
+	}
+	if err := checkField(parent, rule, value, opts, path); err != nil {
+		warnings.add(path, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type profileRequest struct {
+	Name    string `json:"name" required:"true"`
+	Bio     string `json:"bio" max-length:"100" warn:"max-length=10"`
+	Email   string `json:"email" warn:"required,email"`
+	Handle  string `json:"handle" warn:"deprecated"`
+	Address *struct {
+		City string `json:"city" warn:"max-length=3"`
+	} `json:"address" warn:"required"`
+}
+
+func TestBindWithWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		warnings Warnings
+		error    string
+	}{
+		{
+			name: "no warnings",
+			body: `{"name":"a","email":"a@b.com","address":{"city":"nyc"}}`,
+		},
+		{
+			name: "every warning",
+			body: `{"name":"a","bio":"a longer bio","email":"nope","handle":"a","address":{"city":"Portland"}}`,
+			warnings: Warnings{
+				{Path: "bio", Message: "field Bio is too long"},
+				{Path: "email", Message: "field Email is invalid: invalid email address"},
+				{Path: "handle", Message: "field Handle is deprecated", Deprecated: true},
+				{Path: "address.city", Message: "field City is too long"},
+			},
+		},
+		{
+			name: "missing",
+			body: `{"name":"a"}`,
+			warnings: Warnings{
+				{Path: "email", Message: "field Email is required"},
+				{Path: "address", Message: "field Address is required"},
+			},
+		},
+		{
+			name:  "errors still fail",
+			body:  `{"bio":"a longer bio"}`,
+			error: "field Name is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", "/", strings.NewReader(test.body))
+			require.NoError(t, err)
+			warnings, err := BindWithWarnings(request, &profileRequest{})
+			if test.error != "" {
+				require.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			if test.warnings == nil {
+				test.warnings = Warnings{}
+			}
+			require.Equal(t, test.warnings, warnings)
+		})
+	}
+}
+
+func TestWarningsDontFailBind(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a","bio":"a longer bio","handle":"a"}`))
+	require.NoError(t, err)
+	k := &profileRequest{}
+	require.NoError(t, Bind(request, k))
+	require.Equal(t, "a longer bio", k.Bio)
+}
+
+func TestWarnTagInvalid(t *testing.T) {
+	request, err := http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	_, err = BindWithWarnings(request, &struct {
+		Name string `json:"name" warn:"truncate=3"`
+	}{})
+	require.EqualError(t, err, "field Name has warn rule truncate that changes the value")
+
+	request, err = http.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	require.NoError(t, err)
+	_, err = BindWithWarnings(request, &struct {
+		Name string `json:"name" warn:"shouty"`
+	}{})
+	require.EqualError(t, err, "field Name has unknown rule shouty")
+}
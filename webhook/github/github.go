@@ -0,0 +1,63 @@
+// Package github binds and verifies GitHub webhook deliveries
+package github
+
+import (
+	"net/http"
+
+	"github.com/codeallthethingz/reqbind"
+)
+
+const (
+	SignatureHeader = "X-Hub-Signature-256"
+	EventHeader     = "X-GitHub-Event"
+	DeliveryHeader  = "X-GitHub-Delivery"
+)
+
+// Headers are the delivery headers GitHub sends with every event
+type Headers struct {
+	Event    string `header:"X-GitHub-Event" required:"true"`
+	Delivery string `header:"X-GitHub-Delivery" required:"true"`
+}
+
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type Repository struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Private  bool   `json:"private"`
+	Owner    User   `json:"owner"`
+}
+
+// Event holds the fields common to every event payload, embed it in a struct
+// to bind the event specific fields as well
+type Event struct {
+	Action     string     `json:"action"`
+	Sender     User       `json:"sender"`
+	Repository Repository `json:"repository"`
+}
+
+// PushEvent is the payload of a push event
+type PushEvent struct {
+	Event
+	Ref    string `json:"ref" required:"true"`
+	Before string `json:"before"`
+	After  string `json:"after" required:"true"`
+}
+
+// Verifier checks the X-Hub-Signature-256 header against the webhook secret
+func Verifier(secret []byte) reqbind.Verifier {
+	return reqbind.HMACSHA256Verifier(SignatureHeader, "sha256=", secret)
+}
+
+// Unmarshal verifies the delivery, binds its headers into h and the payload
+// into v
+func Unmarshal(r *http.Request, secret []byte, h *Headers, v interface{}) error {
+	if err := reqbind.UnmarshalHeaders(r, h); err != nil {
+		return err
+	}
+	return reqbind.UnmarshalBody(reqbind.WithVerifier(r, Verifier(secret)), v)
+}
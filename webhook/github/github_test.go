@@ -0,0 +1,53 @@
+package github
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestUnmarshalPush(t *testing.T) {
+	body := `{"ref":"refs/heads/main","after":"abc","sender":{"login":"octocat","id":1},"repository":{"id":2,"full_name":"octo/repo"}}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set(SignatureHeader, sign("secret", body))
+	request.Header.Set(EventHeader, "push")
+	request.Header.Set(DeliveryHeader, "72d3162e")
+
+	h := &Headers{}
+	e := &PushEvent{}
+	require.NoError(t, Unmarshal(request, []byte("secret"), h, e))
+	require.Equal(t, "push", h.Event)
+	require.Equal(t, "refs/heads/main", e.Ref)
+	require.Equal(t, "octocat", e.Sender.Login)
+	require.Equal(t, "octo/repo", e.Repository.FullName)
+}
+
+func TestUnmarshalBadSignature(t *testing.T) {
+	body := `{"ref":"refs/heads/main","after":"abc"}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set(SignatureHeader, sign("wrong", body))
+	request.Header.Set(EventHeader, "push")
+	request.Header.Set(DeliveryHeader, "72d3162e")
+
+	require.ErrorIs(t, Unmarshal(request, []byte("secret"), &Headers{}, &PushEvent{}), reqbind.ErrInvalidSignature)
+}
+
+func TestUnmarshalMissingHeaders(t *testing.T) {
+	body := `{"ref":"refs/heads/main","after":"abc"}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set(SignatureHeader, sign("secret", body))
+
+	require.Error(t, Unmarshal(request, []byte("secret"), &Headers{}, &PushEvent{}))
+}
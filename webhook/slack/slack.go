@@ -0,0 +1,69 @@
+// Package slack binds and verifies Slack Events API requests
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/codeallthethingz/reqbind"
+)
+
+const (
+	SignatureHeader = "X-Slack-Signature"
+	TimestampHeader = "X-Slack-Request-Timestamp"
+)
+
+// DefaultTolerance is how far a request timestamp can be from now, in
+// either direction, before the request is rejected as a replay
+const DefaultTolerance = 5 * time.Minute
+
+// Event is the inner event of an event_callback
+type Event struct {
+	Type    string `json:"type"`
+	User    string `json:"user"`
+	Text    string `json:"text"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// Envelope is the outer Events API payload. Challenge is only set for
+// url_verification requests, Event only for event_callback.
+type Envelope struct {
+	Type      string `json:"type" required:"true"`
+	Challenge string `json:"challenge"`
+	TeamID    string `json:"team_id"`
+	APIAppID  string `json:"api_app_id"`
+	EventID   string `json:"event_id"`
+	EventTime int64  `json:"event_time"`
+	Event     Event  `json:"event"`
+}
+
+// Verifier checks the v0 signature over "v0:timestamp:body"
+func Verifier(signingSecret []byte, tolerance time.Duration) reqbind.Verifier {
+	return reqbind.VerifierFunc(func(h http.Header, body []byte) error {
+		timestamp, err := strconv.ParseInt(h.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: missing %s", reqbind.ErrInvalidSignature, TimestampHeader)
+		}
+		if age := time.Since(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+			return fmt.Errorf("%w: timestamp is outside the tolerance", reqbind.ErrInvalidSignature)
+		}
+		payload := append([]byte(fmt.Sprintf("v0:%d:", timestamp)), body...)
+		return reqbind.HMACSHA256Verifier(SignatureHeader, "v0=", signingSecret).Verify(h, payload)
+	})
+}
+
+// Unmarshal verifies the request with DefaultTolerance and binds the
+// envelope. Event.Type is only required for event_callback requests.
+func Unmarshal(r *http.Request, signingSecret []byte) (*Envelope, error) {
+	e := &Envelope{}
+	if err := reqbind.UnmarshalBody(reqbind.WithVerifier(r, Verifier(signingSecret, DefaultTolerance)), e); err != nil {
+		return nil, err
+	}
+	if e.Type == "event_callback" && e.Event.Type == "" {
+		return nil, fmt.Errorf("field Event is required")
+	}
+	return e, nil
+}
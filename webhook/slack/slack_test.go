@@ -0,0 +1,62 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/stretchr/testify/require"
+)
+
+func signedRequest(secret string, timestamp int64, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("v0:%d:%s", timestamp, body)))
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return request
+}
+
+func TestUnmarshalEventCallback(t *testing.T) {
+	body := `{"type":"event_callback","team_id":"T1","event":{"type":"message","user":"U1","text":"hi","channel":"C1"}}`
+	e, err := Unmarshal(signedRequest("secret", time.Now().Unix(), body), []byte("secret"))
+	require.NoError(t, err)
+	require.Equal(t, "message", e.Event.Type)
+	require.Equal(t, "hi", e.Event.Text)
+}
+
+func TestUnmarshalURLVerification(t *testing.T) {
+	body := `{"type":"url_verification","challenge":"abc"}`
+	e, err := Unmarshal(signedRequest("secret", time.Now().Unix(), body), []byte("secret"))
+	require.NoError(t, err)
+	require.Equal(t, "abc", e.Challenge)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	body := `{"type":"url_verification","challenge":"abc"}`
+
+	_, err := Unmarshal(signedRequest("other", time.Now().Unix(), body), []byte("secret"))
+	require.ErrorIs(t, err, reqbind.ErrInvalidSignature)
+
+	_, err = Unmarshal(signedRequest("secret", time.Now().Add(-time.Hour).Unix(), body), []byte("secret"))
+	require.ErrorIs(t, err, reqbind.ErrInvalidSignature)
+
+	_, err = Unmarshal(signedRequest("secret", time.Now().Add(time.Hour).Unix(), body), []byte("secret"))
+	require.ErrorIs(t, err, reqbind.ErrInvalidSignature)
+
+	request := signedRequest("secret", time.Now().Unix(), body)
+	request.Header.Del(TimestampHeader)
+	_, err = Unmarshal(request, []byte("secret"))
+	require.ErrorIs(t, err, reqbind.ErrInvalidSignature)
+
+	_, err = Unmarshal(signedRequest("secret", time.Now().Unix(), `{"type":"event_callback"}`), []byte("secret"))
+	require.Error(t, err)
+}
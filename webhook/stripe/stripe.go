@@ -0,0 +1,85 @@
+// Package stripe binds and verifies Stripe webhook events
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeallthethingz/reqbind"
+)
+
+const SignatureHeader = "Stripe-Signature"
+
+// DefaultTolerance is how far a signature timestamp can be from now, in
+// either direction, before the event is rejected as a replay
+const DefaultTolerance = 5 * time.Minute
+
+// Event is a Stripe event, Data.Object holds the raw object so it can be
+// decoded into the type that matches Type
+type Event struct {
+	ID         string `json:"id" required:"true"`
+	Type       string `json:"type" required:"true"`
+	Created    int64  `json:"created"`
+	Livemode   bool   `json:"livemode"`
+	APIVersion string `json:"api_version"`
+	Data       struct {
+		Object json.RawMessage `json:"object" required:"true"`
+	} `json:"data"`
+}
+
+// Verifier checks the Stripe-Signature header. The signed payload is the
+// timestamp, a dot and the body, any of the v1 signatures may match.
+func Verifier(secret []byte, tolerance time.Duration) reqbind.Verifier {
+	return reqbind.VerifierFunc(func(h http.Header, body []byte) error {
+		timestamp, signatures, err := parseSignatureHeader(h.Get(SignatureHeader))
+		if err != nil {
+			return err
+		}
+		if age := time.Since(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+			return fmt.Errorf("%w: timestamp is outside the tolerance", reqbind.ErrInvalidSignature)
+		}
+
+		payload := append([]byte(strconv.FormatInt(timestamp, 10)+"."), body...)
+		for _, signature := range signatures {
+			if reqbind.VerifyHMACSHA256(secret, payload, signature) == nil {
+				return nil
+			}
+		}
+		return reqbind.ErrInvalidSignature
+	})
+}
+
+func parseSignatureHeader(header string) (int64, []string, error) {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, nil, fmt.Errorf("%w: invalid timestamp", reqbind.ErrInvalidSignature)
+			}
+			timestamp = t
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return 0, nil, fmt.Errorf("%w: missing %s", reqbind.ErrInvalidSignature, SignatureHeader)
+	}
+	return timestamp, signatures, nil
+}
+
+// Unmarshal verifies the event signature with DefaultTolerance and binds it
+func Unmarshal(r *http.Request, secret []byte) (*Event, error) {
+	e := &Event{}
+	if err := reqbind.UnmarshalBody(reqbind.WithVerifier(r, Verifier(secret, DefaultTolerance)), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
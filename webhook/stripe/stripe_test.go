@@ -0,0 +1,65 @@
+package stripe
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codeallthethingz/reqbind"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, timestamp int64, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, body)))
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+const eventBody = `{"id":"evt_1","type":"charge.succeeded","created":1700000000,"data":{"object":{"id":"ch_1","amount":100}}}`
+
+func TestUnmarshal(t *testing.T) {
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(eventBody)))
+	request.Header.Set(SignatureHeader, sign("whsec", time.Now().Unix(), eventBody))
+
+	e, err := Unmarshal(request, []byte("whsec"))
+	require.NoError(t, err)
+	require.Equal(t, "evt_1", e.ID)
+	require.Equal(t, "charge.succeeded", e.Type)
+	require.JSONEq(t, `{"id":"ch_1","amount":100}`, string(e.Data.Object))
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+	}{
+		{name: "wrong secret", header: sign("other", time.Now().Unix(), eventBody), body: eventBody},
+		{name: "too old", header: sign("whsec", time.Now().Add(-time.Hour).Unix(), eventBody), body: eventBody},
+		{name: "in the future", header: sign("whsec", time.Now().Add(time.Hour).Unix(), eventBody), body: eventBody},
+		{name: "missing header", header: "", body: eventBody},
+		{name: "bad timestamp", header: "t=abc,v1=00", body: eventBody},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(test.body)))
+			request.Header.Set(SignatureHeader, test.header)
+			_, err := Unmarshal(request, []byte("whsec"))
+			require.ErrorIs(t, err, reqbind.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestUnmarshalMissingFields(t *testing.T) {
+	body := `{"type":"charge.succeeded"}`
+	request := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	request.Header.Set(SignatureHeader, sign("whsec", time.Now().Unix(), body))
+	_, err := Unmarshal(request, []byte("whsec"))
+	require.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package reqbind
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotWebSocket is returned (wrapped) when BindWebSocket gets a request
+// that isn't a valid websocket upgrade
+var ErrNotWebSocket = errors.New("request is not a websocket upgrade")
+
+// WebSocket is what a websocket upgrade request asks for
+type WebSocket struct {
+	// Key is the Sec-WebSocket-Key the accept header is made from
+	Key string
+	// Origin is the Origin header, check it before upgrading
+	Origin string
+	// Subprotocols are the Sec-WebSocket-Protocol values in the client's
+	// order of preference
+	Subprotocols []string
+	// Extensions are the names of the Sec-WebSocket-Extensions asked for
+	Extensions []string
+}
+
+// Subprotocol returns the first of the client's subprotocols that's in
+// supported, or "" when there's none to agree on
+func (ws WebSocket) Subprotocol(supported ...string) string {
+	for _, protocol := range ws.Subprotocols {
+		for _, s := range supported {
+			if protocol == s {
+				return protocol
+			}
+		}
+	}
+	return ""
+}
+
+// BindWebSocket checks that r is a websocket upgrade and binds its query and
+// header tagged fields into v with Bind, so a bad request is rejected before
+// the connection is upgraded. v can be nil when there's nothing to bind.
+func BindWebSocket(r *http.Request, v interface{}) (WebSocket, error) {
+	return defaultBinder.BindWebSocket(r, v)
+}
+
+// BindWebSocket binds an upgrade request like the package level
+// BindWebSocket
+func (b *Binder) BindWebSocket(r *http.Request, v interface{}) (_ WebSocket, err error) {
+	defer b.redact(&err)
+	defer recoverPanic(&err)
+	r, done := b.withBindTimeout(r)
+	defer done(&err)
+	switch {
+	case r.Method != http.MethodGet:
+		return WebSocket{}, fmt.Errorf("%w: method must be GET", ErrNotWebSocket)
+	case !headerHasToken(r.Header, "Connection", "upgrade"):
+		return WebSocket{}, fmt.Errorf("%w: missing Connection upgrade", ErrNotWebSocket)
+	case !headerHasToken(r.Header, "Upgrade", "websocket"):
+		return WebSocket{}, fmt.Errorf("%w: missing Upgrade websocket", ErrNotWebSocket)
+	case r.Header.Get("Sec-WebSocket-Version") != "13":
+		return WebSocket{}, fmt.Errorf("%w: unsupported Sec-WebSocket-Version", ErrNotWebSocket)
+	}
+	ws := WebSocket{
+		Key:          r.Header.Get("Sec-WebSocket-Key"),
+		Origin:       r.Header.Get("Origin"),
+		Subprotocols: headerTokens(r.Header, "Sec-WebSocket-Protocol"),
+	}
+	if key, err := base64.StdEncoding.DecodeString(ws.Key); err != nil || len(key) != 16 {
+		return WebSocket{}, fmt.Errorf("%w: invalid Sec-WebSocket-Key", ErrNotWebSocket)
+	}
+	for _, extension := range headerTokens(r.Header, "Sec-WebSocket-Extensions") {
+		name, _, _ := strings.Cut(extension, ";")
+		ws.Extensions = append(ws.Extensions, strings.TrimSpace(name))
+	}
+
+	if v != nil {
+		if err := b.Bind(r, v); err != nil {
+			return WebSocket{}, err
+		}
+	}
+	return ws, nil
+}
+
+// headerTokens splits the comma separated values of every name header
+func headerTokens(header http.Header, name string) []string {
+	var tokens []string
+	for _, value := range header.Values(name) {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// headerHasToken reports whether a name header lists token, ignoring case
+func headerHasToken(header http.Header, name string, token string) bool {
+	for _, t := range headerTokens(header, name) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
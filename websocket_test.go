@@ -0,0 +1,78 @@
+package reqbind
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type websocketParams struct {
+	Room  string `json:"room" required:"true"`
+	Token string `json:"token" header:"X-Token" required:"true"`
+}
+
+func websocketRequest() *http.Request {
+	r := httptest.NewRequest("GET", "/ws?room=lobby", nil)
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Origin", "https://example.com")
+	r.Header.Add("Sec-WebSocket-Protocol", "chat.v2, chat.v1")
+	r.Header.Add("Sec-WebSocket-Protocol", "json")
+	r.Header.Set("Sec-WebSocket-Extensions", "permessage-deflate; client_max_window_bits")
+	r.Header.Set("X-Token", "secret")
+	return r
+}
+
+func TestBindWebSocket(t *testing.T) {
+	params := &websocketParams{}
+	ws, err := BindWebSocket(websocketRequest(), params)
+	require.NoError(t, err)
+	require.Equal(t, &websocketParams{Room: "lobby", Token: "secret"}, params)
+	require.Equal(t, WebSocket{
+		Key:          "dGhlIHNhbXBsZSBub25jZQ==",
+		Origin:       "https://example.com",
+		Subprotocols: []string{"chat.v2", "chat.v1", "json"},
+		Extensions:   []string{"permessage-deflate"},
+	}, ws)
+	require.Equal(t, "chat.v1", ws.Subprotocol("json", "chat.v1"))
+	require.Equal(t, "", ws.Subprotocol("xml"))
+}
+
+func TestBindWebSocketInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(r *http.Request)
+		expected string
+	}{
+		{name: "method", change: func(r *http.Request) { r.Method = "POST" }, expected: "request is not a websocket upgrade: method must be GET"},
+		{name: "connection", change: func(r *http.Request) { r.Header.Set("Connection", "keep-alive") }, expected: "request is not a websocket upgrade: missing Connection upgrade"},
+		{name: "upgrade", change: func(r *http.Request) { r.Header.Del("Upgrade") }, expected: "request is not a websocket upgrade: missing Upgrade websocket"},
+		{name: "version", change: func(r *http.Request) { r.Header.Set("Sec-WebSocket-Version", "8") }, expected: "request is not a websocket upgrade: unsupported Sec-WebSocket-Version"},
+		{name: "key", change: func(r *http.Request) { r.Header.Set("Sec-WebSocket-Key", "c2hvcnQ=") }, expected: "request is not a websocket upgrade: invalid Sec-WebSocket-Key"},
+		{name: "params", change: func(r *http.Request) { r.Header.Del("X-Token") }, expected: "field Token is required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := websocketRequest()
+			test.change(r)
+			_, err := BindWebSocket(r, &websocketParams{})
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestWebSocketWriteError(t *testing.T) {
+	r := websocketRequest()
+	r.Method = "POST"
+	_, err := BindWebSocket(r, nil)
+	require.True(t, errors.Is(err, ErrNotWebSocket))
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, r, err)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
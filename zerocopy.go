@@ -0,0 +1,287 @@
+package reqbind
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// WithZeroCopyStrings binds the top level string fields of a json body as
+// views over the body's bytes instead of copying each one. This is unsafe
+// in one way: a view keeps the whole body in memory for as long as the
+// string is referenced. Bound strings are fine to use while the request is
+// handled, call CloneStrings before keeping the struct for longer, e.g. in
+// a cache. Values with escapes, and fields with their own decoding, are
+// copied as usual.
+func WithZeroCopyStrings() Option {
+	return func(b *Binder) {
+		b.zeroCopy = true
+	}
+}
+
+// CloneStrings copies every string reachable from v, so none of them are
+// views over a request body anymore
+func CloneStrings(v interface{}) {
+	cloneStrings(reflect.ValueOf(v))
+}
+
+func cloneStrings(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			if value.Kind() == reflect.Interface && value.Elem().Kind() == reflect.String && value.CanSet() {
+				clone := reflect.New(value.Elem().Type()).Elem()
+				clone.SetString(strings.Clone(value.Elem().String()))
+				value.Set(clone)
+				return
+			}
+			cloneStrings(value.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				cloneStrings(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			cloneStrings(value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			elem := reflect.New(value.Type().Elem()).Elem()
+			elem.Set(value.MapIndex(key))
+			cloneStrings(elem)
+			newKey := reflect.New(key.Type()).Elem()
+			newKey.Set(key)
+			cloneStrings(newKey)
+			value.SetMapIndex(key, reflect.Value{})
+			value.SetMapIndex(newKey, elem)
+		}
+	case reflect.String:
+		if value.CanSet() {
+			value.SetString(strings.Clone(value.String()))
+		}
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// decodeBody is decodeBody, with zero copy strings when they're on
+func (b *Binder) decodeBody(bodyBytes []byte, v interface{}) error {
+	if !b.zeroCopy {
+		return decodeBody(bodyBytes, v, b.useNumber)
+	}
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return decodeBody(bodyBytes, v, b.useNumber)
+	}
+	pairs, ok := scanObject(bodyBytes)
+	if !ok {
+		// let the decoder report whatever is wrong
+		return decodeBody(bodyBytes, v, b.useNumber)
+	}
+
+	// match each plain string value to a field, a field sent twice is left
+	// to the decoder
+	type view struct {
+		field int
+		pair  int
+	}
+	var views []view
+	matched := make(map[int]int)
+	t := rv.Type()
+	for p, pair := range pairs {
+		key := bodyBytes[pair.key[0]:pair.key[1]]
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !strings.EqualFold(string(key), jsonName(f)) {
+				continue
+			}
+			matched[i]++
+			if zeroCopyField(f) && plainString(bodyBytes[pair.value[0]:pair.value[1]]) {
+				views = append(views, view{field: i, pair: p})
+			}
+		}
+	}
+	skip := make(map[int]bool)
+	for _, view := range views {
+		if matched[view.field] == 1 {
+			skip[view.pair] = true
+		}
+	}
+	if len(skip) == 0 {
+		return decodeBody(bodyBytes, v, b.useNumber)
+	}
+
+	for _, view := range views {
+		if !skip[view.pair] {
+			continue
+		}
+		// the value without its quotes
+		start, end := pairs[view.pair].value[0]+1, pairs[view.pair].value[1]-1
+		field := rv.Field(view.field)
+		if start == end {
+			field.SetString("")
+			continue
+		}
+		field.SetString(unsafe.String(&bodyBytes[start], end-start))
+	}
+
+	// decode the rest without the viewed values
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.WriteByte('{')
+	for p, pair := range pairs {
+		if skip[p] {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(bodyBytes[pair.key[0]-1 : pair.value[1]])
+	}
+	buf.WriteByte('}')
+	return decodeBody(buf.Bytes(), v, b.useNumber)
+}
+
+// zeroCopyField is true for plain string fields encoding/json would copy
+// straight in
+func zeroCopyField(f reflect.StructField) bool {
+	if f.Type.Kind() != reflect.String || f.Anonymous || isIgnored(f) || isRequestField(f) || f.Tag.Get("raw") != "" {
+		return false
+	}
+	if strings.Contains(f.Tag.Get("json"), ",string") {
+		return false
+	}
+	pt := reflect.PtrTo(f.Type)
+	return !pt.Implements(jsonUnmarshalerType) && !pt.Implements(textUnmarshalerType)
+}
+
+// plainString is true for a quoted json string that needs no unescaping
+func plainString(value []byte) bool {
+	if len(value) < 2 || value[0] != '"' {
+		return false
+	}
+	inner := value[1 : len(value)-1]
+	for _, c := range inner {
+		if c == '\\' || c < 0x20 {
+			return false
+		}
+	}
+	return utf8.Valid(inner)
+}
+
+// jsonPair is the byte offsets of one key and value in a json object, the
+// key without its quotes and the value as sent
+type jsonPair struct {
+	key   [2]int
+	value [2]int
+}
+
+// scanObject finds the top level keys and values of a json object without
+// decoding it. It gives up on anything it doesn't understand.
+func scanObject(data []byte) ([]jsonPair, bool) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil, false
+	}
+	var pairs []jsonPair
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return pairs, skipSpace(data, i+1) == len(data)
+	}
+	for {
+		if i >= len(data) || data[i] != '"' {
+			return nil, false
+		}
+		keyEnd, ok := skipString(data, i)
+		if !ok || bytes.IndexByte(data[i:keyEnd], '\\') >= 0 {
+			return nil, false
+		}
+		pair := jsonPair{key: [2]int{i + 1, keyEnd - 1}}
+		i = skipSpace(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return nil, false
+		}
+		i = skipSpace(data, i+1)
+		valueEnd, ok := skipValue(data, i)
+		if !ok {
+			return nil, false
+		}
+		pair.value = [2]int{i, valueEnd}
+		pairs = append(pairs, pair)
+
+		i = skipSpace(data, valueEnd)
+		if i >= len(data) {
+			return nil, false
+		}
+		switch data[i] {
+		case ',':
+			i = skipSpace(data, i+1)
+		case '}':
+			return pairs, skipSpace(data, i+1) == len(data)
+		default:
+			return nil, false
+		}
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipString returns the offset just past the string starting at i
+func skipString(data []byte, i int) (int, bool) {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, true
+		}
+	}
+	return 0, false
+}
+
+// skipValue returns the offset just past the value starting at i
+func skipValue(data []byte, i int) (int, bool) {
+	if i >= len(data) {
+		return 0, false
+	}
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				end, ok := skipString(data, j)
+				if !ok {
+					return 0, false
+				}
+				j = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, true
+				}
+			}
+		}
+		return 0, false
+	}
+	j := i
+	for j < len(data) && !strings.ContainsRune(",}] \t\n\r", rune(data[j])) {
+		j++
+	}
+	return j, j > i
+}
@@ -0,0 +1,111 @@
+package reqbind
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+type zeroCopyRequest struct {
+	Name    string            `json:"name" required:"true"`
+	Email   string            `json:"email" trimlower:"true"`
+	Note    string            `json:"note"`
+	Count   int               `json:"count"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Address struct {
+		City string `json:"city"`
+	} `json:"address"`
+}
+
+func TestZeroCopyMatchesDecoding(t *testing.T) {
+	bodies := []string{
+		`{"name":"a","email":" Bob@Example.com ","note":"","count":3}`,
+		`{"name":"aéb","note":"tab\there","tags":["x","y"]}`,
+		` { "NAME" : "upper" , "labels" : {"k":"v"}, "address": {"city": "Portland"} } `,
+		`{"name":"first","name":"second"}`,
+		`{"name":"caf` + "é" + `","note":"{not an object}"}`,
+		`{"name":"a","count":"nope"}`,
+		`{"name":"a",}`,
+		`{"name":"a"} trailing`,
+		`{}`,
+	}
+	for _, body := range bodies {
+		expected := &zeroCopyRequest{}
+		request, err := http.NewRequest("POST", "/", strings.NewReader(body))
+		require.NoError(t, err)
+		expectedErr := UnmarshalBody(request, expected)
+
+		actual := &zeroCopyRequest{}
+		request, err = http.NewRequest("POST", "/", strings.NewReader(body))
+		require.NoError(t, err)
+		actualErr := New(WithZeroCopyStrings()).UnmarshalBody(request, actual)
+
+		require.Equal(t, expectedErr == nil, actualErr == nil, body)
+		require.Equal(t, expected, actual, body)
+	}
+}
+
+func TestZeroCopyViews(t *testing.T) {
+	body := []byte(`{"name":"alice","note":"line\nbreak","tags":["a"]}`)
+	k := &zeroCopyRequest{}
+	require.NoError(t, New(WithZeroCopyStrings()).decodeBody(body, k))
+	require.Equal(t, "alice", k.Name)
+	require.Equal(t, "line\nbreak", k.Note)
+
+	// plain values point into the body, escaped ones are copied
+	require.True(t, within(body, k.Name))
+	require.False(t, within(body, k.Note))
+
+	CloneStrings(k)
+	require.Equal(t, "alice", k.Name)
+	require.False(t, within(body, k.Name))
+}
+
+func TestCloneStrings(t *testing.T) {
+	body := []byte(`abcdef`)
+	view := unsafe.String(&body[0], 3)
+	v := &struct {
+		S     string
+		P     *string
+		L     []string
+		M     map[string]string
+		I     interface{}
+		inner string
+	}{S: view, P: &view, L: []string{view}, M: map[string]string{view: view}, I: view, inner: view}
+
+	CloneStrings(v)
+	require.False(t, within(body, v.S))
+	require.False(t, within(body, *v.P))
+	require.False(t, within(body, v.L[0]))
+	for key, value := range v.M {
+		require.False(t, within(body, key))
+		require.False(t, within(body, value))
+	}
+	require.False(t, within(body, v.I.(string)))
+	require.Equal(t, "abc", v.S)
+}
+
+func TestScanObject(t *testing.T) {
+	pairs, ok := scanObject([]byte(` {"a" : 1, "b":{"c":[1,"]"]}, "d":"x\"y"} `))
+	require.True(t, ok)
+	require.Len(t, pairs, 3)
+
+	for _, bad := range []string{``, `[]`, `{"a"}`, `{"a":}`, `{"a":1,}`, `{"a":1`, `{"a\"b":1}`, `{} {}`} {
+		_, ok := scanObject([]byte(bad))
+		require.False(t, ok, bad)
+	}
+}
+
+// within reports whether s points into b
+func within(b []byte, s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	start := uintptr(unsafe.Pointer(&b[0]))
+	p := uintptr(unsafe.Pointer(unsafe.StringData(s)))
+	return p >= start && p < start+uintptr(len(b))
+}